@@ -1,6 +1,29 @@
 package main
 
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+)
+
 type Config struct {
-	PublicURL  string `yaml:"public_url" validate:"required"`
-	ServerPort int    `yaml:"server_port" validate:"required"`
+	PublicURL        string `yaml:"public_url" validate:"required"`
+	ServerPort       int    `yaml:"server_port" validate:"required"`
+	ElasticsearchURL string `yaml:"elasticsearch_url" validate:"required"`
+	// Settings of the wave data indices created from the index template.
+	ElasticsearchShards   int `yaml:"elasticsearch_shards" validate:"required,min=1"`
+	ElasticsearchReplicas int `yaml:"elasticsearch_replicas" validate:"min=0"`
+
+	DBUser     string `yaml:"db_user" validate:"required"`
+	DBPassword string `yaml:"db_password" validate:"required"`
+	DBHost     string `yaml:"db_host" validate:"required"`
+	DBPort     string `yaml:"db_port" validate:"required,numeric"`
+	DBName     string `yaml:"db_name" validate:"required"`
+
+	// Age from which the latest observation of a campaign is stale, Candhis publishes one every 30 minutes.
+	LatestStaleAfter time.Duration `yaml:"latest_stale_after" validate:"required,gt=0"`
+	// Time the latest observation of a campaign is served from memory before it is read again.
+	LatestCacheTTL time.Duration `yaml:"latest_cache_ttl" validate:"required,gt=0"`
+
+	AlertWebhook cmdconfig.AlertWebhookHostsConfig `yaml:"alert_webhook" validate:"required"`
 }
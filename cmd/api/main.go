@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/elastic/go-elasticsearch/v8"
 	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
 	"github.com/tul1/candhis_api/internal/pkg/configuration"
+	"github.com/tul1/candhis_api/internal/pkg/db"
 	"github.com/tul1/candhis_api/internal/pkg/logger"
 	"github.com/tul1/candhis_api/internal/pkg/server"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run serves the API until it is interrupted, and returns the exit code of the process.
+func run() int {
 	log := logger.NewWithDefaultLogger()
 
 	// Parse the config file path from the command line arguments
@@ -23,18 +34,53 @@ func main() {
 	config, err := configuration.Load[Config](*configFile)
 	if err != nil {
 		log.Errorf("Configuration error: %v", err)
-		return
+		return appmodel.ExitCodeConfiguration
 	}
 
 	// Create Gin server
 	s, err := server.NewGinServer(log, config.PublicURL, config.ServerPort)
 	if err != nil {
 		log.Errorf("Failed to create Gin server: %v", err)
-		return
+		return appmodel.ExitCodeConfiguration
+	}
+
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{config.ElasticsearchURL}})
+	if err != nil {
+		log.Errorf("Failed to create Elasticsearch client: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// The API knows no campaign, it checks the mappings of the indices already covered by the index template
+	err = persistence.NewWaveDataIndexBootstrapper(esClient, config.ElasticsearchShards, config.ElasticsearchReplicas).
+		Bootstrap(context.Background(), nil)
+	if err != nil {
+		log.Errorf("Elasticsearch index bootstrap error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+
+	// Create connect to the PostgreSQL database
+	dbConn, err := db.NewDBConnection(
+		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName, db.DefaultDBConnector, log)
+	if err != nil {
+		log.Errorf("Database connection error: %v", err)
+		return appmodel.ExitCodeStorageUnavailable
 	}
+	defer dbConn.CloseWithLog()
 
 	// Register candhis API handlers
-	_ = candhisapi.NewCandhisAPI(s.GetRouter())
+	waveDataRepo := persistence.NewWaveData(esClient)
+	scrapeRunRepo := persistence.NewScrapeRun(dbConn.DB)
+	_ = candhisapi.NewCandhisAPI(s.GetRouter(), candhisapi.Deps{
+		WaveData:          waveDataRepo,
+		ScrapeRun:         scrapeRunRepo,
+		Station:           persistence.NewStation(dbConn.DB),
+		AlertRule:         persistence.NewAlertRule(dbConn.DB),
+		AlertEvent:        persistence.NewAlertEvent(dbConn.DB),
+		AlertWebhookHosts: config.AlertWebhook.Hosts(),
+		Spectrum:          persistence.NewSpectrum(esClient),
+		LatestWaveData:    service.NewLatestWaveData(waveDataRepo, scrapeRunRepo, config.LatestStaleAfter, config.LatestCacheTTL),
+		GapAnalyser:       service.NewGapAnalyser(waveDataRepo),
+	})
 
 	// Start server
 	errCh := make(chan error)
@@ -59,6 +105,7 @@ func main() {
 	// Stop server
 	if err = s.Close(); err != nil {
 		log.Errorf("Error while closing the application: %s\n", err)
-		os.Exit(1)
+		return appmodel.ExitCodeFailure
 	}
+	return 0
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/pkg/runner"
+)
+
+// runBackfill ingests the archived observations of a campaign between two days included. An interrupted backfill
+// stops after its current chunk and is resumed by running it again with the same days. With a gap analyser, only the
+// days missing observations are backfilled. It returns the exit code of the process.
+func runBackfill(
+	ctx context.Context,
+	log *logrus.Logger,
+	candhisBackfill service.CandhisBackfill,
+	gapAnalyser service.GapAnalyser,
+	campaigns []appmodel.Campaign,
+	buoyID, firstDay, lastDay string,
+	chunkDays int,
+) int {
+	campaign, from, to, err := backfillParameters(campaigns, buoyID, firstDay, lastDay)
+	if err != nil {
+		log.Errorf("Backfill configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logCampaign := log.WithFields(logrus.Fields{
+		"campaign": campaign.Name(),
+		"buoy_id":  campaign.BuoyID(),
+		"index":    campaign.IndexName(),
+	})
+
+	ranges := []appmodel.DayRange{{From: from, To: to}}
+	if gapAnalyser != nil {
+		ranges, err = gapDayRanges(ctx, gapAnalyser, campaign, from, to)
+		if err != nil {
+			logCampaign.Errorf("Failed analysing campaign gaps: %v", err)
+			return appmodel.ExitCode(err)
+		}
+		logCampaign.WithField("ranges", len(ranges)).Info("Analysed campaign gaps")
+	}
+
+	for _, dayRange := range ranges {
+		logRange := logCampaign.WithFields(logrus.Fields{
+			"from": dayRange.From.Format(time.DateOnly),
+			"to":   dayRange.To.AddDate(0, 0, -1).Format(time.DateOnly),
+		})
+
+		logRange.Info("Start backfilling campaign from Candhis web")
+		report, err := candhisBackfill.Backfill(ctx, campaign, dayRange.From, dayRange.To, chunkDays)
+		if report.LayoutChanged {
+			logRange.Warnf("Layout of campaign table changed, fingerprint %s", report.LayoutFingerprint)
+		}
+		for _, rejected := range report.Rejected {
+			logRange.Warnf("Rejected row %d of campaign table: %s", rejected.Row, rejected.Reason)
+		}
+		for _, empty := range report.EmptyPeriods {
+			logRange.Warnf("No archived observation from %s to %s, the buoy may have been down",
+				empty.From.Format(time.DateOnly), empty.To.AddDate(0, 0, -1).Format(time.DateOnly))
+		}
+		logRange = logRange.WithFields(runner.IngestionReportFields(report))
+		if err != nil {
+			logRange.Errorf("Failed backfilling campaign, run it again to resume: %v", err)
+			return appmodel.ExitCode(err)
+		}
+		logRange.Info("Finished backfilling campaign successfully")
+	}
+	return 0
+}
+
+// gapDayRanges returns the days of the [from, to) range that miss observations, analysed MaxGapQueryPeriod at a time.
+func gapDayRanges(
+	ctx context.Context,
+	gapAnalyser service.GapAnalyser,
+	campaign appmodel.Campaign,
+	from, to time.Time,
+) ([]appmodel.DayRange, error) {
+	var ranges []appmodel.DayRange
+	for windowFrom := from; windowFrom.Before(to); {
+		windowTo := windowFrom.Add(appmodel.MaxGapQueryPeriod)
+		if windowTo.After(to) {
+			windowTo = to
+		}
+
+		query, err := appmodel.NewGapQuery(&windowFrom, &windowTo, windowTo)
+		if err != nil {
+			return nil, err
+		}
+		report, err := gapAnalyser.Analyse(ctx, campaign.IndexName(), query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dayRange := range report.MissingDayRanges() {
+			if len(ranges) > 0 && !dayRange.From.After(ranges[len(ranges)-1].To) {
+				ranges[len(ranges)-1].To = dayRange.To
+				continue
+			}
+			ranges = append(ranges, dayRange)
+		}
+		windowFrom = windowTo
+	}
+
+	return ranges, nil
+}
+
+func backfillParameters(
+	campaigns []appmodel.Campaign,
+	buoyID, firstDay, lastDay string,
+) (appmodel.Campaign, time.Time, time.Time, error) {
+	var campaign *appmodel.Campaign
+	for i := range campaigns {
+		if campaigns[i].BuoyID() == buoyID {
+			campaign = &campaigns[i]
+			break
+		}
+	}
+	if campaign == nil {
+		return appmodel.Campaign{}, time.Time{}, time.Time{}, fmt.Errorf("unknown campaign %s", buoyID)
+	}
+
+	from, err := time.Parse(time.DateOnly, firstDay)
+	if err != nil {
+		return appmodel.Campaign{}, time.Time{}, time.Time{}, fmt.Errorf("invalid backfill-from: %w", err)
+	}
+
+	last, err := time.Parse(time.DateOnly, lastDay)
+	if err != nil {
+		return appmodel.Campaign{}, time.Time{}, time.Time{}, fmt.Errorf("invalid backfill-to: %w", err)
+	}
+
+	return *campaign, from, last.AddDate(0, 0, 1), nil
+}
@@ -1,5 +1,7 @@
 package main
 
+import "github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+
 type Config struct {
 	DBUser           string `yaml:"db_user" validate:"required"`
 	DBPassword       string `yaml:"db_password" validate:"required"`
@@ -7,4 +9,19 @@ type Config struct {
 	DBPort           string `yaml:"db_port" validate:"required,numeric"`
 	DBName           string `yaml:"db_name" validate:"required"`
 	ElasticsearchURL string `yaml:"elasticsearch_url" validate:"required"`
+	// Settings of the wave data indices created from the index template.
+	ElasticsearchShards   int `yaml:"elasticsearch_shards" validate:"required,min=1"`
+	ElasticsearchReplicas int `yaml:"elasticsearch_replicas" validate:"min=0"`
+
+	// SessionScraper obtains the Candhis session ID with plain HTTP requests ("http") or with the headless Chrome of
+	// ChromeURL ("chrome", the default).
+	SessionScraper   string                      `yaml:"session_scraper" validate:"omitempty,oneof=chrome http"`
+	ChromeURL        string                      `yaml:"chrome_url" validate:"required_unless=SessionScraper http"`
+	Chrome           cmdconfig.ChromeConfig      `yaml:"chrome"`
+	CandhisHTTP      cmdconfig.CandhisHTTPConfig `yaml:"candhis_http"`
+	SessionTargetWeb string                      `yaml:"session_target_web" validate:"required,url"`
+	Campaigns        []cmdconfig.CampaignConfig  `yaml:"campaigns" validate:"required,min=1,dive"`
+
+	AlertWebhook   cmdconfig.AlertWebhookConfig   `yaml:"alert_webhook" validate:"required"`
+	QualityControl cmdconfig.QualityControlConfig `yaml:"quality_control" validate:"required"`
 }
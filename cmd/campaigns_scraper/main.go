@@ -4,29 +4,59 @@ import (
 	"context"
 	"flag"
 	"net/http"
+	"os"
 
 	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/service"
 	"github.com/tul1/candhis_api/internal/infrastructure/client"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
 	"github.com/tul1/candhis_api/internal/pkg/configuration"
 	"github.com/tul1/candhis_api/internal/pkg/db"
 	"github.com/tul1/candhis_api/internal/pkg/logger"
+	"github.com/tul1/candhis_api/internal/pkg/runner"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run scrapes, backfills or downloads the spectra of the campaigns, and returns the exit code of the process.
+func run() int {
 	log := logger.NewWithDefaultLogger()
 	ctx := context.Background()
 
-	// Parse the config file path from the command line arguments
+	// Parse the config file path and the backfill options from the command line arguments
 	configFile := flag.String("config", "", "Path to the configuration file")
+	backfillCampaign := flag.String("backfill-campaign", "",
+		"Buoy ID of the campaign to backfill, scrapes the current campaign pages when empty")
+	backfillFrom := flag.String("backfill-from", "", "First day to backfill, YYYY-MM-DD")
+	backfillTo := flag.String("backfill-to", "", "Last day to backfill, YYYY-MM-DD")
+	backfillChunkDays := flag.Int("backfill-chunk-days", 7, "Number of days requested to Candhis at a time")
+	backfillGapsOnly := flag.Bool("backfill-gaps-only", false,
+		"Only backfill the days missing observations between backfill-from and backfill-to")
+	spectra := flag.Bool("spectra", false,
+		"Download the spectra of yesterday and today of the campaigns instead of scraping their wave data tables")
 	flag.Parse()
 
 	// Load configuration
 	config, err := configuration.Load[Config](*configFile)
 	if err != nil {
 		log.Errorf("Configuration error: %v", err)
-		return
+		return appmodel.ExitCodeConfiguration
+	}
+
+	campaigns, err := cmdconfig.Campaigns(config.Campaigns)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	qualityControl, err := config.QualityControl.QualityControl()
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
 	}
 
 	// Create cConnect to the PostgreSQL database
@@ -34,32 +64,99 @@ func main() {
 		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName, db.DefaultDBConnector, log)
 	if err != nil {
 		log.Errorf("Database connection error: %v", err)
-		return
+		return appmodel.ExitCodeStorageUnavailable
 	}
 	defer dbConn.CloseWithLog()
 
 	// Create candhis scraper service
 	httpClient := http.Client{}
 	defer httpClient.CloseIdleConnections()
+	candhisHTTPClient := client.NewCandhisHTTPClient(&httpClient, config.CandhisHTTP.Options())
 
 	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{config.ElasticsearchURL}})
 	if err != nil {
 		log.Errorf("Failed to create Elasticsearch client: %v", err)
-		return
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// Install the index template before writing observations, and refuse to write into drifted indices
+	err = persistence.NewWaveDataIndexBootstrapper(esClient, config.ElasticsearchShards, config.ElasticsearchReplicas).
+		Bootstrap(ctx, appmodel.CampaignIndexNames(campaigns))
+	if err != nil {
+		log.Errorf("Elasticsearch index bootstrap error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+
+	// The session ID scraper is only used to renew the session ID when Candhis rejects the stored one
+	sessionIDWebScraper, err := client.NewSessionIDWebScraper(
+		&httpClient, candhisHTTPClient, config.SessionScraper, config.ChromeURL, config.Chrome.Options(), config.SessionTargetWeb)
+	if err != nil {
+		log.Errorf("Session ID scraper initialization error: %v", err)
+		return appmodel.ExitCode(err)
 	}
 
+	if *backfillCampaign != "" {
+		candhisBackfill := service.NewCandhisBackfill(
+			persistence.NewSessionID(dbConn.DB),
+			persistence.NewWaveData(esClient),
+			client.NewCandhisCampaignsWebScraper(candhisHTTPClient),
+			sessionIDWebScraper,
+			persistence.NewBackfillCheckpoint(dbConn.DB),
+			persistence.NewScrapeRun(dbConn.DB),
+			qualityControl,
+		)
+		var gapAnalyser service.GapAnalyser
+		if *backfillGapsOnly {
+			gapAnalyser = service.NewGapAnalyser(persistence.NewWaveData(esClient))
+		}
+		return runBackfill(ctx, log, candhisBackfill, gapAnalyser, campaigns, *backfillCampaign, *backfillFrom,
+			*backfillTo, *backfillChunkDays)
+	}
+
+	if *spectra {
+		err = persistence.NewSpectrumIndexBootstrapper(esClient, config.ElasticsearchShards, config.ElasticsearchReplicas).
+			Bootstrap(ctx)
+		if err != nil {
+			log.Errorf("Elasticsearch spectra index bootstrap error: %v", err)
+			return appmodel.ExitCode(err)
+		}
+
+		candhisSpectraScraper := service.NewCandhisSpectraScraper(
+			persistence.NewSessionID(dbConn.DB),
+			persistence.NewSpectrum(esClient),
+			client.NewCandhisSpectraDownloader(candhisHTTPClient),
+			sessionIDWebScraper,
+			persistence.NewScrapeRun(dbConn.DB),
+			campaigns,
+		)
+		return runSpectra(ctx, log, candhisSpectraScraper)
+	}
+
+	waveDataRepo := persistence.NewWaveData(esClient)
 	candhisCampaignsScraper := service.NewCandhisCampaignsScraper(
 		persistence.NewSessionID(dbConn.DB),
-		persistence.NewWaveData(esClient),
-		client.NewCandhisCampaignsWebScraper(&httpClient),
+		waveDataRepo,
+		client.NewCandhisCampaignsWebScraper(candhisHTTPClient),
+		sessionIDWebScraper,
+		persistence.NewScrapeRun(dbConn.DB),
+		qualityControl,
+		campaigns,
+	)
+	alertEvaluator := service.NewAlertEvaluator(
+		persistence.NewAlertRule(dbConn.DB),
+		persistence.NewAlertEvent(dbConn.DB),
+		waveDataRepo,
+		client.NewAlertWebhook(&httpClient, config.AlertWebhook.Secret, config.AlertWebhook.Hosts(),
+			config.AlertWebhook.Attempts, config.AlertWebhook.Backoff),
 	)
 
 	// Scraping and store campaigns from Candhis web
 	log.Info("Start scraping Candhis web to fetch and store wave data from campaigns")
-	err = candhisCampaignsScraper.FetchAndStoreWaveData(ctx)
+	err = runner.Campaigns(log, candhisCampaignsScraper, alertEvaluator)(ctx)
 	if err != nil {
 		log.Errorf("Failed scraping Candhis web to fetch and store wave data from campaigns: %v", err)
-		return
+		return appmodel.ExitCode(err)
 	}
 	log.Info("Finished scraping Candhis web to fetch and store wave data from campaigns Successfully")
+	return 0
 }
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/pkg/runner"
+)
+
+// runSpectra downloads and stores the spectra of the campaigns, and returns the exit code of the process.
+func runSpectra(ctx context.Context, log *logrus.Logger, candhisSpectraScraper service.CandhisSpectraScraper) int {
+	log.Info("Start downloading Candhis spectral files to fetch and store spectra from campaigns")
+	err := runner.Spectra(log, candhisSpectraScraper)(ctx)
+	if err != nil {
+		log.Errorf("Failed downloading Candhis spectral files to fetch and store spectra from campaigns: %v", err)
+		return appmodel.ExitCode(err)
+	}
+	log.Info("Finished downloading Candhis spectral files to fetch and store spectra from campaigns successfully")
+	return 0
+}
@@ -0,0 +1,20 @@
+package main
+
+import "github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+
+type Config struct {
+	DBUser     string `yaml:"db_user" validate:"required"`
+	DBPassword string `yaml:"db_password" validate:"required"`
+	DBHost     string `yaml:"db_host" validate:"required"`
+	DBPort     string `yaml:"db_port" validate:"required,numeric"`
+	DBName     string `yaml:"db_name" validate:"required"`
+
+	// SessionScraper obtains the Candhis session ID with plain HTTP requests ("http") or with the headless Chrome of
+	// ChromeURL ("chrome", the default).
+	SessionScraper   string                      `yaml:"session_scraper" validate:"omitempty,oneof=chrome http"`
+	ChromeURL        string                      `yaml:"chrome_url" validate:"required_unless=SessionScraper http"`
+	Chrome           cmdconfig.ChromeConfig      `yaml:"chrome"`
+	CandhisHTTP      cmdconfig.CandhisHTTPConfig `yaml:"candhis_http"`
+	SessionTargetWeb string                      `yaml:"session_target_web" validate:"required,url"`
+	CatalogueURL     string                      `yaml:"catalogue_url" validate:"required,url"`
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/pkg/configuration"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run scrapes the station catalogue, and returns the exit code of the process.
+func run() int {
+	log := logger.NewWithDefaultLogger()
+	ctx := context.Background()
+
+	// Parse the config file path from the command line arguments
+	configFile := flag.String("config", "", "Path to the configuration file")
+	flag.Parse()
+
+	// Load configuration
+	config, err := configuration.Load[Config](*configFile)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// Create connect to the PostgreSQL database
+	dbConn, err := db.NewDBConnection(
+		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName, db.DefaultDBConnector, log)
+	if err != nil {
+		log.Errorf("Database connection error: %v", err)
+		return appmodel.ExitCodeStorageUnavailable
+	}
+	defer dbConn.CloseWithLog()
+
+	httpClient := http.Client{}
+	defer httpClient.CloseIdleConnections()
+	candhisHTTPClient := client.NewCandhisHTTPClient(&httpClient, config.CandhisHTTP.Options())
+
+	// The session ID scraper is only used to renew the session ID when Candhis rejects the stored one
+	sessionIDWebScraper, err := client.NewSessionIDWebScraper(
+		&httpClient, candhisHTTPClient, config.SessionScraper, config.ChromeURL, config.Chrome.Options(), config.SessionTargetWeb)
+	if err != nil {
+		log.Errorf("Session ID scraper initialization error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+
+	catalogueScraper := service.NewCandhisCatalogueScraper(
+		persistence.NewSessionID(dbConn.DB),
+		persistence.NewStation(dbConn.DB),
+		client.NewCandhisCatalogueWebScraper(candhisHTTPClient),
+		sessionIDWebScraper,
+		persistence.NewScrapeRun(dbConn.DB),
+		config.CatalogueURL,
+	)
+
+	// Scraping and store the stations of the Candhis catalogue
+	log.Info("Start scraping Candhis web to fetch and store the stations of the catalogue")
+	catalogue, err := catalogueScraper.FetchAndStoreStations(ctx)
+	for _, rejected := range catalogue.Rejected {
+		log.Warnf("Rejected row %d of catalogue table: %s", rejected.Row, rejected.Reason)
+	}
+	log.WithFields(logrus.Fields{
+		"rows_seen": catalogue.RowsSeen,
+		"stations":  len(catalogue.Stations),
+		"rejected":  len(catalogue.Rejected),
+	}).Info("Catalogue report")
+	if err != nil {
+		log.Errorf("Failed scraping Candhis web to fetch and store the stations of the catalogue: %v", err)
+		return appmodel.ExitCode(err)
+	}
+	log.Info("Finished scraping Candhis web to fetch and store the stations of the catalogue successfully")
+	return 0
+}
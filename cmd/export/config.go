@@ -0,0 +1,5 @@
+package main
+
+type Config struct {
+	ElasticsearchURL string `yaml:"elasticsearch_url" validate:"required"`
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/tul1/candhis_api/internal/application/export"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/pkg/configuration"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run exports the observations of a campaign, and returns the exit code of the process.
+func run() int {
+	log := logger.NewWithDefaultLogger()
+
+	// Parse the config file path and the export options from the command line arguments
+	configFile := flag.String("config", "", "Path to the configuration file")
+	campaign := flag.String("campaign", "", "Campaign name, as used for its Elasticsearch index")
+	from := flag.String("from", "", "Only export observations at or after this RFC 3339 time")
+	to := flag.String("to", "", "Only export observations at or before this RFC 3339 time")
+	formatName := flag.String("format", string(export.FormatCSV), "Export format: csv, ndjson or netcdf")
+	output := flag.String("output", "", "Path of the exported file, standard output when empty")
+	flag.Parse()
+
+	// Load configuration
+	config, err := configuration.Load[Config](*configFile)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	format, query, err := exportParameters(*campaign, *from, *to, *formatName)
+	if err != nil {
+		log.Errorf("Export configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{config.ElasticsearchURL}})
+	if err != nil {
+		log.Errorf("Failed to create Elasticsearch client: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logExport := log.WithFields(logrus.Fields{"campaign": *campaign, "format": format, "output": *output})
+	logExport.Info("Start exporting campaign observations")
+	if err := exportToOutput(ctx, persistence.NewWaveData(esClient), *campaign, query, format, *output); err != nil {
+		logExport.Errorf("Failed exporting campaign observations: %v", err)
+		return appmodel.ExitCode(err)
+	}
+	logExport.Info("Finished exporting campaign observations successfully")
+	return 0
+}
+
+func exportParameters(campaign, from, to, formatName string) (export.Format, appmodel.WaveDataExportQuery, error) {
+	if campaign == "" {
+		return "", appmodel.WaveDataExportQuery{}, fmt.Errorf("campaign is required")
+	}
+
+	format, err := export.ParseFormat(formatName)
+	if err != nil {
+		return "", appmodel.WaveDataExportQuery{}, err
+	}
+
+	fromTime, err := parseOptionalTime(from)
+	if err != nil {
+		return "", appmodel.WaveDataExportQuery{}, fmt.Errorf("invalid from: %w", err)
+	}
+	toTime, err := parseOptionalTime(to)
+	if err != nil {
+		return "", appmodel.WaveDataExportQuery{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	query, err := appmodel.NewWaveDataExportQuery(fromTime, toTime)
+	if err != nil {
+		return "", appmodel.WaveDataExportQuery{}, err
+	}
+
+	return format, query, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// exportToOutput writes the export to the output file, which is removed when the export fails so that no truncated
+// file is left behind.
+func exportToOutput(
+	ctx context.Context,
+	waveDataRepo repository.WaveData,
+	campaign string,
+	query appmodel.WaveDataExportQuery,
+	format export.Format,
+	output string,
+) error {
+	if output == "" {
+		return exportTo(ctx, waveDataRepo, campaign, query, format, os.Stdout)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := exportTo(ctx, waveDataRepo, campaign, query, format, file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(output)
+		return err
+	}
+
+	return file.Close()
+}
+
+func exportTo(
+	ctx context.Context,
+	waveDataRepo repository.WaveData,
+	campaign string,
+	query appmodel.WaveDataExportQuery,
+	format export.Format,
+	out io.Writer,
+) error {
+	buffered := bufio.NewWriter(out)
+	writer, err := export.NewWriter(format, buffered, campaign)
+	if err != nil {
+		return err
+	}
+
+	if err := waveDataRepo.Export(ctx, campaign, query, writer); err != nil {
+		return err
+	}
+
+	return buffered.Flush()
+}
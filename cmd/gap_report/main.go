@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/sirupsen/logrus"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/pkg/configuration"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run reports the gaps of a campaign, and returns the exit code of the process.
+func run() int {
+	log := logger.NewWithDefaultLogger()
+
+	// Parse the config file path and the report options from the command line arguments
+	configFile := flag.String("config", "", "Path to the configuration file")
+	campaign := flag.String("campaign", "", "Campaign name, as used for its Elasticsearch index")
+	from := flag.String("from", "", "Start of the analysed RFC 3339 time range, 7 days before to when empty")
+	to := flag.String("to", "", "End of the analysed RFC 3339 time range, excluded, now when empty")
+	format := flag.String("format", formatText, "Report format: text or json")
+	flag.Parse()
+
+	// Load configuration
+	config, err := configuration.Load[Config](*configFile)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	query, err := gapReportParameters(*campaign, *from, *to, *format)
+	if err != nil {
+		log.Errorf("Gap report configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{config.ElasticsearchURL}})
+	if err != nil {
+		log.Errorf("Failed to create Elasticsearch client: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logReport := log.WithFields(logrus.Fields{"campaign": *campaign, "from": query.From(), "to": query.To()})
+	report, err := service.NewGapAnalyser(persistence.NewWaveData(esClient)).Analyse(ctx, *campaign, query)
+	if err == nil {
+		err = writeGapReport(os.Stdout, *campaign, report, *format)
+	}
+	if err != nil {
+		logReport.Errorf("Failed reporting campaign gaps: %v", err)
+		return appmodel.ExitCode(err)
+	}
+	return 0
+}
+
+func gapReportParameters(campaign, from, to, format string) (appmodel.GapQuery, error) {
+	if campaign == "" {
+		return appmodel.GapQuery{}, fmt.Errorf("campaign is required")
+	}
+	if format != formatText && format != formatJSON {
+		return appmodel.GapQuery{}, fmt.Errorf("unknown format %s, expected text or json", format)
+	}
+
+	fromTime, err := parseOptionalTime(from)
+	if err != nil {
+		return appmodel.GapQuery{}, fmt.Errorf("invalid from: %w", err)
+	}
+	toTime, err := parseOptionalTime(to)
+	if err != nil {
+		return appmodel.GapQuery{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return appmodel.NewGapQuery(fromTime, toTime, time.Now())
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func writeGapReport(out io.Writer, campaign string, report appmodel.GapReport, format string) error {
+	if format == formatJSON {
+		return writeGapReportJSON(out, campaign, report)
+	}
+	return writeGapReportText(out, campaign, report)
+}
+
+func writeGapReportText(out io.Writer, campaign string, report appmodel.GapReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Campaign %s from %s to %s\n", campaign,
+		report.From.Format(time.RFC3339), report.To.Format(time.RFC3339))
+	fmt.Fprintf(w, "Coverage %d/%d (%.1f%%)\n\n", report.Observed, report.Expected, report.Coverage())
+
+	fmt.Fprintln(w, "DAY\tEXPECTED\tOBSERVED\tCOVERAGE")
+	for _, day := range report.Days {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n", day.Day.Format(time.DateOnly), day.Expected, day.Observed, day.Coverage())
+	}
+
+	fmt.Fprintln(w)
+	if len(report.Gaps) == 0 {
+		fmt.Fprintln(w, "No gap")
+		return w.Flush()
+	}
+	fmt.Fprintln(w, "GAP FROM\tGAP TO\tMISSING")
+	for _, gap := range report.Gaps {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", gap.From.Format(time.RFC3339), gap.To.Format(time.RFC3339), gap.Missing())
+	}
+
+	return w.Flush()
+}
+
+type gapReportJSON struct {
+	Campaign string               `json:"campaign"`
+	From     time.Time            `json:"from"`
+	To       time.Time            `json:"to"`
+	Expected int                  `json:"expected"`
+	Observed int                  `json:"observed"`
+	Coverage float64              `json:"coverage"`
+	Gaps     []observationGapJSON `json:"gaps"`
+	Days     []dailyCoverageJSON  `json:"days"`
+}
+
+type observationGapJSON struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Missing int       `json:"missing"`
+}
+
+type dailyCoverageJSON struct {
+	Day      string  `json:"day"`
+	Expected int     `json:"expected"`
+	Observed int     `json:"observed"`
+	Coverage float64 `json:"coverage"`
+}
+
+func writeGapReportJSON(out io.Writer, campaign string, report appmodel.GapReport) error {
+	data := gapReportJSON{
+		Campaign: campaign,
+		From:     report.From,
+		To:       report.To,
+		Expected: report.Expected,
+		Observed: report.Observed,
+		Coverage: report.Coverage(),
+		Gaps:     make([]observationGapJSON, 0, len(report.Gaps)),
+		Days:     make([]dailyCoverageJSON, 0, len(report.Days)),
+	}
+	for _, gap := range report.Gaps {
+		data.Gaps = append(data.Gaps, observationGapJSON{From: gap.From, To: gap.To, Missing: gap.Missing()})
+	}
+	for _, day := range report.Days {
+		data.Days = append(data.Days, dailyCoverageJSON{
+			Day:      day.Day.Format(time.DateOnly),
+			Expected: day.Expected,
+			Observed: day.Observed,
+			Coverage: day.Coverage(),
+		})
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
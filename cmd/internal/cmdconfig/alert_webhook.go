@@ -0,0 +1,26 @@
+package cmdconfig
+
+import (
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+// AlertWebhookHostsConfig lists the only hosts the webhook URLs of the alert rules may target.
+type AlertWebhookHostsConfig struct {
+	AllowedHosts []string `yaml:"allowed_hosts" validate:"required,min=1,dive,hostname_rfc1123|ip"`
+}
+
+func (c AlertWebhookHostsConfig) Hosts() appmodel.AlertWebhookHosts {
+	return appmodel.AlertWebhookHosts(c.AllowedHosts)
+}
+
+// AlertWebhookConfig signs the alert webhook requests with Secret. A failed request is sent up to Attempts times,
+// waiting Backoff before the first retry and twice longer before each next one.
+type AlertWebhookConfig struct {
+	AlertWebhookHostsConfig `yaml:",inline"`
+
+	Secret   string        `yaml:"secret" validate:"required"`
+	Attempts int           `yaml:"attempts" validate:"required,min=1"`
+	Backoff  time.Duration `yaml:"backoff" validate:"min=0"`
+}
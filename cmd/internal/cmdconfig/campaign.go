@@ -0,0 +1,33 @@
+// Package cmdconfig holds the configuration blocks shared by the binaries of cmd, and builds the options of the
+// packages they configure.
+package cmdconfig
+
+import (
+	"fmt"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+type CampaignConfig struct {
+	BuoyID     string  `yaml:"buoy_id" validate:"required"`
+	Name       string  `yaml:"name" validate:"required"`
+	CandhisURL string  `yaml:"candhis_url" validate:"required,url"`
+	Index      string  `yaml:"index" validate:"required"`
+	Latitude   float64 `yaml:"latitude" validate:"latitude"`
+	Longitude  float64 `yaml:"longitude" validate:"longitude"`
+	Enabled    bool    `yaml:"enabled"`
+}
+
+// Campaigns builds the campaigns of the configs, in order.
+func Campaigns(configs []CampaignConfig) ([]appmodel.Campaign, error) {
+	campaigns := make([]appmodel.Campaign, 0, len(configs))
+	for _, cc := range configs {
+		campaign, err := appmodel.NewCampaign(
+			cc.BuoyID, cc.Name, cc.CandhisURL, cc.Index, cc.Latitude, cc.Longitude, cc.Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("campaign %s: %w", cc.BuoyID, err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, nil
+}
@@ -0,0 +1,27 @@
+package cmdconfig
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+)
+
+// CandhisHTTPConfig tunes the requests of the web scrapers to Candhis, zero values take the client package defaults.
+// Requests to a host start MinInterval apart, and network errors and 5xx responses are retried.
+type CandhisHTTPConfig struct {
+	Timeout     time.Duration `yaml:"timeout" validate:"min=0"`
+	UserAgent   string        `yaml:"user_agent"`
+	MinInterval time.Duration `yaml:"min_interval" validate:"min=0"`
+	Attempts    int           `yaml:"attempts" validate:"min=0"`
+	Backoff     time.Duration `yaml:"backoff" validate:"min=0"`
+}
+
+func (c CandhisHTTPConfig) Options() client.CandhisHTTPOptions {
+	return client.CandhisHTTPOptions{
+		Timeout:     c.Timeout,
+		UserAgent:   c.UserAgent,
+		MinInterval: c.MinInterval,
+		Attempts:    c.Attempts,
+		Backoff:     c.Backoff,
+	}
+}
@@ -0,0 +1,29 @@
+package cmdconfig
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/pkg/chrome"
+)
+
+// ChromeConfig tunes the headless Chrome runs of the chrome session scraper, zero values take the chrome package
+// defaults. The page of a failed run is saved into DebugDir when it is set.
+type ChromeConfig struct {
+	NavigationTimeout time.Duration `yaml:"navigation_timeout" validate:"min=0"`
+	ActionTimeout     time.Duration `yaml:"action_timeout" validate:"min=0"`
+	Attempts          int           `yaml:"attempts" validate:"min=0"`
+	Backoff           time.Duration `yaml:"backoff" validate:"min=0"`
+	PoolSize          int           `yaml:"pool_size" validate:"min=0"`
+	DebugDir          string        `yaml:"debug_dir"`
+}
+
+func (c ChromeConfig) Options() chrome.Options {
+	return chrome.Options{
+		NavigationTimeout: c.NavigationTimeout,
+		ActionTimeout:     c.ActionTimeout,
+		Attempts:          c.Attempts,
+		Backoff:           c.Backoff,
+		PoolSize:          c.PoolSize,
+		DebugDir:          c.DebugDir,
+	}
+}
@@ -0,0 +1,52 @@
+package cmdconfig
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// QualityControlConfig holds the thresholds of the observation quality control tests. Values outside the fail range of
+// a field are impossible for the buoy, values outside its suspect range are implausible at sea.
+type QualityControlConfig struct {
+	Height               QCRangesConfig     `yaml:"height" validate:"required"`
+	Period               QCRangesConfig     `yaml:"period" validate:"required"`
+	Temperature          QCRangesConfig     `yaml:"temperature" validate:"required"`
+	HeightSpike          QCThresholdsConfig `yaml:"height_spike" validate:"required"`
+	TemperatureSpike     QCThresholdsConfig `yaml:"temperature_spike" validate:"required"`
+	SpikeMaxGap          time.Duration      `yaml:"spike_max_gap" validate:"required,gt=0"`
+	FlatLineSuspectCount int                `yaml:"flat_line_suspect_count" validate:"required,min=2"`
+	FlatLineFailCount    int                `yaml:"flat_line_fail_count" validate:"required,min=2"`
+}
+
+type QCRangesConfig struct {
+	Fail    QCRangeConfig `yaml:"fail" validate:"required"`
+	Suspect QCRangeConfig `yaml:"suspect" validate:"required"`
+}
+
+type QCRangeConfig struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+}
+
+type QCThresholdsConfig struct {
+	Suspect float64 `yaml:"suspect" validate:"required,gt=0"`
+	Fail    float64 `yaml:"fail" validate:"required,gt=0"`
+}
+
+// QualityControl validates the thresholds and returns the quality control they configure.
+func (qc QualityControlConfig) QualityControl() (model.QualityControl, error) {
+	return model.NewQualityControl(model.QCConfig{
+		HeightFail:           model.QCRange(qc.Height.Fail),
+		HeightSuspect:        model.QCRange(qc.Height.Suspect),
+		PeriodFail:           model.QCRange(qc.Period.Fail),
+		PeriodSuspect:        model.QCRange(qc.Period.Suspect),
+		TemperatureFail:      model.QCRange(qc.Temperature.Fail),
+		TemperatureSuspect:   model.QCRange(qc.Temperature.Suspect),
+		HeightSpike:          model.QCThresholds(qc.HeightSpike),
+		TemperatureSpike:     model.QCThresholds(qc.TemperatureSpike),
+		SpikeMaxGap:          qc.SpikeMaxGap,
+		FlatLineSuspectCount: qc.FlatLineSuspectCount,
+		FlatLineFailCount:    qc.FlatLineFailCount,
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+)
+
+type Config struct {
+	ServerPort int `yaml:"server_port" validate:"required"`
+
+	DBUser           string `yaml:"db_user" validate:"required"`
+	DBPassword       string `yaml:"db_password" validate:"required"`
+	DBHost           string `yaml:"db_host" validate:"required"`
+	DBPort           string `yaml:"db_port" validate:"required,numeric"`
+	DBName           string `yaml:"db_name" validate:"required"`
+	ElasticsearchURL string `yaml:"elasticsearch_url" validate:"required"`
+	// Settings of the wave data indices created from the index template.
+	ElasticsearchShards   int `yaml:"elasticsearch_shards" validate:"required,min=1"`
+	ElasticsearchReplicas int `yaml:"elasticsearch_replicas" validate:"min=0"`
+
+	// SessionScraper obtains the Candhis session ID with plain HTTP requests ("http") or with the headless Chrome of
+	// ChromeURL ("chrome", the default).
+	SessionScraper   string                      `yaml:"session_scraper" validate:"omitempty,oneof=chrome http"`
+	ChromeURL        string                      `yaml:"chrome_url" validate:"required_unless=SessionScraper http"`
+	Chrome           cmdconfig.ChromeConfig      `yaml:"chrome"`
+	CandhisHTTP      cmdconfig.CandhisHTTPConfig `yaml:"candhis_http"`
+	SessionTargetWeb string                      `yaml:"session_target_web" validate:"required,url"`
+	CatalogueURL     string                      `yaml:"catalogue_url" validate:"required,url"`
+
+	SessionIDJob JobConfig                  `yaml:"sessionid_job" validate:"required"`
+	CampaignsJob JobConfig                  `yaml:"campaigns_job" validate:"required"`
+	CatalogueJob JobConfig                  `yaml:"catalogue_job" validate:"required"`
+	SpectraJob   JobConfig                  `yaml:"spectra_job" validate:"required"`
+	Campaigns    []cmdconfig.CampaignConfig `yaml:"campaigns" validate:"required,min=1,dive"`
+
+	AlertWebhook   cmdconfig.AlertWebhookConfig   `yaml:"alert_webhook" validate:"required"`
+	QualityControl cmdconfig.QualityControlConfig `yaml:"quality_control" validate:"required"`
+}
+
+// JobConfig schedules a job with a standard cron expression, its runs start up to Jitter later.
+type JobConfig struct {
+	Schedule string        `yaml:"schedule" validate:"required"`
+	Jitter   time.Duration `yaml:"jitter" validate:"min=0"`
+}
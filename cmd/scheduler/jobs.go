@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tul1/candhis_api/internal/application/service"
+)
+
+func sessionIDJob(sessionIDScraper service.CandhisSessionIDScraper) func(ctx context.Context) error {
+	return sessionIDScraper.FetchAndStoreSessionID
+}
+
+func catalogueJob(log *logrus.Logger, catalogueScraper service.CandhisCatalogueScraper) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		catalogue, err := catalogueScraper.FetchAndStoreStations(ctx)
+		for _, rejected := range catalogue.Rejected {
+			log.WithField("job", "catalogue").Warnf("Rejected row %d of catalogue table: %s", rejected.Row, rejected.Reason)
+		}
+		log.WithFields(logrus.Fields{
+			"job":       "catalogue",
+			"rows_seen": catalogue.RowsSeen,
+			"stations":  len(catalogue.Stations),
+			"rejected":  len(catalogue.Rejected),
+		}).Info("Catalogue report")
+
+		return err
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/tul1/candhis_api/cmd/internal/cmdconfig"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/pkg/configuration"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+	"github.com/tul1/candhis_api/internal/pkg/runner"
+	"github.com/tul1/candhis_api/internal/pkg/scheduler"
+	"github.com/tul1/candhis_api/internal/pkg/server"
+)
+
+// Running jobs are given this long to finish after a shutdown signal before being cancelled.
+const jobsShutdownTimeout = 2 * time.Minute
+
+func main() {
+	os.Exit(run())
+}
+
+// run runs the jobs until it is interrupted, and returns the exit code of the process.
+func run() int {
+	log := logger.NewWithDefaultLogger()
+
+	// Parse the config file path from the command line arguments
+	configFile := flag.String("config", "", "Path to the configuration file")
+	flag.Parse()
+
+	// Load configuration
+	config, err := configuration.Load[Config](*configFile)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	campaigns, err := cmdconfig.Campaigns(config.Campaigns)
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	qualityControl, err := config.QualityControl.QualityControl()
+	if err != nil {
+		log.Errorf("Configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// Create connect to the PostgreSQL database
+	dbConn, err := db.NewDBConnection(
+		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName, db.DefaultDBConnector, log)
+	if err != nil {
+		log.Errorf("Database connection error: %v", err)
+		return appmodel.ExitCodeStorageUnavailable
+	}
+	defer dbConn.CloseWithLog()
+
+	httpClient := http.Client{}
+	defer httpClient.CloseIdleConnections()
+	candhisHTTPClient := client.NewCandhisHTTPClient(&httpClient, config.CandhisHTTP.Options())
+
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{config.ElasticsearchURL}})
+	if err != nil {
+		log.Errorf("Failed to create Elasticsearch client: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// Install the index template before writing observations, and refuse to write into drifted indices
+	err = persistence.NewWaveDataIndexBootstrapper(esClient, config.ElasticsearchShards, config.ElasticsearchReplicas).
+		Bootstrap(context.Background(), appmodel.CampaignIndexNames(campaigns))
+	if err != nil {
+		log.Errorf("Elasticsearch index bootstrap error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+	err = persistence.NewSpectrumIndexBootstrapper(esClient, config.ElasticsearchShards, config.ElasticsearchReplicas).
+		Bootstrap(context.Background())
+	if err != nil {
+		log.Errorf("Elasticsearch spectra index bootstrap error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+
+	sessionIDWebScraper, err := client.NewSessionIDWebScraper(
+		&httpClient, candhisHTTPClient, config.SessionScraper, config.ChromeURL, config.Chrome.Options(), config.SessionTargetWeb)
+	if err != nil {
+		log.Errorf("Session ID scraper initialization error: %v", err)
+		return appmodel.ExitCode(err)
+	}
+
+	// Create scraper services
+	sessionIDRepo := persistence.NewSessionID(dbConn.DB)
+	scrapeRunRepo := persistence.NewScrapeRun(dbConn.DB)
+
+	sessionIDScraper := service.NewCandhisSessionIDScraper(sessionIDRepo, sessionIDWebScraper, scrapeRunRepo)
+	waveDataRepo := persistence.NewWaveData(esClient)
+	campaignsScraper := service.NewCandhisCampaignsScraper(
+		sessionIDRepo,
+		waveDataRepo,
+		client.NewCandhisCampaignsWebScraper(candhisHTTPClient),
+		sessionIDWebScraper,
+		scrapeRunRepo,
+		qualityControl,
+		campaigns,
+	)
+	alertEvaluator := service.NewAlertEvaluator(
+		persistence.NewAlertRule(dbConn.DB),
+		persistence.NewAlertEvent(dbConn.DB),
+		waveDataRepo,
+		client.NewAlertWebhook(&httpClient, config.AlertWebhook.Secret, config.AlertWebhook.Hosts(),
+			config.AlertWebhook.Attempts, config.AlertWebhook.Backoff),
+	)
+	catalogueScraper := service.NewCandhisCatalogueScraper(
+		sessionIDRepo,
+		persistence.NewStation(dbConn.DB),
+		client.NewCandhisCatalogueWebScraper(candhisHTTPClient),
+		sessionIDWebScraper,
+		scrapeRunRepo,
+		config.CatalogueURL,
+	)
+	spectraScraper := service.NewCandhisSpectraScraper(
+		sessionIDRepo,
+		persistence.NewSpectrum(esClient),
+		client.NewCandhisSpectraDownloader(candhisHTTPClient),
+		sessionIDWebScraper,
+		scrapeRunRepo,
+		campaigns,
+	)
+
+	// Create scheduler
+	jobScheduler, err := scheduler.New(log, []scheduler.Job{
+		{
+			Name:     "sessionid",
+			Schedule: config.SessionIDJob.Schedule,
+			Jitter:   config.SessionIDJob.Jitter,
+			Run:      sessionIDJob(sessionIDScraper),
+		},
+		{
+			Name:     "campaigns",
+			Schedule: config.CampaignsJob.Schedule,
+			Jitter:   config.CampaignsJob.Jitter,
+			Run:      runner.Campaigns(log.WithField("job", "campaigns"), campaignsScraper, alertEvaluator),
+		},
+		{
+			Name:     "catalogue",
+			Schedule: config.CatalogueJob.Schedule,
+			Jitter:   config.CatalogueJob.Jitter,
+			Run:      catalogueJob(log, catalogueScraper),
+		},
+		{
+			Name:     "spectra",
+			Schedule: config.SpectraJob.Schedule,
+			Jitter:   config.SpectraJob.Jitter,
+			Run:      runner.Spectra(log.WithField("job", "spectra"), spectraScraper),
+		},
+	})
+	if err != nil {
+		log.Errorf("Scheduler configuration error: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+
+	// Create Gin server exposing the jobs status
+	s, err := server.NewGinServer(log, "", config.ServerPort)
+	if err != nil {
+		log.Errorf("Failed to create Gin server: %v", err)
+		return appmodel.ExitCodeConfiguration
+	}
+	s.GetRouter().GET("/jobs", func(c *gin.Context) {
+		c.JSON(http.StatusOK, jobScheduler.Statuses())
+	})
+
+	// Start scheduler and server
+	jobScheduler.Start()
+	log.Info("Scheduler started")
+
+	errCh := make(chan error)
+	go func() {
+		err := s.Start()
+		if err != nil {
+			errCh <- err
+			return
+		}
+	}()
+
+	// Manage app interruption to stop the scheduler and close server
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	select {
+	case signalErr := <-signalCh:
+		log.Infof("System interruption signal received: %s\n", signalErr.String())
+	case err := <-errCh:
+		log.Errorf("Error while running the application: %s\n", err)
+	}
+
+	// Stop scheduler, waiting for running jobs
+	ctx, cancel := context.WithTimeout(context.Background(), jobsShutdownTimeout)
+	defer cancel()
+	if err = jobScheduler.Stop(ctx); err != nil {
+		log.Errorf("Error while stopping the scheduler: %s\n", err)
+	}
+
+	// Stop server
+	if err = s.Close(); err != nil {
+		log.Errorf("Error while closing the application: %s\n", err)
+	}
+	return 0
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"flag"
 	"net/http"
+	"os"
 
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/service"
 	"github.com/tul1/candhis_api/internal/infrastructure/client"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
-	"github.com/tul1/candhis_api/internal/pkg/chrome"
 	"github.com/tul1/candhis_api/internal/pkg/configuration"
 	"github.com/tul1/candhis_api/internal/pkg/db"
 	"github.com/tul1/candhis_api/internal/pkg/logger"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run fetches and stores a session ID, and returns the exit code of the process.
+func run() int {
 	log := logger.NewWithDefaultLogger()
 	ctx := context.Background()
 
@@ -26,7 +32,7 @@ func main() {
 	config, err := configuration.Load[Config](*configFile)
 	if err != nil {
 		log.Errorf("Configuration error: %v", err)
-		return
+		return appmodel.ExitCodeConfiguration
 	}
 
 	// Create connect to the PostgreSQL database
@@ -34,31 +40,35 @@ func main() {
 		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName, db.DefaultDBConnector, log)
 	if err != nil {
 		log.Errorf("Database connection error: %v", err)
-		return
+		return appmodel.ExitCodeStorageUnavailable
 	}
 	defer dbConn.CloseWithLog()
 
-	// Get Get Chrome ID from headless-chrome service
+	// The chrome session ID scraper gets the Chrome ID from the headless-chrome service, the http one requests Candhis
 	httpClient := http.Client{}
 	defer httpClient.CloseIdleConnections()
+	candhisHTTPClient := client.NewCandhisHTTPClient(&httpClient, config.CandhisHTTP.Options())
 
-	chromeScraper, err := chrome.NewChromedpScraper(&httpClient, config.ChromeURL)
+	sessionIDWebScraper, err := client.NewSessionIDWebScraper(
+		&httpClient, candhisHTTPClient, config.SessionScraper, config.ChromeURL, config.Chrome.Options(), config.SessionTargetWeb)
 	if err != nil {
-		log.Errorf("Chrome scraper initialization error: %v", err)
-		return
+		log.Errorf("Session ID scraper initialization error: %v", err)
+		return appmodel.ExitCode(err)
 	}
 
 	// Create candhisScraper service
 	candhisScraper := service.NewCandhisSessionIDScraper(
 		persistence.NewSessionID(dbConn.DB),
-		client.NewCandhisSessionIDWebScraper(chromeScraper, config.TargetWeb),
+		sessionIDWebScraper,
+		persistence.NewScrapeRun(dbConn.DB),
 	)
 
 	// Retrieve and Store CandhisSessionID
 	log.Info("Start scraping Candhis web to fetch and store session id")
 	if err = candhisScraper.FetchAndStoreSessionID(ctx); err != nil {
 		log.Errorf("Failed scraping Candhis web to fetch and store session id: %v", err)
-		return
+		return appmodel.ExitCode(err)
 	}
 	log.Info("Finished scraping Candhis web to fetch and store session id successfully")
+	return 0
 }
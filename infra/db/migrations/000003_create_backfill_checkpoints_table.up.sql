@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS backfill_checkpoints (
+    buoy_id VARCHAR(255) NOT NULL,
+    range_from TIMESTAMP NOT NULL,
+    range_to TIMESTAMP NOT NULL,
+    done_until TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (buoy_id, range_from, range_to)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS campaigns (
+    candhis_id VARCHAR(255) PRIMARY KEY,
+    buoy_id VARCHAR(32) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    index_name VARCHAR(255) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    depth DOUBLE PRECISION,
+    operator VARCHAR(255) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL,
+    first_seen_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS campaigns_buoy_id_idx ON campaigns (buoy_id);
+CREATE INDEX IF NOT EXISTS campaigns_index_name_idx ON campaigns (index_name);
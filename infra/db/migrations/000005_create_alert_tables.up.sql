@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    campaign VARCHAR(255) NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    comparator VARCHAR(8) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    window_seconds INTEGER NOT NULL DEFAULT 0,
+    cooldown_seconds INTEGER NOT NULL DEFAULT 0,
+    webhook_url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS alert_events (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES alert_rules (id) ON DELETE CASCADE,
+    state VARCHAR(16) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    observed_at TIMESTAMP NOT NULL,
+    fired_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    delivery_attempts INTEGER NOT NULL DEFAULT 0,
+    delivery_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS alert_events_rule_id_created_at_idx ON alert_events (rule_id, created_at DESC);
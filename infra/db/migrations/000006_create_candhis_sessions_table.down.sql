@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS candhis_session (
+    id VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO candhis_session (id, created_at)
+SELECT session_id, created_at FROM candhis_sessions ORDER BY created_at DESC, id DESC LIMIT 1;
+
+DROP TABLE IF EXISTS candhis_sessions;
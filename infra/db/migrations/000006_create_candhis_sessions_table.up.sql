@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS candhis_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    session_id VARCHAR(255) NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    valid_until TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    invalidated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS candhis_sessions_valid_idx ON candhis_sessions (created_at DESC)
+    WHERE invalidated_at IS NULL;
+
+-- The session of the single-row table is kept with the lifetime of a scraped one.
+INSERT INTO candhis_sessions (session_id, source, created_at, valid_until)
+SELECT id, 'sessionid_scraper', created_at, created_at + INTERVAL '24 hours' FROM candhis_session;
+
+DROP TABLE IF EXISTS candhis_session;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS scrape_runs_campaign_started_at_idx;
+
+ALTER TABLE scrape_runs DROP COLUMN IF EXISTS layout_fingerprint;
@@ -0,0 +1,4 @@
+-- Fingerprint of the Candhis table layout a run parsed, empty when the run parsed no table.
+ALTER TABLE scrape_runs ADD COLUMN IF NOT EXISTS layout_fingerprint VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS scrape_runs_campaign_started_at_idx ON scrape_runs (campaign, started_at DESC);
@@ -0,0 +1,193 @@
+package candhisapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+const (
+	defaultAlertEventsLimit = 50
+	maxAlertEventsLimit     = 500
+)
+
+func (s candhisAPI) ListAlertRules(c *gin.Context) {
+	rules, err := s.alertRule.List(c.Request.Context())
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list alert rules: %v", err)})
+		return
+	}
+
+	response := openapi.AlertRulesList{AlertRules: make([]openapi.AlertRule, 0, len(rules))}
+	for _, rule := range rules {
+		response.AlertRules = append(response.AlertRules, toOpenAPIAlertRule(rule))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (s candhisAPI) CreateAlertRule(c *gin.Context) {
+	rule, ok := s.bindAlertRule(c, 0)
+	if !ok {
+		return
+	}
+
+	rule, err := s.alertRule.Add(c.Request.Context(), rule)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to create alert rule: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toOpenAPIAlertRule(rule))
+}
+
+func (s candhisAPI) GetAlertRule(c *gin.Context, id int64) {
+	rule, err := s.alertRule.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get alert rule: %v", err)})
+		return
+	}
+	if rule == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("alert rule not found: %d", id)})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIAlertRule(*rule))
+}
+
+func (s candhisAPI) UpdateAlertRule(c *gin.Context, id int64) {
+	rule, ok := s.bindAlertRule(c, id)
+	if !ok {
+		return
+	}
+
+	err := s.alertRule.Update(c.Request.Context(), rule)
+	if errors.Is(err, appmodel.ErrAlertRuleNotFound) {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("alert rule not found: %d", id)})
+		return
+	}
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to update alert rule: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIAlertRule(rule))
+}
+
+func (s candhisAPI) DeleteAlertRule(c *gin.Context, id int64) {
+	err := s.alertRule.Delete(c.Request.Context(), id)
+	if errors.Is(err, appmodel.ErrAlertRuleNotFound) {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("alert rule not found: %d", id)})
+		return
+	}
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to delete alert rule: %v", err)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (s candhisAPI) ListAlertEvents(c *gin.Context, id int64, params openapi.ListAlertEventsParams) {
+	limit := defaultAlertEventsLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxAlertEventsLimit {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: "invalid limit: must be between 1 and 500"})
+		return
+	}
+
+	rule, err := s.alertRule.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list alert events: %v", err)})
+		return
+	}
+	if rule == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("alert rule not found: %d", id)})
+		return
+	}
+
+	events, err := s.alertEvent.ListByRule(c.Request.Context(), id, limit)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list alert events: %v", err)})
+		return
+	}
+
+	response := openapi.AlertEventsList{Events: make([]openapi.AlertEvent, 0, len(events))}
+	for _, event := range events {
+		response.Events = append(response.Events, toOpenAPIAlertEvent(event))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindAlertRule reads the alert rule of the request body, it responds 400 and returns false when it is invalid or
+// when its webhook host is not allowed.
+func (s candhisAPI) bindAlertRule(c *gin.Context, id int64) (appmodel.AlertRule, bool) {
+	var body openapi.AlertRuleInput
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("invalid alert rule: %v", err)})
+		return appmodel.AlertRule{}, false
+	}
+
+	var window, cooldown time.Duration
+	if body.WindowSeconds != nil {
+		window = time.Duration(*body.WindowSeconds) * time.Second
+	}
+	if body.CooldownSeconds != nil {
+		cooldown = time.Duration(*body.CooldownSeconds) * time.Second
+	}
+	enabled := true
+	if body.Enabled != nil {
+		enabled = *body.Enabled
+	}
+
+	rule, err := appmodel.NewAlertRule(id, body.Name, body.Campaign, appmodel.AlertMetric(body.Metric),
+		appmodel.AlertComparator(body.Comparator), body.Threshold, window, cooldown, body.WebhookUrl, enabled)
+	if err == nil {
+		err = s.alertWebhookHosts.Check(rule.WebhookURL())
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return appmodel.AlertRule{}, false
+	}
+
+	return rule, true
+}
+
+func toOpenAPIAlertRule(rule appmodel.AlertRule) openapi.AlertRule {
+	return openapi.AlertRule{
+		Id:              rule.ID(),
+		Name:            rule.Name(),
+		Campaign:        rule.Campaign(),
+		Metric:          openapi.AlertRuleMetric(rule.Metric()),
+		Comparator:      openapi.AlertRuleComparator(rule.Comparator()),
+		Threshold:       rule.Threshold(),
+		WindowSeconds:   int(rule.Window().Seconds()),
+		CooldownSeconds: int(rule.Cooldown().Seconds()),
+		WebhookUrl:      rule.WebhookURL(),
+		Enabled:         rule.Enabled(),
+	}
+}
+
+func toOpenAPIAlertEvent(event appmodel.AlertEvent) openapi.AlertEvent {
+	alertEvent := openapi.AlertEvent{
+		State:            openapi.AlertEventState(event.State()),
+		Value:            event.Value(),
+		ObservedAt:       event.ObservedAt(),
+		FiredAt:          event.FiredAt(),
+		CreatedAt:        event.CreatedAt(),
+		DeliveryAttempts: event.DeliveryAttempts(),
+	}
+	if event.DeliveryError() != "" {
+		deliveryError := event.DeliveryError()
+		alertEvent.DeliveryError = &deliveryError
+	}
+	return alertEvent
+}
@@ -0,0 +1,346 @@
+package candhisapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	alertWebhookURL = "https://hooks.example.com/candhis"
+
+	bigSwellRuleInputJSON = `{
+		"name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt", "threshold": 4,
+		"cooldown_seconds": 21600, "webhook_url": "https://hooks.example.com/candhis"
+	}`
+	bigSwellRuleJSON = `{
+		"id": 7, "name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt",
+		"threshold": 4, "window_seconds": 0, "cooldown_seconds": 21600,
+		"webhook_url": "https://hooks.example.com/candhis", "enabled": true
+	}`
+)
+
+func TestListAlertRules_Success(t *testing.T) {
+	mocks, router := setupAlertRulesAPI(t)
+
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellAlertRule(t, 7)}, nil)
+
+	resp := performRequest(router, "/alert-rules")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"alert_rules": [`+bigSwellRuleJSON+`]}`, resp.Body.String())
+}
+
+func TestListAlertRules_Failure(t *testing.T) {
+	mocks, router := setupAlertRulesAPI(t)
+
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return(nil, errors.New("error database"))
+
+	resp := performRequest(router, "/alert-rules")
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(t, `{"error": "failed to list alert rules: error database"}`, resp.Body.String())
+}
+
+func TestCreateAlertRule_Success(t *testing.T) {
+	mocks, router := setupAlertRulesAPI(t)
+
+	mocks.alertRule.EXPECT().Add(gomock.Any(), bigSwellAlertRule(t, 0)).Return(bigSwellAlertRule(t, 7), nil)
+
+	resp := performJSONRequest(router, http.MethodPost, "/alert-rules", bigSwellRuleInputJSON)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.JSONEq(t, bigSwellRuleJSON, resp.Body.String())
+}
+
+func TestCreateAlertRule_InvalidRule(t *testing.T) {
+	testCases := map[string]struct {
+		body         string
+		expectedBody string
+	}{
+		"malformed body": {
+			body:         `{"name": `,
+			expectedBody: `{"error": "invalid alert rule: unexpected EOF"}`,
+		},
+		"unknown metric": {
+			body: `{"name": "Big swell", "campaign": "les-pierres-noires", "metric": "wind", "comparator": "gt",
+				"threshold": 4, "webhook_url": "https://hooks.example.com/candhis"}`,
+			expectedBody: `{"error": "invalid alert rule: unknown metric"}`,
+		},
+		"rise without window": {
+			body: `{"name": "Rising swell", "campaign": "les-pierres-noires", "metric": "h1_3_rise", "comparator": "gt",
+				"threshold": 1, "webhook_url": "https://hooks.example.com/candhis"}`,
+			expectedBody: `{"error": "invalid alert rule: rise metrics need a window"}`,
+		},
+		"webhook host not allowed": {
+			body: `{"name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt",
+				"threshold": 4, "webhook_url": "https://internal.example.org/candhis"}`,
+			expectedBody: `{"error": "invalid alert rule: webhook host is not allowed: internal.example.org"}`,
+		},
+		"loopback webhook": {
+			body: `{"name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt",
+				"threshold": 4, "webhook_url": "http://127.0.0.1:6379/candhis"}`,
+			expectedBody: `{"error": "invalid alert rule: webhook URL cannot target a loopback or link-local address"}`,
+		},
+		"link-local webhook": {
+			body: `{"name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt",
+				"threshold": 4, "webhook_url": "http://169.254.169.254/latest/meta-data"}`,
+			expectedBody: `{"error": "invalid alert rule: webhook URL cannot target a loopback or link-local address"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, router := setupAlertRulesAPI(t)
+
+			resp := performJSONRequest(router, http.MethodPost, "/alert-rules", tc.body)
+
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestGetAlertRule(t *testing.T) {
+	testCases := map[string]struct {
+		expect         func(mocks alertRulesAPIMocks)
+		expectedStatus int
+		expectedBody   string
+	}{
+		"success": {
+			expect: func(mocks alertRulesAPIMocks) {
+				rule := bigSwellAlertRule(t, 7)
+				mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(&rule, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   bigSwellRuleJSON,
+		},
+		"not found": {
+			expect: func(mocks alertRulesAPIMocks) {
+				mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "alert rule not found: 7"}`,
+		},
+		"database error": {
+			expect: func(mocks alertRulesAPIMocks) {
+				mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(nil, errors.New("error database"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to get alert rule: error database"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, router := setupAlertRulesAPI(t)
+			tc.expect(mocks)
+
+			resp := performRequest(router, "/alert-rules/7")
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestGetAlertRule_InvalidID(t *testing.T) {
+	_, router := setupAlertRulesAPI(t)
+
+	resp := performRequest(router, "/alert-rules/big-swell")
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestUpdateAlertRule(t *testing.T) {
+	testCases := map[string]struct {
+		updateErr      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"success": {
+			expectedStatus: http.StatusOK,
+			expectedBody:   bigSwellRuleJSON,
+		},
+		"not found": {
+			updateErr:      appmodel.ErrAlertRuleNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "alert rule not found: 7"}`,
+		},
+		"database error": {
+			updateErr:      errors.New("error database"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to update alert rule: error database"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, router := setupAlertRulesAPI(t)
+
+			mocks.alertRule.EXPECT().Update(gomock.Any(), bigSwellAlertRule(t, 7)).Return(tc.updateErr)
+
+			resp := performJSONRequest(router, http.MethodPut, "/alert-rules/7", bigSwellRuleInputJSON)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestDeleteAlertRule(t *testing.T) {
+	testCases := map[string]struct {
+		deleteErr      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"success": {
+			expectedStatus: http.StatusNoContent,
+		},
+		"not found": {
+			deleteErr:      appmodel.ErrAlertRuleNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "alert rule not found: 7"}`,
+		},
+		"database error": {
+			deleteErr:      errors.New("error database"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to delete alert rule: error database"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, router := setupAlertRulesAPI(t)
+
+			mocks.alertRule.EXPECT().Delete(gomock.Any(), int64(7)).Return(tc.deleteErr)
+
+			resp := performJSONRequest(router, http.MethodDelete, "/alert-rules/7", "")
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestListAlertEvents_Success(t *testing.T) {
+	mocks, router := setupAlertRulesAPI(t)
+
+	observedAt := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	firedAt := time.Date(2024, 9, 17, 10, 5, 0, 0, time.UTC)
+	resolvedAt := time.Date(2024, 9, 17, 14, 5, 0, 0, time.UTC)
+	rule := bigSwellAlertRule(t, 7)
+	mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(&rule, nil)
+	mocks.alertEvent.EXPECT().ListByRule(gomock.Any(), int64(7), 2).Return([]appmodel.AlertEvent{
+		appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateResolved, 3.1, observedAt.Add(4*time.Hour), firedAt,
+			resolvedAt, 3, "unexpected status code 502"),
+		appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateFiring, 4.6, observedAt, firedAt, firedAt, 1, ""),
+	}, nil)
+
+	resp := performRequest(router, "/alert-rules/7/events?limit=2")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"events": [
+		{"state": "resolved", "value": 3.1, "observed_at": "2024-09-17T14:00:00Z", "fired_at": "2024-09-17T10:05:00Z",
+			"created_at": "2024-09-17T14:05:00Z", "delivery_attempts": 3, "delivery_error": "unexpected status code 502"},
+		{"state": "firing", "value": 4.6, "observed_at": "2024-09-17T10:00:00Z", "fired_at": "2024-09-17T10:05:00Z",
+			"created_at": "2024-09-17T10:05:00Z", "delivery_attempts": 1}
+	]}`, resp.Body.String())
+}
+
+func TestListAlertEvents_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		expect         func(mocks alertRulesAPIMocks)
+		expectedStatus int
+		expectedBody   string
+	}{
+		"invalid limit": {
+			path:           "/alert-rules/7/events?limit=0",
+			expect:         func(alertRulesAPIMocks) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid limit: must be between 1 and 500"}`,
+		},
+		"unknown rule": {
+			path: "/alert-rules/7/events",
+			expect: func(mocks alertRulesAPIMocks) {
+				mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error": "alert rule not found: 7"}`,
+		},
+		"database error": {
+			path: "/alert-rules/7/events",
+			expect: func(mocks alertRulesAPIMocks) {
+				rule := bigSwellAlertRule(t, 7)
+				mocks.alertRule.EXPECT().Get(gomock.Any(), int64(7)).Return(&rule, nil)
+				mocks.alertEvent.EXPECT().ListByRule(gomock.Any(), int64(7), 50).Return(nil, errors.New("error database"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to list alert events: error database"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, router := setupAlertRulesAPI(t)
+			tc.expect(mocks)
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func bigSwellAlertRule(t *testing.T, id int64) appmodel.AlertRule {
+	t.Helper()
+
+	return appmodeltest.MustCreateAlertRule(t, id, "Big swell", "les-pierres-noires", appmodel.AlertMetricMaxHeight,
+		appmodel.AlertComparatorGreaterThan, 4, 0, 6*time.Hour, alertWebhookURL, true)
+}
+
+func performJSONRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+type alertRulesAPIMocks struct {
+	alertRule  *persistencemock.MockAlertRule
+	alertEvent *persistencemock.MockAlertEvent
+}
+
+func setupAlertRulesAPI(t *testing.T) (alertRulesAPIMocks, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := alertRulesAPIMocks{
+		alertRule:  persistencemock.NewMockAlertRule(ctrl),
+		alertEvent: persistencemock.NewMockAlertEvent(ctrl),
+	}
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{
+		AlertRule:         mocks.alertRule,
+		AlertEvent:        mocks.alertEvent,
+		AlertWebhookHosts: appmodel.AlertWebhookHosts{"hooks.example.com"},
+	})
+
+	return mocks, router
+}
@@ -0,0 +1,128 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+const (
+	jsonMediaType    = "application/json"
+	geoJSONMediaType = "application/geo+json"
+)
+
+func (s candhisAPI) ListCampaigns(c *gin.Context) {
+	stations, err := s.station.List(c.Request.Context())
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list campaigns: %v", err)})
+		return
+	}
+
+	campaigns, err := s.campaigns(c, stations)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list campaigns: %v", err)})
+		return
+	}
+
+	c.Header("Vary", "Accept")
+	if c.NegotiateFormat(jsonMediaType, geoJSONMediaType) == geoJSONMediaType {
+		c.Header("Content-Type", geoJSONMediaType)
+		c.JSON(http.StatusOK, toOpenAPICampaignFeatureCollection(campaigns))
+		return
+	}
+
+	c.JSON(http.StatusOK, openapi.CampaignsList{Campaigns: campaigns})
+}
+
+func (s candhisAPI) GetCampaign(c *gin.Context, campaign string) {
+	station, err := s.station.Get(c.Request.Context(), campaign)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get campaign: %v", err)})
+		return
+	}
+	if station == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("campaign not found: %s", campaign)})
+		return
+	}
+
+	campaigns, err := s.campaigns(c, []appmodel.Station{*station})
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get campaign: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, campaigns[0])
+}
+
+// campaigns adds to the stations the state of their campaigns: the stored observations and the latest scrape.
+func (s candhisAPI) campaigns(c *gin.Context, stations []appmodel.Station) ([]openapi.Campaign, error) {
+	indexNames := make([]string, 0, len(stations))
+	for _, station := range stations {
+		indexNames = append(indexNames, station.IndexName())
+	}
+
+	summaries, err := s.waveData.Summaries(c.Request.Context(), indexNames)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.scrapeRun.LatestByCampaign(c.Request.Context(), appmodel.ScraperCampaigns)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]openapi.Campaign, 0, len(stations))
+	for _, station := range stations {
+		campaign := toOpenAPICampaign(station)
+		if summary, ok := summaries[station.IndexName()]; ok {
+			latest := toOpenAPIWaveData(summary.Latest)
+			campaign.LatestObservation = &latest
+			campaign.Coverage = &openapi.Coverage{From: summary.First, To: summary.Last, Count: summary.Count}
+		}
+		if run, ok := runs[station.BuoyID()]; ok {
+			campaign.LastScrape = &openapi.LastScrape{
+				FinishedAt: run.FinishedAt(),
+				Outcome:    openapi.LastScrapeOutcome(run.Outcome()),
+			}
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, nil
+}
+
+func toOpenAPICampaign(station appmodel.Station) openapi.Campaign {
+	campaign := openapi.Campaign{
+		Id:        station.IndexName(),
+		BuoyId:    station.BuoyID(),
+		CandhisId: station.CandhisID(),
+		Name:      station.Name(),
+		Latitude:  station.Latitude(),
+		Longitude: station.Longitude(),
+		Depth:     station.Depth(),
+		Active:    station.Active(),
+	}
+	if operator := station.Operator(); operator != "" {
+		campaign.Operator = &operator
+	}
+	return campaign
+}
+
+func toOpenAPICampaignFeatureCollection(campaigns []openapi.Campaign) openapi.CampaignFeatureCollection {
+	features := make([]openapi.CampaignFeature, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		features = append(features, openapi.CampaignFeature{
+			Type: openapi.Feature,
+			Id:   campaign.Id,
+			Geometry: openapi.PointGeometry{
+				Type:        openapi.Point,
+				Coordinates: []float64{campaign.Longitude, campaign.Latitude},
+			},
+			Properties: campaign,
+		})
+	}
+	return openapi.CampaignFeatureCollection{Type: openapi.FeatureCollection, Features: features}
+}
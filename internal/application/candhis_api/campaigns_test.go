@@ -0,0 +1,210 @@
+package candhisapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+const lesPierresNoiresCampaignJSON = `{
+	"id": "les-pierres-noires", "buoy_id": "02911", "candhis_id": "Y2FtcD0wMjkxMQ==", "name": "Les Pierres Noires",
+	"latitude": 48.2908, "longitude": -4.9678, "depth": 60, "operator": "Cerema", "active": true,
+	"latest_observation": {"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+		"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15},
+	"coverage": {"from": "2023-01-01T00:00:00Z", "to": "2024-09-17T09:00:00Z", "count": 30000},
+	"last_scrape": {"finished_at": "2024-09-17T09:00:02Z", "outcome": "success"}
+}`
+
+const belleIleCampaignJSON = `{
+	"id": "belle-ile", "buoy_id": "05602", "candhis_id": "Y2FtcD0wNTYwMg==", "name": "Belle Ile",
+	"latitude": 47.2855, "longitude": -3.2847, "active": false
+}`
+
+func TestListCampaigns_Success(t *testing.T) {
+	mocks, router := setupCampaignsAPI(t)
+	expectCampaigns(t, mocks)
+
+	resp := performRequest(router, "/campaigns")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"campaigns": [`+belleIleCampaignJSON+`,`+lesPierresNoiresCampaignJSON+`]}`, resp.Body.String())
+}
+
+func TestListCampaigns_GeoJSON(t *testing.T) {
+	mocks, router := setupCampaignsAPI(t)
+	expectCampaigns(t, mocks)
+
+	req := httptest.NewRequest(http.MethodGet, "/campaigns", http.NoBody)
+	req.Header.Set("Accept", "application/geo+json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/geo+json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", resp.Header().Get("Vary"))
+	assert.JSONEq(t, `{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "id": "belle-ile", "geometry": {"type": "Point", "coordinates": [-3.2847, 47.2855]},
+				"properties": `+belleIleCampaignJSON+`},
+			{"type": "Feature", "id": "les-pierres-noires", "geometry": {"type": "Point", "coordinates": [-4.9678, 48.2908]},
+				"properties": `+lesPierresNoiresCampaignJSON+`}
+		]
+	}`, resp.Body.String())
+}
+
+func TestListCampaigns_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		expect       func(mocks campaignsAPIMocks)
+		expectedBody string
+	}{
+		"stations error": {
+			expect: func(mocks campaignsAPIMocks) {
+				mocks.station.EXPECT().List(gomock.Any()).Return(nil, errors.New("error database"))
+			},
+			expectedBody: `{"error": "failed to list campaigns: error database"}`,
+		},
+		"observations error": {
+			expect: func(mocks campaignsAPIMocks) {
+				mocks.station.EXPECT().List(gomock.Any()).Return(nil, nil)
+				mocks.waveData.EXPECT().Summaries(gomock.Any(), []string{}).Return(nil, errors.New("error elasticsearch"))
+			},
+			expectedBody: `{"error": "failed to list campaigns: error elasticsearch"}`,
+		},
+		"scrape runs error": {
+			expect: func(mocks campaignsAPIMocks) {
+				mocks.station.EXPECT().List(gomock.Any()).Return(nil, nil)
+				mocks.waveData.EXPECT().Summaries(gomock.Any(), []string{}).Return(nil, nil)
+				mocks.scrapeRun.EXPECT().LatestByCampaign(gomock.Any(), appmodel.ScraperCampaigns).
+					Return(nil, errors.New("error database"))
+			},
+			expectedBody: `{"error": "failed to list campaigns: error database"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, router := setupCampaignsAPI(t)
+			tc.expect(mocks)
+
+			resp := performRequest(router, "/campaigns")
+
+			assert.Equal(t, http.StatusInternalServerError, resp.Code)
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestGetCampaign_Success(t *testing.T) {
+	mocks, router := setupCampaignsAPI(t)
+
+	depth := 60.0
+	station := appmodeltest.MustCreateStation(
+		t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.2908, -4.9678, &depth, "Cerema", true)
+	mocks.station.EXPECT().Get(gomock.Any(), "les-pierres-noires").Return(&station, nil)
+	mocks.waveData.EXPECT().Summaries(gomock.Any(), []string{"les-pierres-noires"}).
+		Return(map[string]appmodel.WaveDataSummary{"les-pierres-noires": lesPierresNoiresSummary(t)}, nil)
+	mocks.scrapeRun.EXPECT().LatestByCampaign(gomock.Any(), appmodel.ScraperCampaigns).
+		Return(map[string]appmodel.ScrapeRun{"02911": lesPierresNoiresScrapeRun(t)}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, lesPierresNoiresCampaignJSON, resp.Body.String())
+}
+
+func TestGetCampaign_NotFound(t *testing.T) {
+	mocks, router := setupCampaignsAPI(t)
+
+	mocks.station.EXPECT().Get(gomock.Any(), "unknown").Return(nil, nil)
+
+	resp := performRequest(router, "/campaigns/unknown")
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error": "campaign not found: unknown"}`, resp.Body.String())
+}
+
+func TestGetCampaign_Failure(t *testing.T) {
+	mocks, router := setupCampaignsAPI(t)
+
+	mocks.station.EXPECT().Get(gomock.Any(), "les-pierres-noires").Return(nil, errors.New("error database"))
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires")
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(t, `{"error": "failed to get campaign: error database"}`, resp.Body.String())
+}
+
+func expectCampaigns(t *testing.T, mocks campaignsAPIMocks) {
+	t.Helper()
+
+	depth := 60.0
+	mocks.station.EXPECT().List(gomock.Any()).Return([]appmodel.Station{
+		appmodeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.2855, -3.2847, nil, "", false),
+		appmodeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.2908, -4.9678, &depth, "Cerema", true),
+	}, nil)
+	mocks.waveData.EXPECT().Summaries(gomock.Any(), []string{"belle-ile", "les-pierres-noires"}).
+		Return(map[string]appmodel.WaveDataSummary{"les-pierres-noires": lesPierresNoiresSummary(t)}, nil)
+	mocks.scrapeRun.EXPECT().LatestByCampaign(gomock.Any(), appmodel.ScraperCampaigns).
+		Return(map[string]appmodel.ScrapeRun{"02911": lesPierresNoiresScrapeRun(t)}, nil)
+}
+
+func lesPierresNoiresSummary(t *testing.T) appmodel.WaveDataSummary {
+	t.Helper()
+
+	return appmodel.WaveDataSummary{
+		Count:  30000,
+		First:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Last:   time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC),
+		Latest: modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+}
+
+func lesPierresNoiresScrapeRun(t *testing.T) appmodel.ScrapeRun {
+	t.Helper()
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	run, err := appmodel.NewScrapeRun(appmodel.ScraperCampaigns, "02911", startedAt, startedAt.Add(2*time.Second), "",
+		appmodel.ScrapeRunCounts{})
+	require.NoError(t, err)
+
+	return run
+}
+
+type campaignsAPIMocks struct {
+	station   *persistencemock.MockStation
+	waveData  *persistencemock.MockWaveData
+	scrapeRun *persistencemock.MockScrapeRun
+}
+
+func setupCampaignsAPI(t *testing.T) (campaignsAPIMocks, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := campaignsAPIMocks{
+		station:   persistencemock.NewMockStation(ctrl),
+		waveData:  persistencemock.NewMockWaveData(ctrl),
+		scrapeRun: persistencemock.NewMockScrapeRun(ctrl),
+	}
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{
+		WaveData:  mocks.waveData,
+		ScrapeRun: mocks.scrapeRun,
+		Station:   mocks.station,
+	})
+
+	return mocks, router
+}
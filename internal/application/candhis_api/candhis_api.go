@@ -1,16 +1,100 @@
 package candhisapi
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
 	"github.com/tul1/candhis_api/openapi"
 )
 
 type candhisAPI struct {
-	router *gin.Engine
+	router    *gin.Engine
+	waveData  repository.WaveData
+	scrapeRun repository.ScrapeRun
+	station   repository.Station
+
+	alertRule         repository.AlertRule
+	alertEvent        repository.AlertEvent
+	alertWebhookHosts appmodel.AlertWebhookHosts
+
+	spectrum repository.Spectrum
+
+	latestWaveData service.LatestWaveData
+	gapAnalyser    service.GapAnalyser
 }
 
-func NewCandhisAPI(e *gin.Engine) *candhisAPI {
-	api := candhisAPI{router: e}
-	openapi.RegisterHandlers(e, api)
+// Deps are the repositories and services the handlers of the API rely on, a handler whose dependency is nil must
+// not be called.
+type Deps struct {
+	WaveData  repository.WaveData
+	ScrapeRun repository.ScrapeRun
+	Station   repository.Station
+
+	AlertRule  repository.AlertRule
+	AlertEvent repository.AlertEvent
+	// AlertWebhookHosts are the only hosts the webhook URL of an alert rule may target.
+	AlertWebhookHosts appmodel.AlertWebhookHosts
+
+	Spectrum repository.Spectrum
+
+	LatestWaveData service.LatestWaveData
+	GapAnalyser    service.GapAnalyser
+}
+
+func NewCandhisAPI(e *gin.Engine, deps Deps) *candhisAPI {
+	api := candhisAPI{
+		router:            e,
+		waveData:          deps.WaveData,
+		scrapeRun:         deps.ScrapeRun,
+		station:           deps.Station,
+		alertRule:         deps.AlertRule,
+		alertEvent:        deps.AlertEvent,
+		alertWebhookHosts: deps.AlertWebhookHosts,
+		spectrum:          deps.Spectrum,
+		latestWaveData:    deps.LatestWaveData,
+		gapAnalyser:       deps.GapAnalyser,
+	}
+	openapi.RegisterHandlersWithOptions(e, api, openapi.GinServerOptions{ErrorHandler: errorHandler})
 	return &api
 }
+
+// campaignStation returns the station of the catalogue whose index name is campaign, and answers 404 when there is
+// none. The campaign of a path is used as an Elasticsearch index, it must not reach it as a pattern, a list or the index
+// of another kind of documents.
+func (s candhisAPI) campaignStation(c *gin.Context, campaign string) (*appmodel.Station, bool) {
+	station, err := s.station.Get(c.Request.Context(), campaign)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get campaign: %v", err)})
+		return nil, false
+	}
+	if station == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("campaign not found: %s", campaign)})
+		return nil, false
+	}
+	return station, true
+}
+
+func errorHandler(c *gin.Context, err error, statusCode int) {
+	c.JSON(statusCode, openapi.ErrorResponse{Error: err.Error()})
+}
+
+// errorStatus is the HTTP status of a request that failed with err, by the class of err.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, appmodel.ErrStorageUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, appmodel.ErrUpstreamUnavailable), errors.Is(err, appmodel.ErrSessionExpired),
+		errors.Is(err, appmodel.ErrLayoutChanged):
+		return http.StatusBadGateway
+	case errors.Is(err, model.ErrInvalidObservation), errors.Is(err, appmodel.ErrInvalidInput):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,64 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tul1/candhis_api/internal/application/export"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func (s candhisAPI) ExportCampaignObservations(
+	c *gin.Context,
+	campaign string,
+	params openapi.ExportCampaignObservationsParams,
+) {
+	if _, ok := s.campaignStation(c, campaign); !ok {
+		return
+	}
+
+	format := export.FormatCSV
+	if params.Format != nil {
+		var err error
+		format, err = export.ParseFormat(string(*params.Format))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	query, err := appmodel.NewWaveDataExportQuery(params.From, params.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	writer, err := export.NewWriter(format, c.Writer, campaign)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s%s"`, campaign, format.FileExtension()))
+	c.Status(http.StatusOK)
+
+	err = s.waveData.Export(c.Request.Context(), campaign, query, writer)
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to export campaign observations: %v", err)})
+		return
+	}
+
+	// The status and the beginning of the file are already sent, aborting the response is the only way left to tell
+	// the client that the file is truncated.
+	_ = c.Error(err)
+	panic(http.ErrAbortHandler)
+}
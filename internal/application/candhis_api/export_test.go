@@ -0,0 +1,117 @@
+package candhisapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportCampaignObservations_Success(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	expectedQuery, err := appmodel.NewWaveDataExportQuery(&from, nil)
+	require.NoError(t, err)
+
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", expectedQuery, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ appmodel.WaveDataExportQuery, writer appmodel.WaveDataExportWriter) error {
+			require.NoError(t, writer.Begin(1))
+			require.NoError(t, writer.Write(
+				modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15")))
+			return writer.End()
+		})
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/export?from=2024-09-17T00:00:00Z&format=ndjson")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/x-ndjson", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="les-pierres-noires.ndjson"`, resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, `{"timestamp":"2024-09-17T08:30:00Z","h1_3":0.5,"hmax":0.9,"th1_3":4.8,`+
+		`"peak_direction":4,"peak_directional_spread":47,"temperature":15}`+"\n", resp.Body.String())
+}
+
+func TestExportCampaignObservations_DefaultsToCSV(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ appmodel.WaveDataExportQuery, writer appmodel.WaveDataExportWriter) error {
+			require.NoError(t, writer.Begin(0))
+			return writer.End()
+		})
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/export")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "Date,Heure (TU),H1/3 (m),Hmax (m),Th1/3 (s),Dir. au pic (°),Etal. au pic (°),Temp. mer (°C),"+
+		"Tp (s),Tz (s),Dir. moy. (°),Hm0 (m),Vent (m/s),Dir. vent (°)\n", resp.Body.String())
+}
+
+func TestExportCampaignObservations_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"unknown format": {
+			path:           "/campaigns/les-pierres-noires/export?format=xlsx",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid format: must be one of csv, ndjson, netcdf"}`,
+		},
+		"from after to": {
+			path:           "/campaigns/les-pierres-noires/export?from=2024-09-18T00:00:00Z&to=2024-09-17T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from must not be after to"}`,
+		},
+		"repository error": {
+			path:           "/campaigns/les-pierres-noires/export?format=netcdf",
+			repoErr:        errors.New("error elasticsearch"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to export campaign observations: error elasticsearch"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			waveDataRepo, router := setupObservationsAPI(t)
+			if tc.repoErr != nil {
+				waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+					Return(tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			assert.Equal(t, "application/json; charset=utf-8", resp.Header().Get("Content-Type"))
+			assert.Empty(t, resp.Header().Get("Content-Disposition"))
+			assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+		})
+	}
+}
+
+func TestExportCampaignObservations_AbortsTruncatedExport(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ appmodel.WaveDataExportQuery, writer appmodel.WaveDataExportWriter) error {
+			require.NoError(t, writer.Begin(2))
+			require.NoError(t, writer.Write(
+				modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15")))
+			return errors.New("error elasticsearch")
+		})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		router.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/campaigns/les-pierres-noires/export?format=ndjson", http.NoBody))
+	})
+}
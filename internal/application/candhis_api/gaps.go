@@ -0,0 +1,56 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	openapitypes "github.com/oapi-codegen/runtime/types"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func (s candhisAPI) GetCampaignGaps(c *gin.Context, campaign string, params openapi.GetCampaignGapsParams) {
+	if _, ok := s.campaignStation(c, campaign); !ok {
+		return
+	}
+
+	query, err := appmodel.NewGapQuery(params.From, params.To, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := s.gapAnalyser.Analyse(c.Request.Context(), campaign, query)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to analyse gaps: %v", err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIGapReport(report))
+}
+
+func toOpenAPIGapReport(report appmodel.GapReport) openapi.GapReport {
+	response := openapi.GapReport{
+		From:     report.From,
+		To:       report.To,
+		Expected: report.Expected,
+		Observed: report.Observed,
+		Coverage: report.Coverage(),
+		Gaps:     make([]openapi.ObservationGap, 0, len(report.Gaps)),
+		Days:     make([]openapi.DailyCoverage, 0, len(report.Days)),
+	}
+	for _, gap := range report.Gaps {
+		response.Gaps = append(response.Gaps, openapi.ObservationGap{From: gap.From, To: gap.To, Missing: gap.Missing()})
+	}
+	for _, day := range report.Days {
+		response.Days = append(response.Days, openapi.DailyCoverage{
+			Day:      openapitypes.Date{Time: day.Day},
+			Expected: day.Expected,
+			Observed: day.Observed,
+			Coverage: day.Coverage(),
+		})
+	}
+	return response
+}
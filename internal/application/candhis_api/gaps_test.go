@@ -0,0 +1,108 @@
+package candhisapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCampaignGaps_Success(t *testing.T) {
+	waveDataRepo, router := setupGapsAPI(t)
+
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ appmodel.WaveDataExportQuery,
+			writer appmodel.WaveDataExportWriter,
+		) error {
+			require.NoError(t, writer.Begin(2))
+			require.NoError(t, writer.Write(
+				modeltest.MustCreateWaveData(t, "16/09/2024", "23:00", "0.6", "1.1", "4.7", "8", "32", "15")))
+			require.NoError(t, writer.Write(
+				modeltest.MustCreateWaveData(t, "17/09/2024", "00:30", "0.5", "0.9", "4.8", "4", "47", "15")))
+			return writer.End()
+		})
+
+	resp := performRequest(router,
+		"/campaigns/les-pierres-noires/gaps?from=2024-09-16T23:00:00Z&to=2024-09-17T01:00:00Z")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"from": "2024-09-16T23:00:00Z",
+		"to": "2024-09-17T01:00:00Z",
+		"expected": 4,
+		"observed": 2,
+		"coverage": 50,
+		"gaps": [{"from": "2024-09-16T23:30:00Z", "to": "2024-09-17T00:30:00Z", "missing": 2}],
+		"days": [
+			{"day": "2024-09-16", "expected": 2, "observed": 1, "coverage": 50},
+			{"day": "2024-09-17", "expected": 2, "observed": 1, "coverage": 50}
+		]
+	}`, resp.Body.String())
+}
+
+func TestGetCampaignGaps_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"invalid from format": {
+			path:           "/campaigns/les-pierres-noires/gaps?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"from after to": {
+			path:           "/campaigns/les-pierres-noires/gaps?from=2024-09-18T00:00:00Z&to=2024-09-17T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from must be before to"}`,
+		},
+		"repository failure": {
+			path:           "/campaigns/les-pierres-noires/gaps",
+			repoErr:        errors.New("error elasticsearch"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: `{"error": "failed to analyse gaps: failed to read campaign observations: ` +
+				`error elasticsearch"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			waveDataRepo, router := setupGapsAPI(t)
+			if tc.repoErr != nil {
+				waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+					Return(tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func setupGapsAPI(t *testing.T) (*persistencemock.MockWaveData, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	waveDataRepo := persistencemock.NewMockWaveData(ctrl)
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{
+		Station:     knownStations(t, ctrl),
+		GapAnalyser: service.NewGapAnalyser(waveDataRepo),
+	})
+
+	return waveDataRepo, router
+}
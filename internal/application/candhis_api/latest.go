@@ -0,0 +1,33 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func (s candhisAPI) GetCampaignLatestObservation(c *gin.Context, campaign string) {
+	station, ok := s.campaignStation(c, campaign)
+	if !ok {
+		return
+	}
+
+	latest, err := s.latestWaveData.Get(c.Request.Context(), *station)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get latest observation: %v", err)})
+		return
+	}
+	if latest == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf("no observation for campaign: %s", campaign)})
+		return
+	}
+
+	c.JSON(http.StatusOK, openapi.LatestObservation{
+		Observation:       toOpenAPIWaveData(latest.WaveData),
+		AgeSeconds:        int(latest.Age.Seconds()),
+		Stale:             latest.Stale,
+		StaleAfterSeconds: int(latest.StaleAfter.Seconds()),
+	})
+}
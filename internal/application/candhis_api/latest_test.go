@@ -0,0 +1,88 @@
+package candhisapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"github.com/tul1/candhis_api/openapi"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCampaignLatestObservation_Success(t *testing.T) {
+	waveDataRepo, router := setupLatestAPI(t)
+
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{waveData}}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/latest")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var latest openapi.LatestObservation
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &latest))
+	assert.Equal(t, openapi.WaveData{
+		Timestamp:             time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC),
+		H13:                   0.6,
+		Hmax:                  1.1,
+		Th13:                  4.7,
+		PeakDirection:         8,
+		PeakDirectionalSpread: 32,
+		Temperature:           15,
+	}, latest.Observation)
+	assert.InDelta(t, time.Since(waveData.Timestamp()).Seconds(), latest.AgeSeconds, 2)
+	assert.True(t, latest.Stale)
+	assert.Equal(t, 3600, latest.StaleAfterSeconds)
+}
+
+func TestGetCampaignLatestObservation_NotFound(t *testing.T) {
+	waveDataRepo, router := setupLatestAPI(t)
+
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).Return(appmodel.WaveDataPage{}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/latest")
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error": "no observation for campaign: les-pierres-noires"}`, resp.Body.String())
+}
+
+func TestGetCampaignLatestObservation_Failure(t *testing.T) {
+	waveDataRepo, router := setupLatestAPI(t)
+
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		Return(appmodel.WaveDataPage{}, errors.New("error elasticsearch"))
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/latest")
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.JSONEq(t, `{"error": "failed to get latest observation: failed to list campaign observations: error elasticsearch"}`,
+		resp.Body.String())
+}
+
+func setupLatestAPI(t *testing.T) (*persistencemock.MockWaveData, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	waveDataRepo := persistencemock.NewMockWaveData(ctrl)
+	scrapeRunRepo := persistencemock.NewMockScrapeRun(ctrl)
+	scrapeRunRepo.EXPECT().LastRunFinishedAt(gomock.Any(), "02911", gomock.Any()).
+		Return(time.Date(2024, 9, 17, 9, 0, 2, 0, time.UTC), nil).AnyTimes()
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{
+		Station:        knownStations(t, ctrl),
+		LatestWaveData: service.NewLatestWaveData(waveDataRepo, scrapeRunRepo, time.Hour, time.Minute),
+	})
+
+	return waveDataRepo, router
+}
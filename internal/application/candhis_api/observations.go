@@ -0,0 +1,94 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func (s candhisAPI) ListCampaignObservations(
+	c *gin.Context,
+	campaign string,
+	params openapi.ListCampaignObservationsParams,
+) {
+	if _, ok := s.campaignStation(c, campaign); !ok {
+		return
+	}
+
+	var cursor string
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	var sort appmodel.SortOrder
+	if params.Sort != nil {
+		sort = appmodel.SortOrder(*params.Sort)
+	}
+
+	query, err := appmodel.NewWaveDataQuery(params.From, params.To, cursor, limit, sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	page, err := s.waveData.List(c.Request.Context(), campaign, query)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list campaign observations: %v", err)})
+		return
+	}
+
+	observations := make([]openapi.WaveData, 0, len(page.WaveData))
+	for _, waveData := range page.WaveData {
+		observations = append(observations, toOpenAPIWaveData(waveData))
+	}
+
+	response := openapi.ObservationsPage{Observations: observations}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func toOpenAPIWaveData(waveData model.WaveData) openapi.WaveData {
+	observation := openapi.WaveData{
+		Timestamp:             waveData.Timestamp(),
+		H13:                   waveData.AverageTopThirdWaveHeight(),
+		Hmax:                  waveData.MaxHeight(),
+		Th13:                  waveData.AverageTopThirdWavePeriod(),
+		PeakDirection:         waveData.PeakDirection(),
+		PeakDirectionalSpread: waveData.PeakDirectionalSpread(),
+		Temperature:           waveData.Temperature(),
+	}
+	for m, field := range map[model.Measurement]**float64{
+		model.MeasurementPeakPeriod:         &observation.Tp,
+		model.MeasurementMeanPeriod:         &observation.Tz,
+		model.MeasurementMeanDirection:      &observation.MeanDirection,
+		model.MeasurementSpectralWaveHeight: &observation.Hm0,
+		model.MeasurementWindSpeed:          &observation.WindSpeed,
+		model.MeasurementWindDirection:      &observation.WindDirection,
+	} {
+		if value, ok := waveData.Measurement(m); ok {
+			*field = &value
+		}
+	}
+	if flags := waveData.QCFlags(); flags.Evaluated() {
+		observation.Qc = &openapi.QCFlags{
+			Flag:        openapi.QCFlag(flags.Aggregate()),
+			GrossRange:  openapi.QCFlag(flags.GrossRange),
+			Consistency: openapi.QCFlag(flags.Consistency),
+			Spike:       openapi.QCFlag(flags.Spike),
+			FlatLine:    openapi.QCFlag(flags.FlatLine),
+		}
+	}
+	return observation
+}
@@ -0,0 +1,237 @@
+package candhisapi_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListCampaignObservations_Success(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	expectedQuery, err := appmodel.NewWaveDataQuery(&from, nil, "", 2, appmodel.SortOrderAsc)
+	require.NoError(t, err)
+
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", expectedQuery).
+		Return(appmodel.NewWaveDataPage(wavesData, expectedQuery), nil)
+
+	resp := performRequest(router,
+		"/campaigns/les-pierres-noires/observations?from=2024-09-17T00:00:00Z&limit=2&sort=asc")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"observations": [
+			{"timestamp": "2024-09-17T08:30:00Z", "h1_3": 0.5, "hmax": 0.9, "th1_3": 4.8,
+				"peak_direction": 4, "peak_directional_spread": 47, "temperature": 15},
+			{"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+				"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15}
+		],
+		"next_cursor": "MjAyNC0wOS0xN1QwOTowMDowMFo"
+	}`, resp.Body.String())
+}
+
+func TestListCampaignObservations_QCFlags(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "0.5", "4.7", "8", "32", "15").
+		WithQCFlags(model.QCFlags{
+			GrossRange:  model.QCFlagPass,
+			Consistency: model.QCFlagFail,
+			Spike:       model.QCFlagPass,
+			FlatLine:    model.QCFlagNotEvaluated,
+		})
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{waveData}}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/observations")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"observations": [
+			{"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 0.5, "th1_3": 4.7,
+				"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15,
+				"qc": {"flag": 4, "gross_range": 1, "consistency": 4, "spike": 1, "flat_line": 2}}
+		]
+	}`, resp.Body.String())
+}
+
+func TestListCampaignObservations_Measurements(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	waveData = modeltest.MustWithMeasurement(t, waveData, model.MeasurementPeakPeriod, "11.2")
+	waveData = modeltest.MustWithMeasurement(t, waveData, model.MeasurementWindDirection, "0")
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{waveData}}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/observations")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"observations": [
+			{"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+				"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15, "tp": 11.2, "wind_direction": 0}
+		]
+	}`, resp.Body.String())
+}
+
+func TestListCampaignObservations_EmptyPage(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).Return(appmodel.WaveDataPage{}, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/observations")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"observations": []}`, resp.Body.String())
+}
+
+func TestListCampaignObservations_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"invalid from format": {
+			path:           "/campaigns/les-pierres-noires/observations?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"from after to": {
+			path:           "/campaigns/les-pierres-noires/observations?from=2024-09-18T00:00:00Z&to=2024-09-17T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from must not be after to"}`,
+		},
+		"invalid cursor": {
+			path:           "/campaigns/les-pierres-noires/observations?cursor=!!!",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid cursor"}`,
+		},
+		"repository error": {
+			path:           "/campaigns/les-pierres-noires/observations",
+			repoErr:        errors.New("error elasticsearch"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to list campaign observations: error elasticsearch"}`,
+		},
+		"storage unavailable": {
+			path:           "/campaigns/les-pierres-noires/observations",
+			repoErr:        fmt.Errorf("error searching documents: %w: connection refused", appmodel.ErrStorageUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"error": "failed to list campaign observations: error searching documents: ` +
+				`storage unavailable: connection refused"}`,
+		},
+		"invalid observation": {
+			path:           "/campaigns/les-pierres-noires/observations",
+			repoErr:        fmt.Errorf("%w: invalid value for hmax", model.ErrInvalidObservation),
+			expectedStatus: http.StatusBadRequest,
+		},
+		"invalid input": {
+			path:           "/campaigns/les-pierres-noires/observations",
+			repoErr:        fmt.Errorf("failed to read cursor: %w", appmodel.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			waveDataRepo, router := setupObservationsAPI(t)
+			if tc.repoErr != nil {
+				waveDataRepo.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+					Return(appmodel.WaveDataPage{}, tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestCampaignPaths_UnknownCampaign(t *testing.T) {
+	testCases := map[string]string{
+		"wildcard":         "/campaigns/*/observations",
+		"all indices":      "/campaigns/_all/statistics?interval=day&from=2024-09-01T00:00:00Z&to=2024-09-30T00:00:00Z",
+		"list of indices":  "/campaigns/les-pierres-noires,belle-ile/export?from=2024-09-01T00:00:00Z&to=2024-09-30T00:00:00Z",
+		"spectra index":    "/campaigns/les-pierres-noires-spectra/latest",
+		"unknown campaign": "/campaigns/unknown/gaps",
+		"unknown spectra":  "/campaigns/unknown/spectra/nearest?timestamp=2024-09-17T09:10:00Z",
+	}
+
+	for name, path := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, router := setupObservationsAPI(t)
+
+			resp := performRequest(router, path)
+
+			assert.Equal(t, http.StatusNotFound, resp.Code)
+			assert.Contains(t, resp.Body.String(), "campaign not found")
+		})
+	}
+}
+
+func TestCampaignPaths_StationFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stationRepo := persistencemock.NewMockStation(ctrl)
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{WaveData: persistencemock.NewMockWaveData(ctrl), Station: stationRepo})
+
+	stationRepo.EXPECT().Get(gomock.Any(), "les-pierres-noires").
+		Return(nil, fmt.Errorf("error querying campaigns: %w", appmodel.ErrStorageUnavailable))
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/observations")
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.JSONEq(t, `{"error": "failed to get campaign: error querying campaigns: storage unavailable"}`,
+		resp.Body.String())
+}
+
+func performRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return resp
+}
+
+// knownStations returns a station repository whose catalogue only has the les-pierres-noires campaign.
+func knownStations(t *testing.T, ctrl *gomock.Controller) *persistencemock.MockStation {
+	t.Helper()
+
+	station := appmodeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.2908, -4.9678, nil, "Cerema", true)
+	stationRepo := persistencemock.NewMockStation(ctrl)
+	stationRepo.EXPECT().Get(gomock.Any(), "les-pierres-noires").Return(&station, nil).AnyTimes()
+	stationRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	return stationRepo
+}
+
+func setupObservationsAPI(t *testing.T) (*persistencemock.MockWaveData, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	waveDataRepo := persistencemock.NewMockWaveData(ctrl)
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{WaveData: waveDataRepo, Station: knownStations(t, ctrl)})
+
+	return waveDataRepo, router
+}
@@ -0,0 +1,62 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+const (
+	defaultScrapeRunsLimit = 50
+	maxScrapeRunsLimit     = 500
+)
+
+func (s candhisAPI) ListScrapeRuns(c *gin.Context, params openapi.ListScrapeRunsParams) {
+	limit := defaultScrapeRunsLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxScrapeRunsLimit {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: "invalid limit: must be between 1 and 500"})
+		return
+	}
+
+	runs, err := s.scrapeRun.ListRecent(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to list scrape runs: %v", err)})
+		return
+	}
+
+	scrapeRuns := make([]openapi.ScrapeRun, 0, len(runs))
+	for _, run := range runs {
+		scrapeRuns = append(scrapeRuns, toOpenAPIScrapeRun(run))
+	}
+
+	c.JSON(http.StatusOK, openapi.ScrapeRunsList{ScrapeRuns: scrapeRuns})
+}
+
+func toOpenAPIScrapeRun(run appmodel.ScrapeRun) openapi.ScrapeRun {
+	counts := run.Counts()
+	scrapeRun := openapi.ScrapeRun{
+		Scraper:    openapi.ScrapeRunScraper(run.Scraper()),
+		StartedAt:  run.StartedAt(),
+		FinishedAt: run.FinishedAt(),
+		Outcome:    openapi.ScrapeRunOutcome(run.Outcome()),
+		RowsSeen:   counts.RowsSeen,
+		Parsed:     counts.Parsed,
+		Rejected:   counts.Rejected,
+		Indexed:    counts.Indexed,
+		Unchanged:  counts.Unchanged,
+		Failed:     counts.Failed,
+	}
+	if campaign := run.Campaign(); campaign != "" {
+		scrapeRun.Campaign = &campaign
+	}
+	if errorText := run.ErrorText(); errorText != "" {
+		scrapeRun.Error = &errorText
+	}
+	return scrapeRun
+}
@@ -0,0 +1,106 @@
+package candhisapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListScrapeRuns_Success(t *testing.T) {
+	scrapeRunRepo, router := setupScrapeRunsAPI(t)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	campaignsRun, err := appmodel.NewScrapeRun(appmodel.ScraperCampaigns, "02911", startedAt, startedAt.Add(2*time.Second),
+		"", appmodel.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1})
+	require.NoError(t, err)
+	sessionIDRun, err := appmodel.NewScrapeRun(appmodel.ScraperSessionID, "", startedAt.Add(-time.Hour),
+		startedAt.Add(-time.Hour+5*time.Second), "failed to get session ID from web: timeout", appmodel.ScrapeRunCounts{})
+	require.NoError(t, err)
+
+	scrapeRunRepo.EXPECT().ListRecent(gomock.Any(), 2).Return([]appmodel.ScrapeRun{campaignsRun, sessionIDRun}, nil)
+
+	resp := performRequest(router, "/scrape-runs?limit=2")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"scrape_runs": [
+			{"scraper": "campaigns", "campaign": "02911", "started_at": "2024-09-17T09:00:00Z",
+				"finished_at": "2024-09-17T09:00:02Z", "outcome": "success",
+				"rows_seen": 3, "parsed": 2, "rejected": 1, "indexed": 1, "unchanged": 1, "failed": 0},
+			{"scraper": "sessionid", "started_at": "2024-09-17T08:00:00Z", "finished_at": "2024-09-17T08:00:05Z",
+				"outcome": "failure", "error": "failed to get session ID from web: timeout",
+				"rows_seen": 0, "parsed": 0, "rejected": 0, "indexed": 0, "unchanged": 0, "failed": 0}
+		]
+	}`, resp.Body.String())
+}
+
+func TestListScrapeRuns_DefaultLimit(t *testing.T) {
+	scrapeRunRepo, router := setupScrapeRunsAPI(t)
+
+	scrapeRunRepo.EXPECT().ListRecent(gomock.Any(), 50).Return(nil, nil)
+
+	resp := performRequest(router, "/scrape-runs")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"scrape_runs": []}`, resp.Body.String())
+}
+
+func TestListScrapeRuns_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"limit not a number": {
+			path:           "/scrape-runs?limit=ten",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"limit too big": {
+			path:           "/scrape-runs?limit=501",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid limit: must be between 1 and 500"}`,
+		},
+		"repository error": {
+			path:           "/scrape-runs",
+			repoErr:        errors.New("error db"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to list scrape runs: error db"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scrapeRunRepo, router := setupScrapeRunsAPI(t)
+			if tc.repoErr != nil {
+				scrapeRunRepo.EXPECT().ListRecent(gomock.Any(), 50).Return(nil, tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func setupScrapeRunsAPI(t *testing.T) (*persistencemock.MockScrapeRun, *gin.Engine) {
+	t.Helper()
+
+	scrapeRunRepo := persistencemock.NewMockScrapeRun(gomock.NewController(t))
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{ScrapeRun: scrapeRunRepo})
+
+	return scrapeRunRepo, router
+}
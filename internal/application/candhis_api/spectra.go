@@ -0,0 +1,68 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+const (
+	defaultSpectrumMaxDistanceMinutes = 180
+	maxSpectrumMaxDistanceMinutes     = 1440
+)
+
+func (s candhisAPI) GetCampaignNearestSpectrum(
+	c *gin.Context,
+	campaign string,
+	params openapi.GetCampaignNearestSpectrumParams,
+) {
+	if _, ok := s.campaignStation(c, campaign); !ok {
+		return
+	}
+
+	maxDistanceMinutes := defaultSpectrumMaxDistanceMinutes
+	if params.MaxDistanceMinutes != nil {
+		maxDistanceMinutes = *params.MaxDistanceMinutes
+	}
+	if maxDistanceMinutes < 1 || maxDistanceMinutes > maxSpectrumMaxDistanceMinutes {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: "invalid max_distance_minutes: must be between 1 and 1440"})
+		return
+	}
+
+	spectrum, err := s.spectrum.Nearest(c.Request.Context(), appmodel.SpectraIndexName(campaign),
+		params.Timestamp.UTC(), time.Duration(maxDistanceMinutes)*time.Minute)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to get nearest spectrum: %v", err)})
+		return
+	}
+	if spectrum == nil {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: fmt.Sprintf(
+			"no spectrum for campaign %s within %d minutes of %s", campaign, maxDistanceMinutes,
+			params.Timestamp.UTC().Format(time.RFC3339))})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPISpectrum(*spectrum))
+}
+
+func toOpenAPISpectrum(spectrum model.Spectrum) openapi.Spectrum {
+	bins := spectrum.Bins()
+	response := openapi.Spectrum{
+		Timestamp: spectrum.Timestamp(),
+		Bins:      make([]openapi.SpectrumBin, 0, len(bins)),
+	}
+	for _, bin := range bins {
+		response.Bins = append(response.Bins, openapi.SpectrumBin{
+			Frequency:     bin.Frequency,
+			EnergyDensity: bin.EnergyDensity,
+			Direction:     bin.Direction,
+			Spread:        bin.Spread,
+		})
+	}
+	return response
+}
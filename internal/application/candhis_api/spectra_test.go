@@ -0,0 +1,107 @@
+package candhisapi_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	candhisapi "github.com/tul1/candhis_api/internal/application/candhis_api"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCampaignNearestSpectrum_Success(t *testing.T) {
+	spectrumRepo, router := setupSpectraAPI(t)
+
+	spectrum := modeltest.MustCreateSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC),
+		model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		model.SpectrumBin{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+	)
+	spectrumRepo.EXPECT().Nearest(gomock.Any(), "les-pierres-noires-spectra",
+		time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC), 3*time.Hour).Return(&spectrum, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/spectra/nearest?timestamp=2024-09-17T11:10:00%2B02:00")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"timestamp": "2024-09-17T09:00:00Z",
+		"bins": [
+			{"frequency": 0.04, "energy_density": 0.012, "direction": 285, "spread": 32},
+			{"frequency": 0.05, "energy_density": 0.35, "direction": 280, "spread": 25}
+		]
+	}`, resp.Body.String())
+}
+
+func TestGetCampaignNearestSpectrum_MaxDistance(t *testing.T) {
+	spectrumRepo, router := setupSpectraAPI(t)
+
+	spectrumRepo.EXPECT().Nearest(gomock.Any(), "les-pierres-noires-spectra",
+		time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC), 30*time.Minute).Return(nil, nil)
+
+	resp := performRequest(router,
+		"/campaigns/les-pierres-noires/spectra/nearest?timestamp=2024-09-17T09:10:00Z&max_distance_minutes=30")
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error": "no spectrum for campaign les-pierres-noires within 30 minutes of 2024-09-17T09:10:00Z"}`,
+		resp.Body.String())
+}
+
+func TestGetCampaignNearestSpectrum_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"missing timestamp": {
+			path:           "/campaigns/les-pierres-noires/spectra/nearest",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"max distance out of range": {
+			path:           "/campaigns/les-pierres-noires/spectra/nearest?timestamp=2024-09-17T09:10:00Z&max_distance_minutes=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid max_distance_minutes: must be between 1 and 1440"}`,
+		},
+		"storage unavailable": {
+			path:           "/campaigns/les-pierres-noires/spectra/nearest?timestamp=2024-09-17T09:10:00Z",
+			repoErr:        fmt.Errorf("error searching documents: %w", appmodel.ErrStorageUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"error": "failed to get nearest spectrum: error searching documents: ` +
+				`storage unavailable"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			spectrumRepo, router := setupSpectraAPI(t)
+			if tc.repoErr != nil {
+				spectrumRepo.EXPECT().Nearest(gomock.Any(), "les-pierres-noires-spectra", gomock.Any(), gomock.Any()).
+					Return(nil, tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func setupSpectraAPI(t *testing.T) (*persistencemock.MockSpectrum, *gin.Engine) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	spectrumRepo := persistencemock.NewMockSpectrum(ctrl)
+	router := gin.New()
+	candhisapi.NewCandhisAPI(router, candhisapi.Deps{Station: knownStations(t, ctrl), Spectrum: spectrumRepo})
+
+	return spectrumRepo, router
+}
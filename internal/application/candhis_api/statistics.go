@@ -0,0 +1,96 @@
+package candhisapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func (s candhisAPI) GetCampaignStatistics(
+	c *gin.Context,
+	campaign string,
+	params openapi.GetCampaignStatisticsParams,
+) {
+	if _, ok := s.campaignStation(c, campaign); !ok {
+		return
+	}
+
+	var interval appmodel.StatisticsInterval
+	if params.Interval != nil {
+		interval = appmodel.StatisticsInterval(*params.Interval)
+	}
+
+	var fields []appmodel.StatisticsField
+	if params.Fields != nil {
+		for _, field := range *params.Fields {
+			fields = append(fields, appmodel.StatisticsField(field))
+		}
+	}
+
+	var metrics []appmodel.StatisticsMetric
+	if params.Metrics != nil {
+		for _, metric := range *params.Metrics {
+			metrics = append(metrics, appmodel.StatisticsMetric(metric))
+		}
+	}
+
+	query, err := appmodel.NewWaveDataStatisticsQuery(params.From, params.To, interval, fields, metrics)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	buckets, err := s.waveData.Statistics(c.Request.Context(), campaign, query)
+	if err != nil {
+		c.JSON(errorStatus(err), openapi.ErrorResponse{Error: fmt.Sprintf("failed to compute campaign statistics: %v", err)})
+		return
+	}
+
+	response := openapi.WaveDataStatistics{
+		Interval: openapi.WaveDataStatisticsInterval(query.Interval()),
+		Buckets:  make([]openapi.WaveDataStatisticsBucket, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		response.Buckets = append(response.Buckets, toOpenAPIWaveDataStatisticsBucket(bucket))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func toOpenAPIWaveDataStatisticsBucket(bucket appmodel.WaveDataStatisticsBucket) openapi.WaveDataStatisticsBucket {
+	return openapi.WaveDataStatisticsBucket{
+		Start:             bucket.Start,
+		Count:             bucket.Count,
+		H13:               toOpenAPIFieldStatistics(bucket.Values[appmodel.StatisticsFieldAverageTopThirdWaveHeight]),
+		Hmax:              toOpenAPIFieldStatistics(bucket.Values[appmodel.StatisticsFieldMaxHeight]),
+		Th13:              toOpenAPIFieldStatistics(bucket.Values[appmodel.StatisticsFieldAverageTopThirdWavePeriod]),
+		Temperature:       toOpenAPIFieldStatistics(bucket.Values[appmodel.StatisticsFieldTemperature]),
+		PeakDirectionMean: bucket.PeakDirectionMean,
+	}
+}
+
+func toOpenAPIFieldStatistics(values map[appmodel.StatisticsMetric]float64) *openapi.FieldStatistics {
+	if len(values) == 0 {
+		return nil
+	}
+
+	metric := func(metric appmodel.StatisticsMetric) *float64 {
+		value, ok := values[metric]
+		if !ok {
+			return nil
+		}
+		return &value
+	}
+
+	return &openapi.FieldStatistics{
+		Min: metric(appmodel.StatisticsMetricMin),
+		Max: metric(appmodel.StatisticsMetricMax),
+		Avg: metric(appmodel.StatisticsMetricAvg),
+		P50: metric(appmodel.StatisticsMetricP50),
+		P90: metric(appmodel.StatisticsMetricP90),
+		P99: metric(appmodel.StatisticsMetricP99),
+	}
+}
@@ -0,0 +1,148 @@
+package candhisapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCampaignStatistics_Success(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	expectedQuery, err := appmodel.NewWaveDataStatisticsQuery(&from, nil, appmodel.StatisticsIntervalMonth,
+		[]appmodel.StatisticsField{appmodel.StatisticsFieldMaxHeight, appmodel.StatisticsFieldAverageTopThirdWaveHeight},
+		[]appmodel.StatisticsMetric{appmodel.StatisticsMetricMax, appmodel.StatisticsMetricP90})
+	require.NoError(t, err)
+
+	peakDirectionMean := 284.5
+	waveDataRepo.EXPECT().Statistics(gomock.Any(), "les-pierres-noires", expectedQuery).
+		Return([]appmodel.WaveDataStatisticsBucket{
+			{
+				Start: from,
+				Count: 48,
+				Values: map[appmodel.StatisticsField]map[appmodel.StatisticsMetric]float64{
+					appmodel.StatisticsFieldMaxHeight: {
+						appmodel.StatisticsMetricMax: 2.4, appmodel.StatisticsMetricP90: 1.9,
+					},
+					appmodel.StatisticsFieldAverageTopThirdWaveHeight: {
+						appmodel.StatisticsMetricMax: 1.3, appmodel.StatisticsMetricP90: 1.1,
+					},
+				},
+				PeakDirectionMean: &peakDirectionMean,
+			},
+			{Start: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+
+	resp := performRequest(router,
+		"/campaigns/les-pierres-noires/statistics?from=2024-09-17T00:00:00Z&interval=month&fields=hmax,h1_3&metrics=max,p90")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"interval": "month",
+		"buckets": [
+			{
+				"start": "2024-09-17T00:00:00Z",
+				"count": 48,
+				"hmax": {"max": 2.4, "p90": 1.9},
+				"h1_3": {"max": 1.3, "p90": 1.1},
+				"peak_direction_mean": 284.5
+			},
+			{"start": "2024-10-01T00:00:00Z", "count": 0}
+		]
+	}`, resp.Body.String())
+}
+
+func TestGetCampaignStatistics_Defaults(t *testing.T) {
+	waveDataRepo, router := setupObservationsAPI(t)
+
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	expectedQuery, err := appmodel.NewWaveDataStatisticsQuery(&from, &to, "", nil, nil)
+	require.NoError(t, err)
+	waveDataRepo.EXPECT().Statistics(gomock.Any(), "les-pierres-noires", expectedQuery).Return(nil, nil)
+
+	resp := performRequest(router, "/campaigns/les-pierres-noires/statistics?from=2024-09-01T00:00:00Z&to=2024-10-01T00:00:00Z")
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"interval": "day", "buckets": []}`, resp.Body.String())
+}
+
+func TestGetCampaignStatistics_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		path           string
+		repoErr        error
+		expectedStatus int
+		expectedBody   string
+	}{
+		"invalid from format": {
+			path:           "/campaigns/les-pierres-noires/statistics?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"from after to": {
+			path:           "/campaigns/les-pierres-noires/statistics?from=2024-09-18T00:00:00Z&to=2024-09-17T00:00:00Z",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from must not be after to"}`,
+		},
+		"too many buckets": {
+			path: "/campaigns/les-pierres-noires/statistics?from=2000-01-01T00:00:00Z&to=2024-09-17T00:00:00Z" +
+				"&interval=hour",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: must span at most 10000 hour intervals"}`,
+		},
+		"hour interval without to": {
+			path:           "/campaigns/les-pierres-noires/statistics?from=2024-09-17T00:00:00Z&interval=hour",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from and to are required with the hour interval"}`,
+		},
+		"default interval without bounds": {
+			path:           "/campaigns/les-pierres-noires/statistics",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid time range: from and to are required with the day interval"}`,
+		},
+		"unknown interval": {
+			path:           "/campaigns/les-pierres-noires/statistics?interval=minute",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid interval: must be one of hour, day, week, month"}`,
+		},
+		"unknown field": {
+			path:           "/campaigns/les-pierres-noires/statistics?interval=month&fields=hmax,peak_direction",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid field: must be one of h1_3, hmax, th1_3, temperature"}`,
+		},
+		"unknown metric": {
+			path:           "/campaigns/les-pierres-noires/statistics?interval=month&metrics=p95",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error": "invalid metric: must be one of min, max, avg, p50, p90, p99"}`,
+		},
+		"repository error": {
+			path:           "/campaigns/les-pierres-noires/statistics?interval=month",
+			repoErr:        errors.New("error elasticsearch"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error": "failed to compute campaign statistics: error elasticsearch"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			waveDataRepo, router := setupObservationsAPI(t)
+			if tc.repoErr != nil {
+				waveDataRepo.EXPECT().Statistics(gomock.Any(), "les-pierres-noires", gomock.Any()).
+					Return(nil, tc.repoErr)
+			}
+
+			resp := performRequest(router, tc.path)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// csvHeader reproduces the columns of the Candhis campaign tables, the optional measurements of model.Measurements
+// included.
+var csvHeader = []string{
+	"Date", "Heure (TU)", "H1/3 (m)", "Hmax (m)", "Th1/3 (s)", "Dir. au pic (°)", "Etal. au pic (°)", "Temp. mer (°C)",
+	"Tp (s)", "Tz (s)", "Dir. moy. (°)", "Hm0 (m)", "Vent (m/s)", "Dir. vent (°)",
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Begin(int) error {
+	return w.writer.Write(csvHeader)
+}
+
+func (w *csvWriter) Write(waveData model.WaveData) error {
+	timestamp := waveData.Timestamp().UTC()
+	record := []string{
+		timestamp.Format("02/01/2006"),
+		timestamp.Format("15:04"),
+		strconv.FormatFloat(waveData.AverageTopThirdWaveHeight(), 'f', -1, 64),
+		strconv.FormatFloat(waveData.MaxHeight(), 'f', -1, 64),
+		strconv.FormatFloat(waveData.AverageTopThirdWavePeriod(), 'f', -1, 64),
+		strconv.Itoa(waveData.PeakDirection()),
+		strconv.Itoa(waveData.PeakDirectionalSpread()),
+		strconv.FormatFloat(waveData.Temperature(), 'f', -1, 64),
+	}
+	// The cells of the measurements the observation lacks are left empty.
+	for _, m := range model.Measurements {
+		value, ok := waveData.Measurement(m)
+		if !ok {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) End() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
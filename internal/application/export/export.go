@@ -0,0 +1,62 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatNetCDF Format = "netcdf"
+)
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatCSV, FormatNDJSON, FormatNetCDF:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("invalid format: must be one of csv, ndjson, netcdf")
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/x-netcdf"
+	}
+}
+
+func (f Format) FileExtension() string {
+	switch f {
+	case FormatCSV:
+		return ".csv"
+	case FormatNDJSON:
+		return ".ndjson"
+	default:
+		return ".nc"
+	}
+}
+
+// NewWriter returns the writer encoding the observations of a campaign in format to w. Writers do not buffer more
+// than a single observation, the export is streamed to w as it is read.
+func NewWriter(format Format, w io.Writer, campaign string) (appmodel.WaveDataExportWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatNetCDF:
+		return newNetCDFWriter(w, campaign), nil
+	default:
+		return nil, fmt.Errorf("invalid format: must be one of csv, ndjson, netcdf")
+	}
+}
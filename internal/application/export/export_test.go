@@ -0,0 +1,119 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/export"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := export.ParseFormat("netcdf")
+	require.NoError(t, err)
+	assert.Equal(t, export.FormatNetCDF, format)
+	assert.Equal(t, "application/x-netcdf", format.ContentType())
+	assert.Equal(t, ".nc", format.FileExtension())
+
+	_, err = export.ParseFormat("xlsx")
+	assert.EqualError(t, err, "invalid format: must be one of csv, ndjson, netcdf")
+}
+
+func TestCSVWriter(t *testing.T) {
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15.2")
+	waveData = modeltest.MustWithMeasurement(t, waveData, model.MeasurementPeakPeriod, "11.2")
+	waveData = modeltest.MustWithMeasurement(t, waveData, model.MeasurementWindDirection, "270")
+
+	var out bytes.Buffer
+	writeExport(t, export.FormatCSV, &out, []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+		waveData,
+	})
+
+	assert.Equal(t, "Date,Heure (TU),H1/3 (m),Hmax (m),Th1/3 (s),Dir. au pic (°),Etal. au pic (°),Temp. mer (°C),"+
+		"Tp (s),Tz (s),Dir. moy. (°),Hm0 (m),Vent (m/s),Dir. vent (°)\n"+
+		"17/09/2024,08:30,0.5,0.9,4.8,4,47,15,,,,,,\n"+
+		"17/09/2024,09:00,0.6,1.1,4.7,8,32,15.2,11.2,,,,,270\n", out.String())
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var out bytes.Buffer
+	writeExport(t, export.FormatNDJSON, &out, []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	})
+
+	assert.Equal(t,
+		`{"timestamp":"2024-09-17T08:30:00Z","h1_3":0.5,"hmax":0.9,"th1_3":4.8,"peak_direction":4,"peak_directional_spread":47,"temperature":15}`+"\n"+
+			`{"timestamp":"2024-09-17T09:00:00Z","h1_3":0.6,"hmax":1.1,"th1_3":4.7,"peak_direction":8,"peak_directional_spread":32,"temperature":15}`+"\n",
+		out.String())
+}
+
+func TestNetCDFWriter(t *testing.T) {
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	withMeasurement := modeltest.MustWithMeasurement(t, waveData, model.MeasurementWindSpeed, "8.5")
+
+	var out bytes.Buffer
+	writeExport(t, export.FormatNetCDF, &out, []model.WaveData{waveData, withMeasurement})
+	file := out.Bytes()
+
+	assert.Equal(t, []byte("CDF\x02"), file[:4])
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(file[4:8]), "number of records")
+	assert.Contains(t, string(file), "CF-1.8")
+	assert.Contains(t, string(file), "Candhis wave observations of les-pierres-noires")
+	assert.Contains(t, string(file), "sea_surface_wave_significant_height")
+	assert.Contains(t, string(file), "_FillValue")
+
+	// Each record holds time, h1_3, hmax, th1_3, the two padded shorts, temperature and the optional measurements
+	// tp, tz, mean_direction, hm0, wind_speed and wind_direction.
+	fill := math.Float32bits(9.9692099683868690e+36)
+	record := func(windSpeed uint32) []byte {
+		var record []byte
+		record = binary.BigEndian.AppendUint64(record, math.Float64bits(float64(waveData.Timestamp().Unix())))
+		record = binary.BigEndian.AppendUint32(record, math.Float32bits(0.6))
+		record = binary.BigEndian.AppendUint32(record, math.Float32bits(1.1))
+		record = binary.BigEndian.AppendUint32(record, math.Float32bits(4.7))
+		record = append(record, 0, 8, 0, 0, 0, 32, 0, 0)
+		record = binary.BigEndian.AppendUint32(record, math.Float32bits(15))
+		for _, value := range []uint32{fill, fill, fill, fill, windSpeed, fill} {
+			record = binary.BigEndian.AppendUint32(record, value)
+		}
+		return record
+	}
+	first, second := record(fill), record(math.Float32bits(8.5))
+	require.Len(t, first, 56)
+
+	headerSize := len(file) - len(first) - len(second)
+	assert.Equal(t, append(first, second...), file[headerSize:])
+	assert.Zero(t, headerSize%4, "the header is padded to 4 bytes")
+
+	// The begin offset of the last variable, wind_direction, is the last 8 bytes of the header.
+	assert.Equal(t, uint64(headerSize+52), binary.BigEndian.Uint64(file[headerSize-8:headerSize]))
+}
+
+func TestNetCDFWriter_CountMismatch(t *testing.T) {
+	writer, err := export.NewWriter(export.FormatNetCDF, &bytes.Buffer{}, "les-pierres-noires")
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Begin(2))
+	require.NoError(t, writer.Write(modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")))
+	assert.EqualError(t, writer.End(), "wrote 1 observations instead of the 2 announced in the NetCDF header")
+}
+
+func writeExport(t *testing.T, format export.Format, out *bytes.Buffer, waveDataList []model.WaveData) {
+	t.Helper()
+
+	writer, err := export.NewWriter(format, out, "les-pierres-noires")
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Begin(len(waveDataList)))
+	for _, waveData := range waveDataList {
+		require.NoError(t, writer.Write(waveData))
+	}
+	require.NoError(t, writer.End())
+}
@@ -0,0 +1,29 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// ndjsonWriter writes one observation per line, with the fields of the observations API.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *ndjsonWriter) Begin(int) error {
+	return nil
+}
+
+func (w *ndjsonWriter) Write(waveData model.WaveData) error {
+	return w.encoder.Encode(waveData)
+}
+
+func (w *ndjsonWriter) End() error {
+	return nil
+}
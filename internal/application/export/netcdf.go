@@ -0,0 +1,308 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// NetCDF classic format tags and types, see https://docs.unidata.ucar.edu/netcdf-c/current/file_format_specifications.html
+const (
+	netCDFDimensionTag = 0x0A
+	netCDFVariableTag  = 0x0B
+	netCDFAttributeTag = 0x0C
+
+	netCDFChar   = 2
+	netCDFShort  = 3
+	netCDFFloat  = 5
+	netCDFDouble = 6
+)
+
+// netCDFFillFloat is the default fill value of the NetCDF floats, held by the optional measurements an observation
+// lacks.
+const netCDFFillFloat = float32(9.9692099683868690e+36)
+
+type netCDFAttribute struct {
+	name  string
+	value string
+}
+
+type netCDFVariable struct {
+	name       string
+	ncType     int32
+	attributes []netCDFAttribute
+	// optional variables declare netCDFFillFloat as their _FillValue.
+	optional bool
+	// put appends the big endian value of the variable for an observation.
+	put func(record []byte, waveData model.WaveData) []byte
+}
+
+// vsize is the size of the variable in a record, padded to 4 bytes.
+func (v netCDFVariable) vsize() int32 {
+	switch v.ncType {
+	case netCDFDouble:
+		return 8
+	default:
+		return 4
+	}
+}
+
+var netCDFVariables = []netCDFVariable{
+	{
+		name:   "time",
+		ncType: netCDFDouble,
+		attributes: []netCDFAttribute{
+			{"standard_name", "time"},
+			{"long_name", "Time of the observation"},
+			{"units", "seconds since 1970-01-01 00:00:00 UTC"},
+			{"calendar", "standard"},
+			{"axis", "T"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return binary.BigEndian.AppendUint64(record, math.Float64bits(float64(waveData.Timestamp().Unix())))
+		},
+	},
+	{
+		name:   "h1_3",
+		ncType: netCDFFloat,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_surface_wave_significant_height"},
+			{"long_name", "Significant wave height H1/3"},
+			{"units", "m"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFFloat(record, waveData.AverageTopThirdWaveHeight())
+		},
+	},
+	{
+		name:   "hmax",
+		ncType: netCDFFloat,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_surface_wave_maximum_height"},
+			{"long_name", "Height of the largest wave Hmax"},
+			{"units", "m"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFFloat(record, waveData.MaxHeight())
+		},
+	},
+	{
+		name:   "th1_3",
+		ncType: netCDFFloat,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_surface_wave_significant_period"},
+			{"long_name", "Significant wave period Th1/3"},
+			{"units", "s"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFFloat(record, waveData.AverageTopThirdWavePeriod())
+		},
+	},
+	{
+		name:   "peak_direction",
+		ncType: netCDFShort,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_surface_wave_from_direction_at_variance_spectral_density_maximum"},
+			{"long_name", "Direction of wave origin at the spectral peak"},
+			{"units", "degree"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFShort(record, waveData.PeakDirection())
+		},
+	},
+	{
+		name:   "peak_directional_spread",
+		ncType: netCDFShort,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_surface_wave_directional_spread_at_variance_spectral_density_maximum"},
+			{"long_name", "Directional spread at the spectral peak"},
+			{"units", "degree"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFShort(record, waveData.PeakDirectionalSpread())
+		},
+	},
+	{
+		name:   "temperature",
+		ncType: netCDFFloat,
+		attributes: []netCDFAttribute{
+			{"standard_name", "sea_water_temperature"},
+			{"long_name", "Sea water temperature"},
+			{"units", "degree_C"},
+		},
+		put: func(record []byte, waveData model.WaveData) []byte {
+			return appendNetCDFFloat(record, waveData.Temperature())
+		},
+	},
+	measurementVariable(model.MeasurementPeakPeriod, "sea_surface_wave_period_at_variance_spectral_density_maximum",
+		"Peak period Tp", "s"),
+	measurementVariable(model.MeasurementMeanPeriod, "sea_surface_wave_zero_upcrossing_period",
+		"Mean zero up-crossing period Tz", "s"),
+	measurementVariable(model.MeasurementMeanDirection, "sea_surface_wave_from_direction",
+		"Mean direction of wave origin", "degree"),
+	measurementVariable(model.MeasurementSpectralWaveHeight, "sea_surface_wave_significant_height",
+		"Spectral significant wave height Hm0", "m"),
+	measurementVariable(model.MeasurementWindSpeed, "wind_speed", "Wind speed", "m s-1"),
+	measurementVariable(model.MeasurementWindDirection, "wind_from_direction", "Direction of wind origin", "degree"),
+}
+
+// measurementVariable is the float variable of an optional measurement.
+func measurementVariable(m model.Measurement, standardName, longName, units string) netCDFVariable {
+	return netCDFVariable{
+		name:   string(m),
+		ncType: netCDFFloat,
+		attributes: []netCDFAttribute{
+			{"standard_name", standardName},
+			{"long_name", longName},
+			{"units", units},
+		},
+		optional: true,
+		put: func(record []byte, waveData model.WaveData) []byte {
+			value, ok := waveData.Measurement(m)
+			if !ok {
+				return binary.BigEndian.AppendUint32(record, math.Float32bits(netCDFFillFloat))
+			}
+			return appendNetCDFFloat(record, value)
+		},
+	}
+}
+
+// netCDFWriter writes a CF convention NetCDF classic file with 64-bit offsets, where every variable is a record
+// variable along the unlimited time dimension. The header holds the number of records, which is why it needs the
+// count of observations before the first one.
+type netCDFWriter struct {
+	w        io.Writer
+	campaign string
+	count    int
+	written  int
+	record   []byte
+}
+
+func newNetCDFWriter(w io.Writer, campaign string) *netCDFWriter {
+	return &netCDFWriter{w: w, campaign: campaign}
+}
+
+func (w *netCDFWriter) Begin(count int) error {
+	if count > math.MaxInt32 {
+		return fmt.Errorf("too many observations for a NetCDF classic file: %d", count)
+	}
+	w.count = count
+
+	// The variable offsets depend on the header size, which does not depend on the offsets.
+	header := w.header(0)
+	_, err := w.w.Write(w.header(int64(len(header))))
+	return err
+}
+
+func (w *netCDFWriter) Write(waveData model.WaveData) error {
+	w.record = w.record[:0]
+	for _, variable := range netCDFVariables {
+		w.record = variable.put(w.record, waveData)
+	}
+	if _, err := w.w.Write(w.record); err != nil {
+		return err
+	}
+	w.written++
+	return nil
+}
+
+func (w *netCDFWriter) End() error {
+	if w.written != w.count {
+		return fmt.Errorf("wrote %d observations instead of the %d announced in the NetCDF header", w.written, w.count)
+	}
+	return nil
+}
+
+func (w *netCDFWriter) header(dataOffset int64) []byte {
+	var header bytes.Buffer
+	header.WriteString("CDF\x02")
+	putInt32(&header, int32(w.count))
+
+	// The only dimension is the unlimited time dimension, whose length is the number of records.
+	putInt32(&header, netCDFDimensionTag)
+	putInt32(&header, 1)
+	putName(&header, "time")
+	putInt32(&header, 0)
+
+	putAttributes(&header, []netCDFAttribute{
+		{"Conventions", "CF-1.8"},
+		{"title", "Candhis wave observations of " + w.campaign},
+		{"institution", "Cerema"},
+		{"source", "Candhis campaign tables, https://candhis.cerema.fr"},
+	})
+
+	putInt32(&header, netCDFVariableTag)
+	putInt32(&header, int32(len(netCDFVariables)))
+	begin := dataOffset
+	for _, variable := range netCDFVariables {
+		putName(&header, variable.name)
+		putInt32(&header, 1)
+		putInt32(&header, 0)
+		putVariableAttributes(&header, variable)
+		putInt32(&header, variable.ncType)
+		putInt32(&header, variable.vsize())
+		_ = binary.Write(&header, binary.BigEndian, begin)
+		begin += int64(variable.vsize())
+	}
+
+	return header.Bytes()
+}
+
+func putInt32(buf *bytes.Buffer, value int32) {
+	_ = binary.Write(buf, binary.BigEndian, value)
+}
+
+func putName(buf *bytes.Buffer, name string) {
+	putInt32(buf, int32(len(name)))
+	buf.WriteString(name)
+	putPadding(buf, len(name))
+}
+
+func putAttributes(buf *bytes.Buffer, attributes []netCDFAttribute) {
+	putInt32(buf, netCDFAttributeTag)
+	putInt32(buf, int32(len(attributes)))
+	putCharAttributes(buf, attributes)
+}
+
+// putVariableAttributes writes the attributes of variable, followed by the _FillValue of the optional ones.
+func putVariableAttributes(buf *bytes.Buffer, variable netCDFVariable) {
+	if !variable.optional {
+		putAttributes(buf, variable.attributes)
+		return
+	}
+
+	putInt32(buf, netCDFAttributeTag)
+	putInt32(buf, int32(len(variable.attributes)+1))
+	putCharAttributes(buf, variable.attributes)
+	putName(buf, "_FillValue")
+	putInt32(buf, netCDFFloat)
+	putInt32(buf, 1)
+	_ = binary.Write(buf, binary.BigEndian, netCDFFillFloat)
+}
+
+func putCharAttributes(buf *bytes.Buffer, attributes []netCDFAttribute) {
+	for _, attribute := range attributes {
+		putName(buf, attribute.name)
+		putInt32(buf, netCDFChar)
+		putInt32(buf, int32(len(attribute.value)))
+		buf.WriteString(attribute.value)
+		putPadding(buf, len(attribute.value))
+	}
+}
+
+func putPadding(buf *bytes.Buffer, size int) {
+	buf.Write(make([]byte, (4-size%4)%4))
+}
+
+func appendNetCDFFloat(record []byte, value float64) []byte {
+	return binary.BigEndian.AppendUint32(record, math.Float32bits(float32(value)))
+}
+
+// appendNetCDFShort pads the short value to the 4 bytes of its record slot.
+func appendNetCDFShort(record []byte, value int) []byte {
+	return append(binary.BigEndian.AppendUint16(record, uint16(int16(value))), 0, 0)
+}
@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+type AlertState string
+
+const (
+	AlertStateFiring   AlertState = "firing"
+	AlertStateResolved AlertState = "resolved"
+)
+
+// AlertEvent is a state change of an alert rule, recorded with the outcome of its webhook delivery.
+type AlertEvent struct {
+	ruleID int64
+	state  AlertState
+	// Metric value and time of the most recent evaluated observation.
+	value      float64
+	observedAt time.Time
+	// Time the alert fired, resolved events keep the time of the firing they resolve.
+	firedAt   time.Time
+	createdAt time.Time
+	// Number of webhook requests sent, and the error of the last one when none was accepted.
+	deliveryAttempts int
+	deliveryError    string
+}
+
+func NewAlertEvent(
+	ruleID int64,
+	state AlertState,
+	value float64,
+	observedAt, firedAt, createdAt time.Time,
+	deliveryAttempts int,
+	deliveryError string,
+) (AlertEvent, error) {
+	if state != AlertStateFiring && state != AlertStateResolved {
+		return AlertEvent{}, newInvalidInputError("invalid alert event: unknown state")
+	}
+	if observedAt.Location() != time.UTC || firedAt.Location() != time.UTC || createdAt.Location() != time.UTC {
+		return AlertEvent{}, newInvalidInputError("invalid alert event: times must be in UTC format")
+	}
+	if deliveryAttempts < 0 {
+		return AlertEvent{}, newInvalidInputError("invalid alert event: delivery attempts cannot be negative")
+	}
+
+	return AlertEvent{
+		ruleID:           ruleID,
+		state:            state,
+		value:            value,
+		observedAt:       observedAt,
+		firedAt:          firedAt.Truncate(time.Microsecond), // database precision
+		createdAt:        createdAt.Truncate(time.Microsecond),
+		deliveryAttempts: deliveryAttempts,
+		deliveryError:    deliveryError,
+	}, nil
+}
+
+func (e AlertEvent) RuleID() int64 {
+	return e.ruleID
+}
+
+func (e AlertEvent) State() AlertState {
+	return e.state
+}
+
+func (e AlertEvent) Value() float64 {
+	return e.value
+}
+
+func (e AlertEvent) ObservedAt() time.Time {
+	return e.observedAt
+}
+
+func (e AlertEvent) FiredAt() time.Time {
+	return e.firedAt
+}
+
+func (e AlertEvent) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func (e AlertEvent) DeliveryAttempts() int {
+	return e.deliveryAttempts
+}
+
+func (e AlertEvent) DeliveryError() string {
+	return e.deliveryError
+}
+
+// AlertNotification is the content of the webhook request sent for an alert event.
+type AlertNotification struct {
+	Rule       AlertRule
+	State      AlertState
+	Value      float64
+	ObservedAt time.Time
+	FiredAt    time.Time
+}
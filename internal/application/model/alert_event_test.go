@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewAlertEventSuccess(t *testing.T) {
+	observedAt := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	firedAt := time.Date(2024, 9, 17, 10, 5, 0, 123456789, time.UTC)
+	createdAt := time.Date(2024, 9, 17, 12, 5, 0, 0, time.UTC)
+
+	event, err := model.NewAlertEvent(7, model.AlertStateResolved, 3.2, observedAt, firedAt, createdAt, 2,
+		"unexpected status 502 Bad Gateway")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), event.RuleID())
+	assert.Equal(t, model.AlertStateResolved, event.State())
+	assert.Equal(t, 3.2, event.Value())
+	assert.Equal(t, observedAt, event.ObservedAt())
+	assert.Equal(t, firedAt.Truncate(time.Microsecond), event.FiredAt())
+	assert.Equal(t, createdAt, event.CreatedAt())
+	assert.Equal(t, 2, event.DeliveryAttempts())
+	assert.Equal(t, "unexpected status 502 Bad Gateway", event.DeliveryError())
+}
+
+func TestNewAlertEventFailure(t *testing.T) {
+	now := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		state    model.AlertState
+		firedAt  time.Time
+		attempts int
+		errMsg   string
+	}{
+		"unknown state": {
+			state: "pending", firedAt: now, attempts: 1,
+			errMsg: "invalid alert event: unknown state",
+		},
+		"non-UTC times": {
+			state: model.AlertStateFiring, firedAt: now.In(time.FixedZone("Non-UTC", 3600)), attempts: 1,
+			errMsg: "invalid alert event: times must be in UTC format",
+		},
+		"negative attempts": {
+			state: model.AlertStateFiring, firedAt: now, attempts: -1,
+			errMsg: "invalid alert event: delivery attempts cannot be negative",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			event, err := model.NewAlertEvent(7, tc.state, 4.6, now, tc.firedAt, now, tc.attempts, "")
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.AlertEvent{}, event)
+		})
+	}
+}
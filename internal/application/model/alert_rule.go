@@ -0,0 +1,241 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+var ErrAlertRuleNotFound = errors.New("alert rule not found")
+
+// AlertMetric is the observed value of an alert rule. The rise metrics are the change of a field over the rule
+// window, from its oldest to its most recent observation.
+type AlertMetric string
+
+const (
+	AlertMetricAverageTopThirdWaveHeight     AlertMetric = "h1_3"
+	AlertMetricMaxHeight                     AlertMetric = "hmax"
+	AlertMetricAverageTopThirdWavePeriod     AlertMetric = "th1_3"
+	AlertMetricTemperature                   AlertMetric = "temperature"
+	AlertMetricAverageTopThirdWaveHeightRise AlertMetric = "h1_3_rise"
+	AlertMetricMaxHeightRise                 AlertMetric = "hmax_rise"
+)
+
+func (m AlertMetric) valid() bool {
+	switch m {
+	case AlertMetricAverageTopThirdWaveHeight, AlertMetricMaxHeight, AlertMetricAverageTopThirdWavePeriod,
+		AlertMetricTemperature, AlertMetricAverageTopThirdWaveHeightRise, AlertMetricMaxHeightRise:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m AlertMetric) rise() bool {
+	return m == AlertMetricAverageTopThirdWaveHeightRise || m == AlertMetricMaxHeightRise
+}
+
+func (m AlertMetric) value(waveData model.WaveData) float64 {
+	switch m {
+	case AlertMetricAverageTopThirdWaveHeight, AlertMetricAverageTopThirdWaveHeightRise:
+		return waveData.AverageTopThirdWaveHeight()
+	case AlertMetricMaxHeight, AlertMetricMaxHeightRise:
+		return waveData.MaxHeight()
+	case AlertMetricAverageTopThirdWavePeriod:
+		return waveData.AverageTopThirdWavePeriod()
+	default:
+		return waveData.Temperature()
+	}
+}
+
+type AlertComparator string
+
+const (
+	AlertComparatorGreaterThan        AlertComparator = "gt"
+	AlertComparatorGreaterThanOrEqual AlertComparator = "gte"
+	AlertComparatorLessThan           AlertComparator = "lt"
+	AlertComparatorLessThanOrEqual    AlertComparator = "lte"
+)
+
+func (c AlertComparator) valid() bool {
+	switch c {
+	case AlertComparatorGreaterThan, AlertComparatorGreaterThanOrEqual, AlertComparatorLessThan,
+		AlertComparatorLessThanOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+type AlertRule struct {
+	// Database identifier, 0 until the rule is stored.
+	id   int64
+	name string
+	// Index name of the observed campaign, e.g. les-pierres-noires.
+	campaign   string
+	metric     AlertMetric
+	comparator AlertComparator
+	threshold  float64
+	// Observations older than window are not evaluated, 0 evaluates the most recent observation whatever its age.
+	window time.Duration
+	// Once fired, the rule cannot fire again before cooldown elapsed, even if it resolved in between.
+	cooldown   time.Duration
+	webhookURL string
+	enabled    bool
+}
+
+func NewAlertRule(
+	id int64,
+	name, campaign string,
+	metric AlertMetric,
+	comparator AlertComparator,
+	threshold float64,
+	window, cooldown time.Duration,
+	webhookURL string,
+	enabled bool,
+) (AlertRule, error) {
+	if name == "" {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: name cannot be empty")
+	}
+	if campaign == "" {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: campaign cannot be empty")
+	}
+	if !metric.valid() {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: unknown metric")
+	}
+	if !comparator.valid() {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: unknown comparator")
+	}
+	if window < 0 || cooldown < 0 {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: window and cooldown cannot be negative")
+	}
+	if metric.rise() && window == 0 {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: rise metrics need a window")
+	}
+	webhook, err := url.Parse(webhookURL)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Host == "" {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: webhook URL must be an absolute http(s) URL")
+	}
+	if loopbackOrLinkLocal(webhook.Hostname()) {
+		return AlertRule{}, newInvalidInputError("invalid alert rule: webhook URL cannot target a loopback or link-local address")
+	}
+
+	return AlertRule{
+		id:         id,
+		name:       name,
+		campaign:   campaign,
+		metric:     metric,
+		comparator: comparator,
+		threshold:  threshold,
+		window:     window,
+		cooldown:   cooldown,
+		webhookURL: webhookURL,
+		enabled:    enabled,
+	}, nil
+}
+
+// loopbackOrLinkLocal tells whether host names the local machine or is a link-local address, such as the instance
+// metadata endpoint of the cloud providers.
+func loopbackOrLinkLocal(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
+
+// AlertWebhookHosts is the allow-list of the hosts the alert webhooks may be posted to.
+type AlertWebhookHosts []string
+
+// Check returns an ErrInvalidInput error when webhookURL is not on a host of the allow-list. Hosts are compared
+// case-insensitively and without their port.
+func (h AlertWebhookHosts) Check(webhookURL string) error {
+	webhook, err := url.Parse(webhookURL)
+	if err != nil {
+		return newInvalidInputError("invalid alert rule: webhook URL must be an absolute http(s) URL")
+	}
+	if !slices.ContainsFunc(h, func(host string) bool { return strings.EqualFold(host, webhook.Hostname()) }) {
+		return newInvalidInputError(fmt.Sprintf("invalid alert rule: webhook host is not allowed: %s", webhook.Hostname()))
+	}
+	return nil
+}
+
+// Value computes the metric of the observations of the rule window, most recent first. ok is false when there are
+// not enough observations, a rise needs two of them.
+func (r AlertRule) Value(observations []model.WaveData) (value float64, ok bool) {
+	if len(observations) == 0 || (r.metric.rise() && len(observations) < 2) {
+		return 0, false
+	}
+
+	latest := r.metric.value(observations[0])
+	if !r.metric.rise() {
+		return latest, true
+	}
+	return latest - r.metric.value(observations[len(observations)-1]), true
+}
+
+// Breached tells whether the value satisfies the rule condition.
+func (r AlertRule) Breached(value float64) bool {
+	switch r.comparator {
+	case AlertComparatorGreaterThan:
+		return value > r.threshold
+	case AlertComparatorGreaterThanOrEqual:
+		return value >= r.threshold
+	case AlertComparatorLessThan:
+		return value < r.threshold
+	default:
+		return value <= r.threshold
+	}
+}
+
+func (r AlertRule) ID() int64 {
+	return r.id
+}
+
+func (r AlertRule) Name() string {
+	return r.name
+}
+
+func (r AlertRule) Campaign() string {
+	return r.campaign
+}
+
+func (r AlertRule) Metric() AlertMetric {
+	return r.metric
+}
+
+func (r AlertRule) Comparator() AlertComparator {
+	return r.comparator
+}
+
+func (r AlertRule) Threshold() float64 {
+	return r.threshold
+}
+
+func (r AlertRule) Window() time.Duration {
+	return r.window
+}
+
+func (r AlertRule) Cooldown() time.Duration {
+	return r.cooldown
+}
+
+func (r AlertRule) WebhookURL() string {
+	return r.webhookURL
+}
+
+func (r AlertRule) Enabled() bool {
+	return r.enabled
+}
@@ -0,0 +1,205 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	domainmodel "github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+const webhookURL = "https://hooks.example.com/candhis"
+
+func TestNewAlertRuleSuccess(t *testing.T) {
+	rule, err := model.NewAlertRule(7, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 4, 2*time.Hour, 6*time.Hour, webhookURL, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), rule.ID())
+	assert.Equal(t, "Big swell", rule.Name())
+	assert.Equal(t, "les-pierres-noires", rule.Campaign())
+	assert.Equal(t, model.AlertMetricMaxHeight, rule.Metric())
+	assert.Equal(t, model.AlertComparatorGreaterThan, rule.Comparator())
+	assert.Equal(t, 4.0, rule.Threshold())
+	assert.Equal(t, 2*time.Hour, rule.Window())
+	assert.Equal(t, 6*time.Hour, rule.Cooldown())
+	assert.Equal(t, webhookURL, rule.WebhookURL())
+	assert.True(t, rule.Enabled())
+}
+
+func TestNewAlertRuleFailure(t *testing.T) {
+	testCases := map[string]struct {
+		name       string
+		campaign   string
+		metric     model.AlertMetric
+		comparator model.AlertComparator
+		window     time.Duration
+		cooldown   time.Duration
+		webhookURL string
+		errMsg     string
+	}{
+		"empty name": {
+			campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight, comparator: model.AlertComparatorGreaterThan,
+			webhookURL: webhookURL,
+			errMsg:     "invalid alert rule: name cannot be empty",
+		},
+		"empty campaign": {
+			name: "Big swell", metric: model.AlertMetricMaxHeight, comparator: model.AlertComparatorGreaterThan,
+			webhookURL: webhookURL,
+			errMsg:     "invalid alert rule: campaign cannot be empty",
+		},
+		"unknown metric": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: "wind", comparator: model.AlertComparatorGreaterThan,
+			webhookURL: webhookURL,
+			errMsg:     "invalid alert rule: unknown metric",
+		},
+		"unknown comparator": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight, comparator: "eq",
+			webhookURL: webhookURL,
+			errMsg:     "invalid alert rule: unknown comparator",
+		},
+		"negative cooldown": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, cooldown: -time.Hour, webhookURL: webhookURL,
+			errMsg: "invalid alert rule: window and cooldown cannot be negative",
+		},
+		"rise without window": {
+			name: "Rising swell", campaign: "les-pierres-noires", metric: model.AlertMetricAverageTopThirdWaveHeightRise,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: webhookURL,
+			errMsg: "invalid alert rule: rise metrics need a window",
+		},
+		"relative webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "/candhis",
+			errMsg: "invalid alert rule: webhook URL must be an absolute http(s) URL",
+		},
+		"localhost webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "http://LocalHost:8080/candhis",
+			errMsg: "invalid alert rule: webhook URL cannot target a loopback or link-local address",
+		},
+		"loopback webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "http://127.0.0.2/candhis",
+			errMsg: "invalid alert rule: webhook URL cannot target a loopback or link-local address",
+		},
+		"IPv6 loopback webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "http://[::1]:8080/candhis",
+			errMsg: "invalid alert rule: webhook URL cannot target a loopback or link-local address",
+		},
+		"link-local webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "http://169.254.169.254/latest/meta-data",
+			errMsg: "invalid alert rule: webhook URL cannot target a loopback or link-local address",
+		},
+		"IPv4-mapped link-local webhook URL": {
+			name: "Big swell", campaign: "les-pierres-noires", metric: model.AlertMetricMaxHeight,
+			comparator: model.AlertComparatorGreaterThan, webhookURL: "http://[::ffff:169.254.169.254]/latest",
+			errMsg: "invalid alert rule: webhook URL cannot target a loopback or link-local address",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := model.NewAlertRule(0, tc.name, tc.campaign, tc.metric, tc.comparator, 4, tc.window, tc.cooldown,
+				tc.webhookURL, true)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.AlertRule{}, rule)
+		})
+	}
+}
+
+func TestAlertRuleValue(t *testing.T) {
+	observations := []domainmodel.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "10:00", "3.1", "4.6", "9.2", "280", "25", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:30", "2.4", "3.9", "8.8", "280", "25", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "1.9", "3.2", "8.5", "280", "25", "15"),
+	}
+
+	testCases := map[string]struct {
+		metric        model.AlertMetric
+		observations  []domainmodel.WaveData
+		expectedValue float64
+		expectedOK    bool
+	}{
+		"latest value":               {metric: model.AlertMetricMaxHeight, observations: observations, expectedValue: 4.6, expectedOK: true},
+		"rise over window":           {metric: model.AlertMetricAverageTopThirdWaveHeightRise, observations: observations, expectedValue: 1.2, expectedOK: true},
+		"no observation":             {metric: model.AlertMetricMaxHeight},
+		"rise of single observation": {metric: model.AlertMetricMaxHeightRise, observations: observations[:1]},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := model.NewAlertRule(1, "rule", "les-pierres-noires", tc.metric, model.AlertComparatorGreaterThan, 1,
+				time.Hour, 0, webhookURL, true)
+			require.NoError(t, err)
+
+			value, ok := rule.Value(tc.observations)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.InDelta(t, tc.expectedValue, value, 1e-9)
+		})
+	}
+}
+
+func TestAlertRuleBreached(t *testing.T) {
+	testCases := map[model.AlertComparator]map[float64]bool{
+		model.AlertComparatorGreaterThan:        {3.9: false, 4: false, 4.1: true},
+		model.AlertComparatorGreaterThanOrEqual: {3.9: false, 4: true, 4.1: true},
+		model.AlertComparatorLessThan:           {3.9: true, 4: false, 4.1: false},
+		model.AlertComparatorLessThanOrEqual:    {3.9: true, 4: true, 4.1: false},
+	}
+
+	for comparator, expectations := range testCases {
+		t.Run(string(comparator), func(t *testing.T) {
+			rule, err := model.NewAlertRule(1, "rule", "les-pierres-noires", model.AlertMetricMaxHeight, comparator, 4, 0, 0,
+				webhookURL, true)
+			require.NoError(t, err)
+
+			for value, expected := range expectations {
+				assert.Equal(t, expected, rule.Breached(value), "value %v", value)
+			}
+		})
+	}
+}
+
+func TestAlertWebhookHostsCheck(t *testing.T) {
+	hosts := model.AlertWebhookHosts{"hooks.example.com", "10.0.0.12"}
+
+	testCases := map[string]struct {
+		webhookURL string
+		errMsg     string
+	}{
+		"allowed host":           {webhookURL: webhookURL},
+		"allowed host with port": {webhookURL: "https://HOOKS.example.com:8443/candhis"},
+		"allowed IP":             {webhookURL: "http://10.0.0.12/candhis"},
+		"subdomain of an allowed host": {
+			webhookURL: "https://evil.hooks.example.com/candhis",
+			errMsg:     "invalid alert rule: webhook host is not allowed: evil.hooks.example.com",
+		},
+		"other host": {
+			webhookURL: "https://example.org/candhis",
+			errMsg:     "invalid alert rule: webhook host is not allowed: example.org",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := hosts.Check(tc.webhookURL)
+			if tc.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.errMsg)
+			assert.ErrorIs(t, err, model.ErrInvalidInput)
+		})
+	}
+}
+
+func TestAlertWebhookHostsCheck_EmptyAllowList(t *testing.T) {
+	assert.EqualError(t, model.AlertWebhookHosts(nil).Check(webhookURL),
+		"invalid alert rule: webhook host is not allowed: hooks.example.com")
+}
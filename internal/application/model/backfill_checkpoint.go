@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// BackfillCheckpoint is the progress of the backfill of a campaign over the [from, to) period, the observations
+// before doneUntil are already ingested.
+type BackfillCheckpoint struct {
+	buoyID    string
+	from      time.Time
+	to        time.Time
+	doneUntil time.Time
+}
+
+func NewBackfillCheckpoint(buoyID string, from, to, doneUntil time.Time) (BackfillCheckpoint, error) {
+	if buoyID == "" {
+		return BackfillCheckpoint{}, newInvalidInputError("invalid backfill checkpoint: buoy ID cannot be empty")
+	}
+	if from.Location() != time.UTC || to.Location() != time.UTC || doneUntil.Location() != time.UTC {
+		return BackfillCheckpoint{}, newInvalidInputError("invalid backfill checkpoint: times must be in UTC format")
+	}
+	if !from.Before(to) {
+		return BackfillCheckpoint{}, newInvalidInputError("invalid backfill checkpoint: from must be before to")
+	}
+	if doneUntil.Before(from) || doneUntil.After(to) {
+		return BackfillCheckpoint{}, newInvalidInputError("invalid backfill checkpoint: done until must be between from and to")
+	}
+
+	return BackfillCheckpoint{buoyID: buoyID, from: from, to: to, doneUntil: doneUntil}, nil
+}
+
+func (c BackfillCheckpoint) BuoyID() string {
+	return c.buoyID
+}
+
+func (c BackfillCheckpoint) From() time.Time {
+	return c.from
+}
+
+func (c BackfillCheckpoint) To() time.Time {
+	return c.to
+}
+
+func (c BackfillCheckpoint) DoneUntil() time.Time {
+	return c.doneUntil
+}
+
+func (c BackfillCheckpoint) Done() bool {
+	return !c.doneUntil.Before(c.to)
+}
+
+// NextChunk returns the period following the checkpoint, at most size long.
+func (c BackfillCheckpoint) NextChunk(size time.Duration) (time.Time, time.Time) {
+	end := c.doneUntil.Add(size)
+	if end.After(c.to) {
+		end = c.to
+	}
+	return c.doneUntil, end
+}
+
+// Advance marks the observations before doneUntil as ingested.
+func (c BackfillCheckpoint) Advance(doneUntil time.Time) (BackfillCheckpoint, error) {
+	return NewBackfillCheckpoint(c.buoyID, c.from, c.to, doneUntil)
+}
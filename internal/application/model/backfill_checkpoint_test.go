@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewBackfillCheckpointFailure(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		buoyID    string
+		from      time.Time
+		to        time.Time
+		doneUntil time.Time
+		errMsg    string
+	}{
+		"empty buoy ID": {
+			from: from, to: to, doneUntil: from,
+			errMsg: "invalid backfill checkpoint: buoy ID cannot be empty",
+		},
+		"not UTC": {
+			buoyID: "02911", from: from.In(time.FixedZone("CET", 3600)), to: to, doneUntil: from,
+			errMsg: "invalid backfill checkpoint: times must be in UTC format",
+		},
+		"empty period": {
+			buoyID: "02911", from: to, to: from, doneUntil: from,
+			errMsg: "invalid backfill checkpoint: from must be before to",
+		},
+		"done until after to": {
+			buoyID: "02911", from: from, to: to, doneUntil: to.Add(time.Hour),
+			errMsg: "invalid backfill checkpoint: done until must be between from and to",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			checkpoint, err := model.NewBackfillCheckpoint(tc.buoyID, tc.from, tc.to, tc.doneUntil)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.BackfillCheckpoint{}, checkpoint)
+		})
+	}
+}
+
+func TestBackfillCheckpointChunks(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	checkpoint, err := model.NewBackfillCheckpoint("02911", from, to, from)
+	require.NoError(t, err)
+	assert.False(t, checkpoint.Done())
+
+	chunkFrom, chunkTo := checkpoint.NextChunk(week)
+	assert.Equal(t, from, chunkFrom)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), chunkTo)
+
+	checkpoint, err = checkpoint.Advance(chunkTo)
+	require.NoError(t, err)
+	chunkFrom, chunkTo = checkpoint.NextChunk(week)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), chunkFrom)
+	assert.Equal(t, to, chunkTo)
+
+	checkpoint, err = checkpoint.Advance(chunkTo)
+	require.NoError(t, err)
+	assert.True(t, checkpoint.Done())
+	assert.Equal(t, "02911", checkpoint.BuoyID())
+	assert.Equal(t, from, checkpoint.From())
+	assert.Equal(t, to, checkpoint.To())
+	assert.Equal(t, to, checkpoint.DoneUntil())
+}
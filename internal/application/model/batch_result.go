@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// BatchResult summarizes how the storage handled a batch of documents, observations or spectra.
+type BatchResult struct {
+	// Documents created or modified by the batch.
+	Indexed int
+	// Documents already stored with the same values.
+	Unchanged int
+	// Documents rejected by the storage.
+	Failures []IndexFailure
+}
+
+// IndexFailure describes a document of a batch that the storage rejected.
+type IndexFailure struct {
+	Timestamp time.Time
+	Reason    string
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+type Campaign struct {
+	// Candhis identifier of the buoy, e.g. 02911 for Les Pierres Noires.
+	buoyID string
+	// Human readable name of the campaign.
+	name string
+	// Candhis web page publishing the campaign table.
+	candhisURL string
+	// Elasticsearch index where the campaign observations are stored.
+	indexName string
+	// Buoy position in decimal degrees.
+	latitude  float64
+	longitude float64
+	// Whether the campaign is scraped, disabled campaigns stay in the registry.
+	enabled bool
+}
+
+func NewCampaign(buoyID, name, candhisURL, indexName string, latitude, longitude float64, enabled bool) (Campaign, error) {
+	if buoyID == "" {
+		return Campaign{}, newInvalidInputError("invalid campaign: buoy ID cannot be empty")
+	}
+	if name == "" {
+		return Campaign{}, newInvalidInputError("invalid campaign: name cannot be empty")
+	}
+
+	u, err := url.Parse(candhisURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Campaign{}, newInvalidInputError("invalid campaign: candhis URL must be an absolute http(s) URL")
+	}
+
+	if indexName == "" || indexName != strings.ToLower(indexName) {
+		return Campaign{}, newInvalidInputError("invalid campaign: index name must be a non empty lowercase string")
+	}
+
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return Campaign{}, newInvalidInputError("invalid campaign: coordinates out of range")
+	}
+
+	return Campaign{
+		buoyID:     buoyID,
+		name:       name,
+		candhisURL: candhisURL,
+		indexName:  indexName,
+		latitude:   latitude,
+		longitude:  longitude,
+		enabled:    enabled,
+	}, nil
+}
+
+func (c Campaign) BuoyID() string {
+	return c.buoyID
+}
+
+func (c Campaign) Name() string {
+	return c.name
+}
+
+func (c Campaign) CandhisURL() string {
+	return c.candhisURL
+}
+
+func (c Campaign) IndexName() string {
+	return c.indexName
+}
+
+func (c Campaign) Latitude() float64 {
+	return c.latitude
+}
+
+func (c Campaign) Longitude() float64 {
+	return c.longitude
+}
+
+func (c Campaign) Enabled() bool {
+	return c.enabled
+}
+
+// CampaignIndexNames returns the Elasticsearch indices of the campaigns, disabled ones included since their
+// observations are still served.
+func CampaignIndexNames(campaigns []Campaign) []string {
+	indexNames := make([]string, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		indexNames = append(indexNames, campaign.IndexName())
+	}
+	return indexNames
+}
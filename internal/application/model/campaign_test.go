@@ -0,0 +1,99 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+const lesPierresNoiresURL = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ=="
+
+func TestNewCampaignSuccess(t *testing.T) {
+	campaign, err := model.NewCampaign(
+		"02911", "Les Pierres Noires", lesPierresNoiresURL, "les-pierres-noires", 48.291, -4.968, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "02911", campaign.BuoyID())
+	assert.Equal(t, "Les Pierres Noires", campaign.Name())
+	assert.Equal(t, lesPierresNoiresURL, campaign.CandhisURL())
+	assert.Equal(t, "les-pierres-noires", campaign.IndexName())
+	assert.Equal(t, 48.291, campaign.Latitude())
+	assert.Equal(t, -4.968, campaign.Longitude())
+	assert.True(t, campaign.Enabled())
+}
+
+func TestNewCampaignFailure(t *testing.T) {
+	testCases := map[string]struct {
+		buoyID    string
+		name      string
+		url       string
+		index     string
+		latitude  float64
+		longitude float64
+		errMsg    string
+	}{
+		"empty buoy ID": {
+			name:   "Les Pierres Noires",
+			url:    lesPierresNoiresURL,
+			index:  "les-pierres-noires",
+			errMsg: "invalid campaign: buoy ID cannot be empty",
+		},
+		"empty name": {
+			buoyID: "02911",
+			url:    lesPierresNoiresURL,
+			index:  "les-pierres-noires",
+			errMsg: "invalid campaign: name cannot be empty",
+		},
+		"relative URL": {
+			buoyID: "02911",
+			name:   "Les Pierres Noires",
+			url:    "/_public_/campagne.php?Y2FtcD0wMjkxMQ==",
+			index:  "les-pierres-noires",
+			errMsg: "invalid campaign: candhis URL must be an absolute http(s) URL",
+		},
+		"uppercase index": {
+			buoyID: "02911",
+			name:   "Les Pierres Noires",
+			url:    lesPierresNoiresURL,
+			index:  "Les-Pierres-Noires",
+			errMsg: "invalid campaign: index name must be a non empty lowercase string",
+		},
+		"latitude out of range": {
+			buoyID:   "02911",
+			name:     "Les Pierres Noires",
+			url:      lesPierresNoiresURL,
+			index:    "les-pierres-noires",
+			latitude: 91,
+			errMsg:   "invalid campaign: coordinates out of range",
+		},
+		"longitude out of range": {
+			buoyID:    "02911",
+			name:      "Les Pierres Noires",
+			url:       lesPierresNoiresURL,
+			index:     "les-pierres-noires",
+			longitude: -181,
+			errMsg:    "invalid campaign: coordinates out of range",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			campaign, err := model.NewCampaign(tc.buoyID, tc.name, tc.url, tc.index, tc.latitude, tc.longitude, true)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.Campaign{}, campaign)
+		})
+	}
+}
+
+func TestCampaignIndexNames(t *testing.T) {
+	enabled, err := model.NewCampaign(
+		"02911", "Les Pierres Noires", lesPierresNoiresURL, "les-pierres-noires", 48.291, -4.968, true)
+	require.NoError(t, err)
+	disabled, err := model.NewCampaign(
+		"02904", "Belle-Ile", lesPierresNoiresURL, "belle-ile", 47.285, -3.285, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"les-pierres-noires", "belle-ile"}, model.CampaignIndexNames([]model.Campaign{enabled, disabled}))
+}
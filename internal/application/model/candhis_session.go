@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// CandhisSessionLifetime is how long a stored session ID is handed out. The sessionid job renews it twice as often,
+// so a valid session is always stored while the job runs.
+const CandhisSessionLifetime = 24 * time.Hour
+
+// ErrNoValidCandhisSession is returned when every stored session ID has expired or been invalidated.
+var ErrNoValidCandhisSession = errors.New("no valid candhis session")
+
+// CandhisSessionSource tells what obtained a stored session ID.
+type CandhisSessionSource string
+
+const (
+	// CandhisSessionSourceScraper is a session ID fetched by the sessionid scraper.
+	CandhisSessionSourceScraper CandhisSessionSource = "sessionid_scraper"
+	// CandhisSessionSourceBootstrap is a session ID fetched by a scraper that found no valid one.
+	CandhisSessionSourceBootstrap CandhisSessionSource = "bootstrap"
+	// CandhisSessionSourceRenewal is a session ID fetched by a scraper after Candhis rejected the previous one.
+	CandhisSessionSourceRenewal CandhisSessionSource = "renewal"
+)
+
+// CandhisSession is a session ID stored in the session pool.
+type CandhisSession struct {
+	sessionID  CandhisSessionID
+	source     CandhisSessionSource
+	validUntil time.Time
+}
+
+// NewCandhisSession builds the pool entry of a session ID, valid for CandhisSessionLifetime from its creation.
+func NewCandhisSession(sessionID CandhisSessionID, source CandhisSessionSource) (CandhisSession, error) {
+	if sessionID.ID() == "" {
+		return CandhisSession{}, newInvalidInputError("invalid candhis session: empty session ID")
+	}
+	if source != CandhisSessionSourceScraper && source != CandhisSessionSourceBootstrap &&
+		source != CandhisSessionSourceRenewal {
+		return CandhisSession{}, newInvalidInputError("invalid candhis session: unknown source")
+	}
+
+	return CandhisSession{
+		sessionID:  sessionID,
+		source:     source,
+		validUntil: sessionID.CreatedAt().Add(CandhisSessionLifetime),
+	}, nil
+}
+
+func (s CandhisSession) SessionID() CandhisSessionID {
+	return s.sessionID
+}
+
+func (s CandhisSession) Source() CandhisSessionSource {
+	return s.source
+}
+
+func (s CandhisSession) ValidUntil() time.Time {
+	return s.validUntil
+}
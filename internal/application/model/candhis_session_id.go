@@ -1,7 +1,6 @@
 package model
 
 import (
-	"errors"
 	"strings"
 	"time"
 )
@@ -13,17 +12,17 @@ type CandhisSessionID struct {
 
 func NewCandhisSessionID(id string, createdAt *time.Time) (CandhisSessionID, error) {
 	if strings.HasPrefix(id, "PHPSESSID=") {
-		return CandhisSessionID{}, errors.New("invalid session ID: contains PHPSESSID prefix")
+		return CandhisSessionID{}, newInvalidInputError("invalid session ID: contains PHPSESSID prefix")
 	}
 	if id == "" {
-		return CandhisSessionID{}, errors.New("invalid session ID: cannot be empty")
+		return CandhisSessionID{}, newInvalidInputError("invalid session ID: cannot be empty")
 	}
 
 	if createdAt == nil {
 		now := time.Now().UTC()
 		createdAt = &now
 	} else if createdAt.Location() != time.UTC {
-		return CandhisSessionID{}, errors.New("invalid createdAt: must be in UTC format")
+		return CandhisSessionID{}, newInvalidInputError("invalid createdAt: must be in UTC format")
 	}
 	*createdAt = createdAt.Truncate(time.Microsecond) // I must truncate to avoid conflicts with database precision
 
@@ -0,0 +1,48 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+)
+
+func TestNewCandhisSession(t *testing.T) {
+	sessionID := modeltest.MustCreateCandhisSessionID(t, "session-id")
+
+	session, err := model.NewCandhisSession(sessionID, model.CandhisSessionSourceBootstrap)
+	require.NoError(t, err)
+
+	assert.Equal(t, sessionID, session.SessionID())
+	assert.Equal(t, model.CandhisSessionSourceBootstrap, session.Source())
+	assert.Equal(t, sessionID.CreatedAt().Add(model.CandhisSessionLifetime), session.ValidUntil())
+}
+
+func TestNewCandhisSessionFailure(t *testing.T) {
+	testCases := map[string]struct {
+		sessionID model.CandhisSessionID
+		source    model.CandhisSessionSource
+		errMsg    string
+	}{
+		"empty session ID": {
+			sessionID: model.CandhisSessionID{},
+			source:    model.CandhisSessionSourceScraper,
+			errMsg:    "invalid candhis session: empty session ID",
+		},
+		"unknown source": {
+			sessionID: modeltest.MustCreateCandhisSessionID(t, "session-id"),
+			source:    "manual",
+			errMsg:    "invalid candhis session: unknown source",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			session, err := model.NewCandhisSession(tc.sessionID, tc.source)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.CandhisSession{}, session)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+var (
+	// ErrSessionExpired is returned when Candhis rejects the session ID sent with a request.
+	ErrSessionExpired = errors.New("candhis session expired")
+	// ErrUpstreamUnavailable is returned when Candhis cannot be reached, fails or refuses to serve a request.
+	ErrUpstreamUnavailable = errors.New("candhis unavailable")
+	// ErrLayoutChanged is returned when a Candhis page no longer has the structure the scrapers parse.
+	ErrLayoutChanged = errors.New("candhis page layout changed")
+	// ErrStorageUnavailable is returned when PostgreSQL or Elasticsearch cannot be reached or fail to serve a request.
+	ErrStorageUnavailable = errors.New("storage unavailable")
+	// ErrInvalidInput is wrapped by the errors of the values that the constructors of the models reject.
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// invalidInputError is a value rejected by a model constructor, its message does not repeat ErrInvalidInput.
+type invalidInputError string
+
+func newInvalidInputError(message string) error {
+	return invalidInputError(message)
+}
+
+func (e invalidInputError) Error() string {
+	return string(e)
+}
+
+func (e invalidInputError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// LayoutChangedError reports the header cells of a Candhis table that no longer match the columns the scrapers parse.
+type LayoutChangedError struct {
+	Table string
+	// Fingerprint of the header cells of the page, see WaveDataTable.
+	Fingerprint string
+	// Header cells of the columns that are parsed but not found, and of the columns found but not parsed.
+	Missing []string
+	Unknown []string
+}
+
+func (e *LayoutChangedError) Error() string {
+	var columns []string
+	if len(e.Missing) > 0 {
+		columns = append(columns, fmt.Sprintf("missing columns %q", e.Missing))
+	}
+	if len(e.Unknown) > 0 {
+		columns = append(columns, fmt.Sprintf("unknown columns %q", e.Unknown))
+	}
+	return fmt.Sprintf("%s: %s has %s, fingerprint %s",
+		ErrLayoutChanged, e.Table, strings.Join(columns, " and "), e.Fingerprint)
+}
+
+func (e *LayoutChangedError) Unwrap() error {
+	return ErrLayoutChanged
+}
+
+// MissingContentError reports a Candhis page served without the content requested. Candhis serves its cookie page
+// instead of the content to an expired session, so the session is deemed expired until a renewed one gets the same
+// page.
+type MissingContentError struct {
+	// Detail names the missing content, e.g. "no wave data table in page".
+	Detail string
+}
+
+func (e *MissingContentError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrSessionExpired, e.Detail)
+}
+
+func (e *MissingContentError) Unwrap() error {
+	return ErrSessionExpired
+}
+
+// Exit codes of the binaries, by the class of the error that stopped them.
+const (
+	ExitCodeFailure             = 1
+	ExitCodeConfiguration       = 2
+	ExitCodeSessionExpired      = 3
+	ExitCodeUpstreamUnavailable = 4
+	ExitCodeLayoutChanged       = 5
+	ExitCodeStorageUnavailable  = 6
+	ExitCodeInvalidObservation  = 7
+)
+
+// ExitCode returns the exit code of the first class of errors, in the order of the exit codes, that err wraps, and
+// ExitCodeFailure when it wraps none. A joined error may wrap several classes.
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrSessionExpired):
+		return ExitCodeSessionExpired
+	case errors.Is(err, ErrUpstreamUnavailable):
+		return ExitCodeUpstreamUnavailable
+	case errors.Is(err, ErrLayoutChanged):
+		return ExitCodeLayoutChanged
+	case errors.Is(err, ErrStorageUnavailable):
+		return ExitCodeStorageUnavailable
+	case errors.Is(err, model.ErrInvalidObservation):
+		return ExitCodeInvalidObservation
+	default:
+		return ExitCodeFailure
+	}
+}
@@ -0,0 +1,98 @@
+package model_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tul1/candhis_api/internal/application/model"
+	domainmodel "github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+func TestExitCode(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		exitCode int
+	}{
+		"unclassified": {
+			err:      errors.New("failed"),
+			exitCode: model.ExitCodeFailure,
+		},
+		"session expired": {
+			err:      fmt.Errorf("failed to gather: %w", model.ErrSessionExpired),
+			exitCode: model.ExitCodeSessionExpired,
+		},
+		"upstream unavailable": {
+			err:      fmt.Errorf("failed to gather: %w", model.ErrUpstreamUnavailable),
+			exitCode: model.ExitCodeUpstreamUnavailable,
+		},
+		"layout changed": {
+			err:      fmt.Errorf("failed to gather: %w", model.ErrLayoutChanged),
+			exitCode: model.ExitCodeLayoutChanged,
+		},
+		"storage unavailable": {
+			err:      fmt.Errorf("failed to store: %w", model.ErrStorageUnavailable),
+			exitCode: model.ExitCodeStorageUnavailable,
+		},
+		"invalid observation": {
+			err:      fmt.Errorf("failed to parse: %w", domainmodel.ErrInvalidObservation),
+			exitCode: model.ExitCodeInvalidObservation,
+		},
+		"layout changed columns": {
+			err:      fmt.Errorf("failed to gather: %w", &model.LayoutChangedError{Missing: []string{"Date"}}),
+			exitCode: model.ExitCodeLayoutChanged,
+		},
+		"index mapping drift": {
+			err:      fmt.Errorf("failed to bootstrap: %w", persistence.ErrIndexMappingDrift),
+			exitCode: model.ExitCodeStorageUnavailable,
+		},
+		"joined classes": {
+			err:      errors.Join(model.ErrStorageUnavailable, model.ErrUpstreamUnavailable),
+			exitCode: model.ExitCodeUpstreamUnavailable,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.exitCode, model.ExitCode(tc.err))
+		})
+	}
+}
+
+func TestLayoutChangedError(t *testing.T) {
+	testCases := map[string]struct {
+		err    *model.LayoutChangedError
+		errMsg string
+	}{
+		"missing columns": {
+			err: &model.LayoutChangedError{
+				Table: "wave data table", Fingerprint: "2c7bd5d6a5c1f0e4", Missing: []string{"Hmax (m)", "Th1/3 (s)"},
+			},
+			errMsg: `candhis page layout changed: wave data table has missing columns ["Hmax (m)" "Th1/3 (s)"], ` +
+				`fingerprint 2c7bd5d6a5c1f0e4`,
+		},
+		"missing and unknown columns": {
+			err: &model.LayoutChangedError{
+				Table: "wave data table", Fingerprint: "9a41c07e33b2d8f5", Missing: []string{"Hmax (m)"},
+				Unknown: []string{"Hmax (cm)"},
+			},
+			errMsg: `candhis page layout changed: wave data table has missing columns ["Hmax (m)"] and ` +
+				`unknown columns ["Hmax (cm)"], fingerprint 9a41c07e33b2d8f5`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.EqualError(t, tc.err, tc.errMsg)
+			assert.ErrorIs(t, tc.err, model.ErrLayoutChanged)
+		})
+	}
+}
+
+func TestInvalidInputError(t *testing.T) {
+	_, err := model.NewWaveDataQuery(nil, nil, "", 0, "sideways")
+	assert.ErrorIs(t, err, model.ErrInvalidInput)
+	assert.EqualError(t, err, "invalid sort: must be asc or desc")
+}
@@ -0,0 +1,156 @@
+package model
+
+import "time"
+
+const (
+	// ObservationInterval is the sampling interval of the Candhis buoys, observations are published at :00 and :30.
+	ObservationInterval = 30 * time.Minute
+
+	DefaultGapQueryPeriod = 7 * 24 * time.Hour
+	MaxGapQueryPeriod     = 366 * 24 * time.Hour
+)
+
+// GapQuery is the [from, to) time range of a gap analysis.
+type GapQuery struct {
+	from time.Time
+	to   time.Time
+}
+
+// NewGapQuery validates the time range of a gap analysis. A nil to falls back to now and a nil from to
+// DefaultGapQueryPeriod before to.
+func NewGapQuery(from, to *time.Time, now time.Time) (GapQuery, error) {
+	query := GapQuery{to: now.UTC()}
+	if to != nil {
+		query.to = to.UTC()
+	}
+	query.from = query.to.Add(-DefaultGapQueryPeriod)
+	if from != nil {
+		query.from = from.UTC()
+	}
+
+	if !query.from.Before(query.to) {
+		return GapQuery{}, newInvalidInputError("invalid time range: from must be before to")
+	}
+	if query.to.Sub(query.from) > MaxGapQueryPeriod {
+		return GapQuery{}, newInvalidInputError("invalid time range: must not exceed 366 days")
+	}
+
+	return query, nil
+}
+
+func (q GapQuery) From() time.Time {
+	return q.from
+}
+
+func (q GapQuery) To() time.Time {
+	return q.to
+}
+
+// ObservationGap is a [From, To) time range without any observation.
+type ObservationGap struct {
+	From time.Time
+	To   time.Time
+}
+
+// Missing is the number of observations expected in the gap.
+func (g ObservationGap) Missing() int {
+	return int(g.To.Sub(g.From) / ObservationInterval)
+}
+
+// DailyCoverage counts the observations of a UTC day within the analysed time range.
+type DailyCoverage struct {
+	Day      time.Time
+	Expected int
+	Observed int
+}
+
+func (d DailyCoverage) Coverage() float64 {
+	return coverage(d.Observed, d.Expected)
+}
+
+type GapReport struct {
+	From     time.Time
+	To       time.Time
+	Expected int
+	Observed int
+	Gaps     []ObservationGap
+	Days     []DailyCoverage
+}
+
+func (r GapReport) Coverage() float64 {
+	return coverage(r.Observed, r.Expected)
+}
+
+// DayRange is a [From, To) range of whole UTC days.
+type DayRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// MissingDayRanges returns the UTC days holding the gaps of the report, the days of consecutive gaps are merged into a
+// single range.
+func (r GapReport) MissingDayRanges() []DayRange {
+	var ranges []DayRange
+	for _, gap := range r.Gaps {
+		from := gap.From.Truncate(24 * time.Hour)
+		to := ceilTime(gap.To, 24*time.Hour)
+		if len(ranges) > 0 && !from.After(ranges[len(ranges)-1].To) {
+			ranges[len(ranges)-1].To = to
+			continue
+		}
+		ranges = append(ranges, DayRange{From: from, To: to})
+	}
+	return ranges
+}
+
+// NewGapReport compares the timestamps of the stored observations to the ObservationInterval sampling over the query
+// time range. Timestamps outside the range are ignored, and several observations within one interval count once.
+func NewGapReport(query GapQuery, timestamps []time.Time) GapReport {
+	observed := make(map[time.Time]bool, len(timestamps))
+	for _, timestamp := range timestamps {
+		observed[timestamp.UTC().Truncate(ObservationInterval)] = true
+	}
+
+	report := GapReport{From: query.from, To: query.to}
+	var gap *ObservationGap
+	for slot := ceilTime(query.from, ObservationInterval); slot.Before(query.to); slot = slot.Add(ObservationInterval) {
+		day := slot.Truncate(24 * time.Hour)
+		if len(report.Days) == 0 || !report.Days[len(report.Days)-1].Day.Equal(day) {
+			report.Days = append(report.Days, DailyCoverage{Day: day})
+		}
+		days := &report.Days[len(report.Days)-1]
+
+		report.Expected++
+		days.Expected++
+		if observed[slot] {
+			report.Observed++
+			days.Observed++
+			gap = nil
+			continue
+		}
+
+		if gap == nil {
+			report.Gaps = append(report.Gaps, ObservationGap{From: slot})
+			gap = &report.Gaps[len(report.Gaps)-1]
+		}
+		gap.To = slot.Add(ObservationInterval)
+	}
+
+	return report
+}
+
+// coverage is the percentage of the expected observations that were observed, 100 when none was expected.
+func coverage(observed, expected int) float64 {
+	if expected == 0 {
+		return 100
+	}
+	return float64(observed) / float64(expected) * 100
+}
+
+func ceilTime(t time.Time, d time.Duration) time.Time {
+	truncated := t.Truncate(d)
+	if truncated.Before(t) {
+		return truncated.Add(d)
+	}
+	return truncated
+}
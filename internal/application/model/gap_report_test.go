@@ -0,0 +1,113 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewGapQueryDefaults(t *testing.T) {
+	now := time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC)
+
+	query, err := model.NewGapQuery(nil, nil, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, now, query.To())
+	assert.Equal(t, now.Add(-model.DefaultGapQueryPeriod), query.From())
+}
+
+func TestNewGapQueryFailure(t *testing.T) {
+	now := time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		from   time.Time
+		to     time.Time
+		errMsg string
+	}{
+		"from equal to to": {
+			from:   now,
+			to:     now,
+			errMsg: "invalid time range: from must be before to",
+		},
+		"range too long": {
+			from:   now.AddDate(-2, 0, 0),
+			to:     now,
+			errMsg: "invalid time range: must not exceed 366 days",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := model.NewGapQuery(&tc.from, &tc.to, now)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestNewGapReport(t *testing.T) {
+	// The range starts between two observations, the first expected one is at 22:30.
+	from := time.Date(2024, 9, 16, 22, 10, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 2, 0, 0, 0, time.UTC)
+	query, err := model.NewGapQuery(&from, &to, to)
+	require.NoError(t, err)
+
+	timestamps := []time.Time{
+		time.Date(2024, 9, 16, 22, 0, 0, 0, time.UTC), // before the range
+		time.Date(2024, 9, 16, 22, 30, 0, 0, time.UTC),
+		time.Date(2024, 9, 16, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 16, 23, 1, 0, 0, time.UTC), // same interval as 23:00
+		time.Date(2024, 9, 17, 0, 30, 0, 0, time.UTC),
+		time.Date(2024, 9, 17, 1, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 17, 2, 0, 0, 0, time.UTC), // end of the range, excluded
+	}
+
+	report := model.NewGapReport(query, timestamps)
+
+	assert.Equal(t, model.GapReport{
+		From:     from,
+		To:       to,
+		Expected: 7,
+		Observed: 4,
+		Gaps: []model.ObservationGap{
+			{From: time.Date(2024, 9, 16, 23, 30, 0, 0, time.UTC), To: time.Date(2024, 9, 17, 0, 30, 0, 0, time.UTC)},
+			{From: time.Date(2024, 9, 17, 1, 30, 0, 0, time.UTC), To: time.Date(2024, 9, 17, 2, 0, 0, 0, time.UTC)},
+		},
+		Days: []model.DailyCoverage{
+			{Day: time.Date(2024, 9, 16, 0, 0, 0, 0, time.UTC), Expected: 3, Observed: 2},
+			{Day: time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC), Expected: 4, Observed: 2},
+		},
+	}, report)
+	assert.Equal(t, 2, report.Gaps[0].Missing())
+	assert.InDelta(t, 57.14, report.Coverage(), 0.01)
+	assert.InDelta(t, 66.67, report.Days[0].Coverage(), 0.01)
+	assert.InDelta(t, 50, report.Days[1].Coverage(), 0.01)
+}
+
+func TestNewGapReport_NoExpectedObservation(t *testing.T) {
+	from := time.Date(2024, 9, 17, 9, 5, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 9, 25, 0, 0, time.UTC)
+	query, err := model.NewGapQuery(&from, &to, to)
+	require.NoError(t, err)
+
+	report := model.NewGapReport(query, nil)
+
+	assert.Zero(t, report.Expected)
+	assert.Empty(t, report.Gaps)
+	assert.Equal(t, float64(100), report.Coverage())
+}
+
+func TestGapReport_MissingDayRanges(t *testing.T) {
+	report := model.GapReport{Gaps: []model.ObservationGap{
+		{From: time.Date(2024, 9, 10, 3, 0, 0, 0, time.UTC), To: time.Date(2024, 9, 10, 4, 0, 0, 0, time.UTC)},
+		{From: time.Date(2024, 9, 10, 22, 0, 0, 0, time.UTC), To: time.Date(2024, 9, 11, 2, 0, 0, 0, time.UTC)},
+		{From: time.Date(2024, 9, 12, 9, 0, 0, 0, time.UTC), To: time.Date(2024, 9, 12, 9, 30, 0, 0, time.UTC)},
+		{From: time.Date(2024, 9, 14, 23, 30, 0, 0, time.UTC), To: time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	assert.Equal(t, []model.DayRange{
+		{From: time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+		{From: time.Date(2024, 9, 14, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)},
+	}, report.MissingDayRanges())
+}
@@ -0,0 +1,52 @@
+package model
+
+// IngestionReport counts what happened to the rows of the campaign tables during a scraping run.
+type IngestionReport struct {
+	RowsSeen  int
+	Parsed    int
+	Rejected  []RejectedRow
+	Indexed   int
+	Unchanged int
+	Failed    int
+	// Layout of the last campaign table, see WaveDataTable.
+	LayoutFingerprint string
+	LayoutChanged     bool
+	// Periods of a backfill whose archive pages had no observation, they are checkpointed like the other ones.
+	EmptyPeriods []DayRange
+}
+
+func NewIngestionReport(table WaveDataTable, batch BatchResult) IngestionReport {
+	return IngestionReport{
+		RowsSeen:  table.RowsSeen,
+		Parsed:    len(table.WaveData),
+		Rejected:  table.Rejected,
+		Indexed:   batch.Indexed,
+		Unchanged: batch.Unchanged,
+		Failed:    len(batch.Failures),
+
+		LayoutFingerprint: table.LayoutFingerprint,
+		LayoutChanged:     table.LayoutChanged,
+	}
+}
+
+// Add sums two reports, it is used to build the report of a run from the reports of its campaigns or chunks.
+func (r IngestionReport) Add(other IngestionReport) IngestionReport {
+	layoutFingerprint := other.LayoutFingerprint
+	if layoutFingerprint == "" {
+		layoutFingerprint = r.LayoutFingerprint
+	}
+
+	return IngestionReport{
+		RowsSeen: r.RowsSeen + other.RowsSeen,
+		Parsed:   r.Parsed + other.Parsed,
+		// Capped so that appending never writes to the backing array of r.
+		Rejected:  append(r.Rejected[:len(r.Rejected):len(r.Rejected)], other.Rejected...),
+		Indexed:   r.Indexed + other.Indexed,
+		Unchanged: r.Unchanged + other.Unchanged,
+		Failed:    r.Failed + other.Failed,
+
+		LayoutFingerprint: layoutFingerprint,
+		LayoutChanged:     r.LayoutChanged || other.LayoutChanged,
+		EmptyPeriods:      append(r.EmptyPeriods[:len(r.EmptyPeriods):len(r.EmptyPeriods)], other.EmptyPeriods...),
+	}
+}
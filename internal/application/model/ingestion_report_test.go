@@ -0,0 +1,71 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tul1/candhis_api/internal/application/model"
+	domainmodel "github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+func TestNewIngestionReport(t *testing.T) {
+	table := model.WaveDataTable{
+		WaveData: []domainmodel.WaveData{
+			modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+			modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+			modeltest.MustCreateWaveData(t, "17/09/2024", "08:00", "0.5", "0.9", "4.8", "4", "47", "15"),
+		},
+		RowsSeen: 4,
+		Rejected: []model.RejectedRow{{Row: 2, Reason: "invalid value for maxHeight"}},
+
+		LayoutFingerprint: "2c7bd5d6a5c1f0e4",
+	}
+	batch := model.BatchResult{
+		Indexed:   1,
+		Unchanged: 1,
+		Failures:  []model.IndexFailure{{Timestamp: time.Now(), Reason: "mapper_parsing_exception"}},
+	}
+
+	report := model.NewIngestionReport(table, batch)
+
+	assert.Equal(t, model.IngestionReport{
+		RowsSeen:  4,
+		Parsed:    3,
+		Rejected:  []model.RejectedRow{{Row: 2, Reason: "invalid value for maxHeight"}},
+		Indexed:   1,
+		Unchanged: 1,
+		Failed:    1,
+
+		LayoutFingerprint: "2c7bd5d6a5c1f0e4",
+	}, report)
+}
+
+func TestIngestionReportAdd(t *testing.T) {
+	outage := model.DayRange{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)}
+	report1 := model.IngestionReport{
+		RowsSeen: 3, Parsed: 2, Rejected: []model.RejectedRow{{Row: 1, Reason: "a"}}, Indexed: 2,
+		LayoutFingerprint: "2c7bd5d6a5c1f0e4", LayoutChanged: true,
+	}
+	report2 := model.IngestionReport{
+		RowsSeen: 2, Parsed: 2, Rejected: []model.RejectedRow{{Row: 3, Reason: "b"}}, Unchanged: 1, Failed: 1,
+		LayoutFingerprint: "9a41c07e33b2d8f5",
+		EmptyPeriods:      []model.DayRange{outage},
+	}
+
+	assert.Equal(t, model.IngestionReport{
+		RowsSeen:  5,
+		Parsed:    4,
+		Rejected:  []model.RejectedRow{{Row: 1, Reason: "a"}, {Row: 3, Reason: "b"}},
+		Indexed:   2,
+		Unchanged: 1,
+		Failed:    1,
+
+		LayoutFingerprint: "9a41c07e33b2d8f5",
+		LayoutChanged:     true,
+		EmptyPeriods:      []model.DayRange{outage},
+	}, report1.Add(report2))
+	assert.Equal(t, "2c7bd5d6a5c1f0e4", report1.Add(model.IngestionReport{}).LayoutFingerprint)
+	assert.Equal(t, []model.RejectedRow{{Row: 1, Reason: "a"}}, report1.Rejected)
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// LatestWaveData is the most recent observation of a campaign, stale when its age exceeds StaleAfter.
+type LatestWaveData struct {
+	WaveData   model.WaveData
+	Age        time.Duration
+	Stale      bool
+	StaleAfter time.Duration
+}
@@ -0,0 +1,45 @@
+package modeltest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func MustCreateAlertRule(
+	t *testing.T,
+	id int64,
+	name, campaign string,
+	metric model.AlertMetric,
+	comparator model.AlertComparator,
+	threshold float64,
+	window, cooldown time.Duration,
+	webhookURL string,
+	enabled bool,
+) model.AlertRule {
+	t.Helper()
+
+	rule, err := model.NewAlertRule(id, name, campaign, metric, comparator, threshold, window, cooldown, webhookURL, enabled)
+	require.NoError(t, err, "failed to create AlertRule")
+
+	return rule
+}
+
+func MustCreateAlertEvent(
+	t *testing.T,
+	ruleID int64,
+	state model.AlertState,
+	value float64,
+	observedAt, firedAt, createdAt time.Time,
+	deliveryAttempts int,
+	deliveryError string,
+) model.AlertEvent {
+	t.Helper()
+
+	event, err := model.NewAlertEvent(ruleID, state, value, observedAt, firedAt, createdAt, deliveryAttempts, deliveryError)
+	require.NoError(t, err, "failed to create AlertEvent")
+
+	return event
+}
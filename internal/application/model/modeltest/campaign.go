@@ -0,0 +1,17 @@
+package modeltest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func MustCreateCampaign(t *testing.T, buoyID, name, candhisURL, indexName string, enabled bool) model.Campaign {
+	t.Helper()
+
+	campaign, err := model.NewCampaign(buoyID, name, candhisURL, indexName, 0, 0, enabled)
+	require.NoError(t, err, "failed to create Campaign")
+
+	return campaign
+}
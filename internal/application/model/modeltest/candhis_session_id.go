@@ -15,3 +15,16 @@ func MustCreateCandhisSessionID(t *testing.T, id string) model.CandhisSessionID
 
 	return sessionID
 }
+
+func MustCreateCandhisSession(
+	t *testing.T,
+	sessionID model.CandhisSessionID,
+	source model.CandhisSessionSource,
+) model.CandhisSession {
+	t.Helper()
+
+	session, err := model.NewCandhisSession(sessionID, source)
+	require.NoError(t, err, "failed to create CandhisSession")
+
+	return session
+}
@@ -0,0 +1,24 @@
+package modeltest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func MustCreateStation(
+	t *testing.T,
+	candhisID, name string,
+	latitude, longitude float64,
+	depth *float64,
+	operator string,
+	active bool,
+) model.Station {
+	t.Helper()
+
+	station, err := model.NewStation(candhisID, name, latitude, longitude, depth, operator, active)
+	require.NoError(t, err, "failed to create Station")
+
+	return station
+}
@@ -0,0 +1,120 @@
+package model
+
+import "time"
+
+type Scraper string
+
+const (
+	ScraperSessionID Scraper = "sessionid"
+	ScraperCampaigns Scraper = "campaigns"
+	ScraperBackfill  Scraper = "backfill"
+	ScraperCatalogue Scraper = "catalogue"
+	ScraperSpectra   Scraper = "spectra"
+)
+
+type ScrapeOutcome string
+
+const (
+	ScrapeOutcomeSuccess ScrapeOutcome = "success"
+	ScrapeOutcomeFailure ScrapeOutcome = "failure"
+)
+
+// ScrapeRunCounts are the ingestion counts kept in the scrape run history, with the layout of the table they were
+// counted in.
+type ScrapeRunCounts struct {
+	RowsSeen  int
+	Parsed    int
+	Rejected  int
+	Indexed   int
+	Unchanged int
+	Failed    int
+	// Fingerprint of the table layout, see WaveDataTable.
+	LayoutFingerprint string
+}
+
+func NewScrapeRunCounts(report IngestionReport) ScrapeRunCounts {
+	return ScrapeRunCounts{
+		RowsSeen:  report.RowsSeen,
+		Parsed:    report.Parsed,
+		Rejected:  len(report.Rejected),
+		Indexed:   report.Indexed,
+		Unchanged: report.Unchanged,
+		Failed:    report.Failed,
+
+		LayoutFingerprint: report.LayoutFingerprint,
+	}
+}
+
+type ScrapeRun struct {
+	scraper Scraper
+	// Buoy ID of the scraped campaign, empty when the run is not about a single campaign.
+	campaign   string
+	startedAt  time.Time
+	finishedAt time.Time
+	outcome    ScrapeOutcome
+	errorText  string
+	counts     ScrapeRunCounts
+}
+
+// NewScrapeRun builds the history entry of a scraper run, the run is a failure when errorText is not empty.
+func NewScrapeRun(
+	scraper Scraper,
+	campaign string,
+	startedAt, finishedAt time.Time,
+	errorText string,
+	counts ScrapeRunCounts,
+) (ScrapeRun, error) {
+	if scraper != ScraperSessionID && scraper != ScraperCampaigns && scraper != ScraperBackfill &&
+		scraper != ScraperCatalogue && scraper != ScraperSpectra {
+		return ScrapeRun{}, newInvalidInputError("invalid scrape run: unknown scraper")
+	}
+	if startedAt.Location() != time.UTC || finishedAt.Location() != time.UTC {
+		return ScrapeRun{}, newInvalidInputError("invalid scrape run: times must be in UTC format")
+	}
+	if finishedAt.Before(startedAt) {
+		return ScrapeRun{}, newInvalidInputError("invalid scrape run: finished before it started")
+	}
+
+	outcome := ScrapeOutcomeSuccess
+	if errorText != "" {
+		outcome = ScrapeOutcomeFailure
+	}
+
+	return ScrapeRun{
+		scraper:    scraper,
+		campaign:   campaign,
+		startedAt:  startedAt.Truncate(time.Microsecond), // database precision
+		finishedAt: finishedAt.Truncate(time.Microsecond),
+		outcome:    outcome,
+		errorText:  errorText,
+		counts:     counts,
+	}, nil
+}
+
+func (r ScrapeRun) Scraper() Scraper {
+	return r.scraper
+}
+
+func (r ScrapeRun) Campaign() string {
+	return r.campaign
+}
+
+func (r ScrapeRun) StartedAt() time.Time {
+	return r.startedAt
+}
+
+func (r ScrapeRun) FinishedAt() time.Time {
+	return r.finishedAt
+}
+
+func (r ScrapeRun) Outcome() ScrapeOutcome {
+	return r.outcome
+}
+
+func (r ScrapeRun) ErrorText() string {
+	return r.errorText
+}
+
+func (r ScrapeRun) Counts() ScrapeRunCounts {
+	return r.counts
+}
@@ -0,0 +1,84 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewScrapeRunSuccess(t *testing.T) {
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(3 * time.Second)
+	counts := model.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1}
+
+	run, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt, finishedAt, "", counts)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.ScraperCampaigns, run.Scraper())
+	assert.Equal(t, "02911", run.Campaign())
+	assert.Equal(t, startedAt, run.StartedAt())
+	assert.Equal(t, finishedAt, run.FinishedAt())
+	assert.Equal(t, model.ScrapeOutcomeSuccess, run.Outcome())
+	assert.Empty(t, run.ErrorText())
+	assert.Equal(t, counts, run.Counts())
+
+	failedRun, err := model.NewScrapeRun(model.ScraperSessionID, "", startedAt, finishedAt, "chrome down", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	assert.Equal(t, model.ScrapeOutcomeFailure, failedRun.Outcome())
+	assert.Equal(t, "chrome down", failedRun.ErrorText())
+}
+
+func TestNewScrapeRunFailure(t *testing.T) {
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		scraper    model.Scraper
+		startedAt  time.Time
+		finishedAt time.Time
+		errMsg     string
+	}{
+		"unknown scraper": {
+			scraper:    "unknown",
+			startedAt:  startedAt,
+			finishedAt: startedAt,
+			errMsg:     "invalid scrape run: unknown scraper",
+		},
+		"non-UTC times": {
+			scraper:    model.ScraperCampaigns,
+			startedAt:  startedAt.In(time.FixedZone("Non-UTC", 3600)),
+			finishedAt: startedAt,
+			errMsg:     "invalid scrape run: times must be in UTC format",
+		},
+		"finished before started": {
+			scraper:    model.ScraperCampaigns,
+			startedAt:  startedAt,
+			finishedAt: startedAt.Add(-time.Second),
+			errMsg:     "invalid scrape run: finished before it started",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			run, err := model.NewScrapeRun(tc.scraper, "", tc.startedAt, tc.finishedAt, "", model.ScrapeRunCounts{})
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.ScrapeRun{}, run)
+		})
+	}
+}
+
+func TestNewScrapeRunCounts(t *testing.T) {
+	report := model.IngestionReport{
+		RowsSeen:  4,
+		Parsed:    3,
+		Rejected:  []model.RejectedRow{{Row: 2, Reason: "invalid value for maxHeight"}},
+		Indexed:   1,
+		Unchanged: 1,
+		Failed:    1,
+	}
+
+	assert.Equal(t, model.ScrapeRunCounts{RowsSeen: 4, Parsed: 3, Rejected: 1, Indexed: 1, Unchanged: 1, Failed: 1},
+		model.NewScrapeRunCounts(report))
+}
@@ -0,0 +1,17 @@
+package model
+
+import "github.com/tul1/candhis_api/internal/domain/model"
+
+// SpectrumFile is the content of a Candhis spectral file once its rows have been parsed.
+type SpectrumFile struct {
+	Spectra []model.Spectrum
+	// Number of data rows found in the file, one per frequency bin, parsed or not.
+	RowsSeen int
+	Rejected []RejectedRow
+}
+
+// SpectraIndexName is the Elasticsearch index of the spectra of the campaign whose observations are stored in
+// indexName.
+func SpectraIndexName(indexName string) string {
+	return indexName + "-spectra"
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+)
+
+var accentsReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "œ", "oe",
+)
+
+// Station is a Candhis buoy as published in the catalogue of campaigns.
+type Station struct {
+	// Candhis identifier of the campaign page, the base64 encoded query of its URL, e.g. Y2FtcD0wMjkxMQ==.
+	candhisID string
+	// Candhis identifier of the buoy decoded from candhisID, e.g. 02911.
+	buoyID string
+	name   string
+	// Elasticsearch index of the campaign observations, derived from the name like the index of the configured
+	// campaigns, e.g. les-pierres-noires. It identifies the campaign in the API.
+	indexName string
+	// Buoy position in decimal degrees.
+	latitude  float64
+	longitude float64
+	// Water depth at the buoy in meters, nil when Candhis does not publish it.
+	depth    *float64
+	operator string
+	// Whether the buoy is still transmitting, inactive stations only have archived campaigns.
+	active bool
+}
+
+func NewStation(
+	candhisID, name string,
+	latitude, longitude float64,
+	depth *float64,
+	operator string,
+	active bool,
+) (Station, error) {
+	buoyID, err := decodeCandhisID(candhisID)
+	if err != nil {
+		return Station{}, err
+	}
+	if name == "" {
+		return Station{}, newInvalidInputError("invalid station: name cannot be empty")
+	}
+	indexName := stationIndexName(name)
+	if indexName == "" {
+		return Station{}, newInvalidInputError("invalid station: name must contain letters or digits")
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return Station{}, newInvalidInputError("invalid station: coordinates out of range")
+	}
+	if depth != nil && *depth < 0 {
+		return Station{}, newInvalidInputError("invalid station: depth cannot be negative")
+	}
+
+	return Station{
+		candhisID: candhisID,
+		buoyID:    buoyID,
+		name:      name,
+		indexName: indexName,
+		latitude:  latitude,
+		longitude: longitude,
+		depth:     depth,
+		operator:  operator,
+		active:    active,
+	}, nil
+}
+
+// decodeCandhisID returns the buoy ID of the camp parameter encoded in a Candhis campaign ID.
+func decodeCandhisID(candhisID string) (string, error) {
+	query, err := base64.StdEncoding.DecodeString(candhisID)
+	if err != nil {
+		return "", newInvalidInputError("invalid station: candhis ID must be base64 encoded")
+	}
+
+	values, err := url.ParseQuery(string(query))
+	if err != nil || values.Get("camp") == "" {
+		return "", newInvalidInputError("invalid station: candhis ID must encode a camp parameter")
+	}
+
+	return values.Get("camp"), nil
+}
+
+// stationIndexName lowercases the name without its accents and joins its words with dashes.
+func stationIndexName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range accentsReplacer.Replace(strings.ToLower(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+func (s Station) CandhisID() string {
+	return s.candhisID
+}
+
+func (s Station) BuoyID() string {
+	return s.buoyID
+}
+
+func (s Station) Name() string {
+	return s.name
+}
+
+func (s Station) IndexName() string {
+	return s.indexName
+}
+
+func (s Station) Latitude() float64 {
+	return s.latitude
+}
+
+func (s Station) Longitude() float64 {
+	return s.longitude
+}
+
+func (s Station) Depth() *float64 {
+	return s.depth
+}
+
+func (s Station) Operator() string {
+	return s.operator
+}
+
+func (s Station) Active() bool {
+	return s.active
+}
+
+// StationCatalogue is the content of the Candhis catalogue of campaigns once its rows have been parsed.
+type StationCatalogue struct {
+	Stations []Station
+	// Number of data rows found in the catalogue, parsed or not.
+	RowsSeen int
+	Rejected []RejectedRow
+}
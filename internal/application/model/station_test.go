@@ -0,0 +1,90 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewStationSuccess(t *testing.T) {
+	depth := 60.0
+	station, err := model.NewStation("Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, &depth, "Cerema", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Y2FtcD0wMjkxMQ==", station.CandhisID())
+	assert.Equal(t, "02911", station.BuoyID())
+	assert.Equal(t, "Les Pierres Noires", station.Name())
+	assert.Equal(t, "les-pierres-noires", station.IndexName())
+	assert.Equal(t, 48.291, station.Latitude())
+	assert.Equal(t, -4.968, station.Longitude())
+	assert.Equal(t, &depth, station.Depth())
+	assert.Equal(t, "Cerema", station.Operator())
+	assert.True(t, station.Active())
+}
+
+func TestStationIndexName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Belle-Île":          "belle-ile",
+		"Baie d'Audierne":    "baie-d-audierne",
+		" Cap Ferret (33) ":  "cap-ferret-33",
+		"Les Pierres Noires": "les-pierres-noires",
+	} {
+		station, err := model.NewStation("Y2FtcD0wMjkxMQ==", name, 0, 0, nil, "", true)
+		require.NoError(t, err)
+		assert.Equal(t, expected, station.IndexName(), name)
+	}
+}
+
+func TestNewStationFailure(t *testing.T) {
+	negativeDepth := -1.0
+
+	testCases := map[string]struct {
+		candhisID string
+		name      string
+		latitude  float64
+		depth     *float64
+		errMsg    string
+	}{
+		"not base64": {
+			candhisID: "camp=02911",
+			name:      "Les Pierres Noires",
+			errMsg:    "invalid station: candhis ID must be base64 encoded",
+		},
+		"no camp parameter": {
+			candhisID: "Zm9vPWJhcg==",
+			name:      "Les Pierres Noires",
+			errMsg:    "invalid station: candhis ID must encode a camp parameter",
+		},
+		"empty name": {
+			candhisID: "Y2FtcD0wMjkxMQ==",
+			errMsg:    "invalid station: name cannot be empty",
+		},
+		"name without letters": {
+			candhisID: "Y2FtcD0wMjkxMQ==",
+			name:      "--",
+			errMsg:    "invalid station: name must contain letters or digits",
+		},
+		"latitude out of range": {
+			candhisID: "Y2FtcD0wMjkxMQ==",
+			name:      "Les Pierres Noires",
+			latitude:  91,
+			errMsg:    "invalid station: coordinates out of range",
+		},
+		"negative depth": {
+			candhisID: "Y2FtcD0wMjkxMQ==",
+			name:      "Les Pierres Noires",
+			depth:     &negativeDepth,
+			errMsg:    "invalid station: depth cannot be negative",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			station, err := model.NewStation(tc.candhisID, tc.name, tc.latitude, 0, tc.depth, "", true)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.Station{}, station)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+type WaveDataExportQuery struct {
+	from *time.Time
+	to   *time.Time
+}
+
+// NewWaveDataExportQuery validates the time range of an export, a nil bound leaves the range open on that side.
+func NewWaveDataExportQuery(from, to *time.Time) (WaveDataExportQuery, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return WaveDataExportQuery{}, newInvalidInputError("invalid time range: from must not be after to")
+	}
+
+	return WaveDataExportQuery{from: from, to: to}, nil
+}
+
+func (q WaveDataExportQuery) From() *time.Time {
+	return q.from
+}
+
+func (q WaveDataExportQuery) To() *time.Time {
+	return q.to
+}
+
+// WaveDataExportWriter encodes exported observations as they are read. Begin is called once with the number of
+// observations before the first Write, and End once after the last one.
+type WaveDataExportWriter interface {
+	Begin(count int) error
+	Write(waveData model.WaveData) error
+	End() error
+}
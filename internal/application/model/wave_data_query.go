@@ -0,0 +1,115 @@
+package model
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+const (
+	DefaultWaveDataQueryLimit = 100
+	MaxWaveDataQueryLimit     = 1000
+)
+
+type WaveDataQuery struct {
+	from  *time.Time
+	to    *time.Time
+	after *time.Time
+	limit int
+	sort  SortOrder
+}
+
+// NewWaveDataQuery validates the read parameters of a campaign observations listing. A zero limit and an empty sort
+// fall back to DefaultWaveDataQueryLimit and SortOrderDesc.
+func NewWaveDataQuery(from, to *time.Time, cursor string, limit int, sort SortOrder) (WaveDataQuery, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return WaveDataQuery{}, newInvalidInputError("invalid time range: from must not be after to")
+	}
+
+	if limit == 0 {
+		limit = DefaultWaveDataQueryLimit
+	}
+	if limit < 0 || limit > MaxWaveDataQueryLimit {
+		return WaveDataQuery{}, newInvalidInputError("invalid limit: must be between 1 and 1000")
+	}
+
+	switch sort {
+	case "":
+		sort = SortOrderDesc
+	case SortOrderAsc, SortOrderDesc:
+	default:
+		return WaveDataQuery{}, newInvalidInputError("invalid sort: must be asc or desc")
+	}
+
+	var after *time.Time
+	if cursor != "" {
+		t, err := decodeWaveDataCursor(cursor)
+		if err != nil {
+			return WaveDataQuery{}, err
+		}
+		after = &t
+	}
+
+	return WaveDataQuery{from: from, to: to, after: after, limit: limit, sort: sort}, nil
+}
+
+func (q WaveDataQuery) From() *time.Time {
+	return q.from
+}
+
+func (q WaveDataQuery) To() *time.Time {
+	return q.to
+}
+
+// After is the timestamp of the last observation of the previous page, nil for the first page.
+func (q WaveDataQuery) After() *time.Time {
+	return q.after
+}
+
+func (q WaveDataQuery) Limit() int {
+	return q.limit
+}
+
+func (q WaveDataQuery) Sort() SortOrder {
+	return q.sort
+}
+
+type WaveDataPage struct {
+	WaveData   []model.WaveData
+	NextCursor string
+}
+
+// NewWaveDataPage builds a page and sets its cursor when the page is full, since more observations may follow.
+func NewWaveDataPage(waveData []model.WaveData, query WaveDataQuery) WaveDataPage {
+	page := WaveDataPage{WaveData: waveData}
+	if len(waveData) > 0 && len(waveData) == query.Limit() {
+		page.NextCursor = EncodeWaveDataCursor(waveData[len(waveData)-1].Timestamp())
+	}
+	return page
+}
+
+func EncodeWaveDataCursor(timestamp time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(timestamp.UTC().Format(time.RFC3339)))
+}
+
+func decodeWaveDataCursor(cursor string) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, newInvalidInputError("invalid cursor")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, string(raw))
+	if err != nil {
+		return time.Time{}, newInvalidInputError("invalid cursor")
+	}
+
+	return timestamp, nil
+}
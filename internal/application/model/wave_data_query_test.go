@@ -0,0 +1,104 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	domainmodel "github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+func TestNewWaveDataQuerySuccess(t *testing.T) {
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 18, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+
+	query, err := model.NewWaveDataQuery(&from, &to, model.EncodeWaveDataCursor(after), 10, model.SortOrderAsc)
+	require.NoError(t, err)
+
+	assert.Equal(t, &from, query.From())
+	assert.Equal(t, &to, query.To())
+	assert.Equal(t, &after, query.After())
+	assert.Equal(t, 10, query.Limit())
+	assert.Equal(t, model.SortOrderAsc, query.Sort())
+}
+
+func TestNewWaveDataQueryDefaults(t *testing.T) {
+	query, err := model.NewWaveDataQuery(nil, nil, "", 0, "")
+	require.NoError(t, err)
+
+	assert.Nil(t, query.From())
+	assert.Nil(t, query.To())
+	assert.Nil(t, query.After())
+	assert.Equal(t, model.DefaultWaveDataQueryLimit, query.Limit())
+	assert.Equal(t, model.SortOrderDesc, query.Sort())
+}
+
+func TestNewWaveDataQueryFailure(t *testing.T) {
+	from := time.Date(2024, 9, 18, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		from   *time.Time
+		to     *time.Time
+		cursor string
+		limit  int
+		sort   model.SortOrder
+		errMsg string
+	}{
+		"from after to": {
+			from:   &from,
+			to:     &to,
+			errMsg: "invalid time range: from must not be after to",
+		},
+		"negative limit": {
+			limit:  -1,
+			errMsg: "invalid limit: must be between 1 and 1000",
+		},
+		"limit too big": {
+			limit:  1001,
+			errMsg: "invalid limit: must be between 1 and 1000",
+		},
+		"unknown sort": {
+			sort:   "random",
+			errMsg: "invalid sort: must be asc or desc",
+		},
+		"cursor not base64": {
+			cursor: "!!!",
+			errMsg: "invalid cursor",
+		},
+		"cursor not a timestamp": {
+			cursor: "bm90LWEtdGltZXN0YW1w",
+			errMsg: "invalid cursor",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			query, err := model.NewWaveDataQuery(tc.from, tc.to, tc.cursor, tc.limit, tc.sort)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Equal(t, model.WaveDataQuery{}, query)
+		})
+	}
+}
+
+func TestNewWaveDataPage(t *testing.T) {
+	query, err := model.NewWaveDataQuery(nil, nil, "", 2, model.SortOrderDesc)
+	require.NoError(t, err)
+
+	wavesData := []domainmodel.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	fullPage := model.NewWaveDataPage(wavesData, query)
+	assert.Equal(t, wavesData, fullPage.WaveData)
+	assert.Equal(t, model.EncodeWaveDataCursor(wavesData[1].Timestamp()), fullPage.NextCursor)
+
+	lastPage := model.NewWaveDataPage(wavesData[:1], query)
+	assert.Equal(t, wavesData[:1], lastPage.WaveData)
+	assert.Empty(t, lastPage.NextCursor)
+}
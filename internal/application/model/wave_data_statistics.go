@@ -0,0 +1,206 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+type StatisticsInterval string
+
+const (
+	StatisticsIntervalHour  StatisticsInterval = "hour"
+	StatisticsIntervalDay   StatisticsInterval = "day"
+	StatisticsIntervalWeek  StatisticsInterval = "week"
+	StatisticsIntervalMonth StatisticsInterval = "month"
+)
+
+// StatisticsField is an aggregated observation field, named after its Elasticsearch document field.
+type StatisticsField string
+
+const (
+	StatisticsFieldAverageTopThirdWaveHeight StatisticsField = "h1_3"
+	StatisticsFieldMaxHeight                 StatisticsField = "hmax"
+	StatisticsFieldAverageTopThirdWavePeriod StatisticsField = "th1_3"
+	StatisticsFieldTemperature               StatisticsField = "temperature"
+)
+
+var statisticsFields = []StatisticsField{
+	StatisticsFieldAverageTopThirdWaveHeight,
+	StatisticsFieldMaxHeight,
+	StatisticsFieldAverageTopThirdWavePeriod,
+	StatisticsFieldTemperature,
+}
+
+type StatisticsMetric string
+
+const (
+	StatisticsMetricMin StatisticsMetric = "min"
+	StatisticsMetricMax StatisticsMetric = "max"
+	StatisticsMetricAvg StatisticsMetric = "avg"
+	StatisticsMetricP50 StatisticsMetric = "p50"
+	StatisticsMetricP90 StatisticsMetric = "p90"
+	StatisticsMetricP99 StatisticsMetric = "p99"
+)
+
+var statisticsMetrics = []StatisticsMetric{
+	StatisticsMetricMin,
+	StatisticsMetricMax,
+	StatisticsMetricAvg,
+	StatisticsMetricP50,
+	StatisticsMetricP90,
+	StatisticsMetricP99,
+}
+
+// Percentile returns the percent computed by a percentile metric, ok is false for the other metrics.
+func (m StatisticsMetric) Percentile() (percent float64, ok bool) {
+	switch m {
+	case StatisticsMetricP50:
+		return 50, true
+	case StatisticsMetricP90:
+		return 90, true
+	case StatisticsMetricP99:
+		return 99, true
+	default:
+		return 0, false
+	}
+}
+
+// MaxStatisticsBuckets bounds the number of buckets of the time series between the from and to of a query.
+const MaxStatisticsBuckets = 10000
+
+// statisticsIntervalSeconds are the durations of the fixed length intervals, in UTC.
+var statisticsIntervalSeconds = map[StatisticsInterval]int64{
+	StatisticsIntervalHour: 60 * 60,
+	StatisticsIntervalDay:  24 * 60 * 60,
+	StatisticsIntervalWeek: 7 * 24 * 60 * 60,
+}
+
+type WaveDataStatisticsQuery struct {
+	from     *time.Time
+	to       *time.Time
+	interval StatisticsInterval
+	fields   []StatisticsField
+	metrics  []StatisticsMetric
+}
+
+// NewWaveDataStatisticsQuery validates the parameters of a campaign statistics request. An empty interval falls back
+// to StatisticsIntervalDay, and empty fields or metrics fall back to all of them. Duplicated fields and metrics are
+// only computed once. The hour and day intervals require both from and to, so that their range can be bounded, and a
+// range with both from and to may span at most MaxStatisticsBuckets intervals.
+func NewWaveDataStatisticsQuery(
+	from, to *time.Time,
+	interval StatisticsInterval,
+	fields []StatisticsField,
+	metrics []StatisticsMetric,
+) (WaveDataStatisticsQuery, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return WaveDataStatisticsQuery{}, newInvalidInputError("invalid time range: from must not be after to")
+	}
+
+	switch interval {
+	case "":
+		interval = StatisticsIntervalDay
+	case StatisticsIntervalHour, StatisticsIntervalDay, StatisticsIntervalWeek, StatisticsIntervalMonth:
+	default:
+		return WaveDataStatisticsQuery{}, newInvalidInputError("invalid interval: must be one of hour, day, week, month")
+	}
+	if (interval == StatisticsIntervalHour || interval == StatisticsIntervalDay) && (from == nil || to == nil) {
+		return WaveDataStatisticsQuery{}, newInvalidInputError(fmt.Sprintf(
+			"invalid time range: from and to are required with the %s interval", interval))
+	}
+	if from != nil && to != nil && statisticsIntervals(*from, *to, interval) >= MaxStatisticsBuckets {
+		return WaveDataStatisticsQuery{}, newInvalidInputError(fmt.Sprintf(
+			"invalid time range: must span at most %d %s intervals", MaxStatisticsBuckets, interval))
+	}
+
+	if len(fields) == 0 {
+		fields = statisticsFields
+	}
+	for _, field := range fields {
+		if !slices.Contains(statisticsFields, field) {
+			return WaveDataStatisticsQuery{}, newInvalidInputError("invalid field: must be one of h1_3, hmax, th1_3, temperature")
+		}
+	}
+
+	if len(metrics) == 0 {
+		metrics = statisticsMetrics
+	}
+	for _, metric := range metrics {
+		if !slices.Contains(statisticsMetrics, metric) {
+			return WaveDataStatisticsQuery{}, newInvalidInputError("invalid metric: must be one of min, max, avg, p50, p90, p99")
+		}
+	}
+
+	return WaveDataStatisticsQuery{
+		from:     from,
+		to:       to,
+		interval: interval,
+		fields:   compactUnique(fields),
+		metrics:  compactUnique(metrics),
+	}, nil
+}
+
+func (q WaveDataStatisticsQuery) From() *time.Time {
+	return q.from
+}
+
+func (q WaveDataStatisticsQuery) To() *time.Time {
+	return q.to
+}
+
+func (q WaveDataStatisticsQuery) Interval() StatisticsInterval {
+	return q.interval
+}
+
+func (q WaveDataStatisticsQuery) Fields() []StatisticsField {
+	return q.fields
+}
+
+func (q WaveDataStatisticsQuery) Metrics() []StatisticsMetric {
+	return q.metrics
+}
+
+// WaveDataStatisticsBucket holds the statistics of the observations of one interval of the time series.
+type WaveDataStatisticsBucket struct {
+	Start time.Time
+	Count int
+	// Values holds the requested metrics of every requested field, a metric is missing when the bucket has no
+	// observation to compute it from.
+	Values map[StatisticsField]map[StatisticsMetric]float64
+	// PeakDirectionMean is the circular mean of the peak directions in degrees, nil when it is undefined.
+	PeakDirectionMean *float64
+}
+
+// CircularMeanDirection returns the mean in degrees, within [0, 360), of directions from the sums of their sines
+// and cosines. The mean is undefined, and nil is returned, when the directions cancel each other out.
+func CircularMeanDirection(sumSin, sumCos float64) *float64 {
+	const epsilon = 1e-9
+	if math.Abs(sumSin) < epsilon && math.Abs(sumCos) < epsilon {
+		return nil
+	}
+
+	degrees := math.Mod(math.Atan2(sumSin, sumCos)*180/math.Pi+360, 360)
+	return &degrees
+}
+
+// statisticsIntervals counts the intervals elapsed from from to to. The time series has one more bucket, or two when
+// from is not at the start of an interval.
+func statisticsIntervals(from, to time.Time, interval StatisticsInterval) int64 {
+	if interval == StatisticsIntervalMonth {
+		from, to = from.UTC(), to.UTC()
+		return int64(to.Year()-from.Year())*12 + int64(to.Month()) - int64(from.Month())
+	}
+	return (to.Unix() - from.Unix()) / statisticsIntervalSeconds[interval]
+}
+
+func compactUnique[T comparable](values []T) []T {
+	unique := make([]T, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,182 @@
+package model_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+func TestNewWaveDataStatisticsQuerySuccess(t *testing.T) {
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := model.NewWaveDataStatisticsQuery(&from, &to, model.StatisticsIntervalWeek,
+		[]model.StatisticsField{model.StatisticsFieldMaxHeight, model.StatisticsFieldMaxHeight},
+		[]model.StatisticsMetric{model.StatisticsMetricMax, model.StatisticsMetricP90})
+	require.NoError(t, err)
+
+	assert.Equal(t, &from, query.From())
+	assert.Equal(t, &to, query.To())
+	assert.Equal(t, model.StatisticsIntervalWeek, query.Interval())
+	assert.Equal(t, []model.StatisticsField{model.StatisticsFieldMaxHeight}, query.Fields())
+	assert.Equal(t, []model.StatisticsMetric{model.StatisticsMetricMax, model.StatisticsMetricP90}, query.Metrics())
+}
+
+func TestNewWaveDataStatisticsQueryMaxBuckets(t *testing.T) {
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add((model.MaxStatisticsBuckets - 1) * time.Hour)
+
+	query, err := model.NewWaveDataStatisticsQuery(&from, &to, model.StatisticsIntervalHour, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &to, query.To())
+}
+
+func TestNewWaveDataStatisticsQueryDefaults(t *testing.T) {
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := model.NewWaveDataStatisticsQuery(&from, &to, "", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.StatisticsIntervalDay, query.Interval())
+	assert.Equal(t, []model.StatisticsField{
+		model.StatisticsFieldAverageTopThirdWaveHeight,
+		model.StatisticsFieldMaxHeight,
+		model.StatisticsFieldAverageTopThirdWavePeriod,
+		model.StatisticsFieldTemperature,
+	}, query.Fields())
+	assert.Equal(t, []model.StatisticsMetric{
+		model.StatisticsMetricMin,
+		model.StatisticsMetricMax,
+		model.StatisticsMetricAvg,
+		model.StatisticsMetricP50,
+		model.StatisticsMetricP90,
+		model.StatisticsMetricP99,
+	}, query.Metrics())
+}
+
+func TestNewWaveDataStatisticsQueryUnbounded(t *testing.T) {
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := model.NewWaveDataStatisticsQuery(&from, nil, model.StatisticsIntervalMonth, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &from, query.From())
+	assert.Nil(t, query.To())
+
+	query, err = model.NewWaveDataStatisticsQuery(nil, nil, model.StatisticsIntervalWeek, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, query.From())
+	assert.Nil(t, query.To())
+}
+
+func TestNewWaveDataStatisticsQueryFailure(t *testing.T) {
+	from := time.Date(2024, 9, 18, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	tooManyHours := to.Add(model.MaxStatisticsBuckets * time.Hour)
+	tooManyMonths := to.AddDate(0, model.MaxStatisticsBuckets, 0)
+
+	testCases := map[string]struct {
+		from     *time.Time
+		to       *time.Time
+		interval model.StatisticsInterval
+		fields   []model.StatisticsField
+		metrics  []model.StatisticsMetric
+		errMsg   string
+	}{
+		"from after to": {
+			from:   &from,
+			to:     &to,
+			errMsg: "invalid time range: from must not be after to",
+		},
+		"too many hour buckets": {
+			from:     &to,
+			to:       &tooManyHours,
+			interval: model.StatisticsIntervalHour,
+			errMsg:   "invalid time range: must span at most 10000 hour intervals",
+		},
+		"too many month buckets": {
+			from:     &to,
+			to:       &tooManyMonths,
+			interval: model.StatisticsIntervalMonth,
+			errMsg:   "invalid time range: must span at most 10000 month intervals",
+		},
+		"hour interval without to": {
+			from:     &to,
+			interval: model.StatisticsIntervalHour,
+			errMsg:   "invalid time range: from and to are required with the hour interval",
+		},
+		"default interval without bounds": {
+			errMsg: "invalid time range: from and to are required with the day interval",
+		},
+		"unknown interval": {
+			interval: "minute",
+			errMsg:   "invalid interval: must be one of hour, day, week, month",
+		},
+		"unknown field": {
+			interval: model.StatisticsIntervalMonth,
+			fields:   []model.StatisticsField{"peak_direction"},
+			errMsg:   "invalid field: must be one of h1_3, hmax, th1_3, temperature",
+		},
+		"unknown metric": {
+			interval: model.StatisticsIntervalMonth,
+			metrics:  []model.StatisticsMetric{"p95"},
+			errMsg:   "invalid metric: must be one of min, max, avg, p50, p90, p99",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := model.NewWaveDataStatisticsQuery(tc.from, tc.to, tc.interval, tc.fields, tc.metrics)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestStatisticsMetricPercentile(t *testing.T) {
+	percent, ok := model.StatisticsMetricP90.Percentile()
+	assert.True(t, ok)
+	assert.Equal(t, 90.0, percent)
+
+	_, ok = model.StatisticsMetricAvg.Percentile()
+	assert.False(t, ok)
+}
+
+func TestCircularMeanDirection(t *testing.T) {
+	testCases := map[string]struct {
+		directions []float64
+		expected   float64
+	}{
+		"around north": {directions: []float64{350, 10}, expected: 0},
+		"east":         {directions: []float64{80, 100}, expected: 90},
+		"west":         {directions: []float64{260, 280}, expected: 270},
+		"single":       {directions: []float64{8}, expected: 8},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var sumSin, sumCos float64
+			for _, direction := range tc.directions {
+				sumSin += math.Sin(direction * math.Pi / 180)
+				sumCos += math.Cos(direction * math.Pi / 180)
+			}
+
+			mean := model.CircularMeanDirection(sumSin, sumCos)
+			require.NotNil(t, mean)
+			assert.GreaterOrEqual(t, *mean, 0.0)
+			assert.Less(t, *mean, 360.0)
+			// Compares the angles on the circle, 359.9999 and 0 are the same direction.
+			assert.InDelta(t, 0, math.Mod(*mean-tc.expected+540, 360)-180, 1e-6)
+		})
+	}
+}
+
+func TestCircularMeanDirection_Undefined(t *testing.T) {
+	sumSin := math.Sin(90*math.Pi/180) + math.Sin(270*math.Pi/180)
+	sumCos := math.Cos(90*math.Pi/180) + math.Cos(270*math.Pi/180)
+
+	assert.Nil(t, model.CircularMeanDirection(sumSin, sumCos))
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// WaveDataSummary describes the observations stored for a campaign.
+type WaveDataSummary struct {
+	Count int
+	// Timestamps of the oldest and of the most recent observations.
+	First  time.Time
+	Last   time.Time
+	Latest model.WaveData
+}
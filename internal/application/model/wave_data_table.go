@@ -0,0 +1,22 @@
+package model
+
+import "github.com/tul1/candhis_api/internal/domain/model"
+
+// WaveDataTable is the content of a Candhis campaign table once its rows have been parsed.
+type WaveDataTable struct {
+	WaveData []model.WaveData
+	// Number of data rows found in the table, parsed or not.
+	RowsSeen int
+	Rejected []RejectedRow
+	// Fingerprint of the header cells of the table, empty when the table has none.
+	LayoutFingerprint string
+	// Set when the columns are not in the order the scraper was written for, although the rows could still be parsed.
+	LayoutChanged bool
+}
+
+// RejectedRow is a table row that could not be parsed into an observation.
+type RejectedRow struct {
+	// Position of the row in the table body, starting at 1.
+	Row    int
+	Reason string
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/alert_event.go -source=alert_event.go AlertEvent
+type AlertEvent interface {
+	Add(ctx context.Context, event appmodel.AlertEvent) error
+	// LatestByRule returns the latest event of each rule, by rule identifier.
+	LatestByRule(ctx context.Context) (map[int64]appmodel.AlertEvent, error)
+	// ListByRule returns the latest events of the rule, most recent first.
+	ListByRule(ctx context.Context, ruleID int64, limit int) ([]appmodel.AlertEvent, error)
+}
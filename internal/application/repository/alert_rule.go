@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/alert_rule.go -source=alert_rule.go AlertRule
+type AlertRule interface {
+	// Add stores a new rule and returns it with its identifier.
+	Add(ctx context.Context, rule appmodel.AlertRule) (appmodel.AlertRule, error)
+	// Update replaces the rule with the same identifier, ErrAlertRuleNotFound when there is none.
+	Update(ctx context.Context, rule appmodel.AlertRule) error
+	// Delete removes the rule and its events, ErrAlertRuleNotFound when there is none.
+	Delete(ctx context.Context, id int64) error
+	// Get returns the rule, nil when there is none.
+	Get(ctx context.Context, id int64) (*appmodel.AlertRule, error)
+	// List returns every rule ordered by identifier.
+	List(ctx context.Context) ([]appmodel.AlertRule, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package clientmock -destination=./client_mock/alert_webhook.go -source=alert_webhook.go AlertWebhook
+type AlertWebhook interface {
+	// Send posts the notification to the webhook URL, retrying failed requests. It returns the number of requests sent.
+	Send(ctx context.Context, webhookURL string, notification appmodel.AlertNotification) (int, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/backfill_checkpoint.go -source=backfill_checkpoint.go BackfillCheckpoint
+type BackfillCheckpoint interface {
+	// Get returns nil when the backfill of the period was never started.
+	Get(ctx context.Context, buoyID string, from, to time.Time) (*appmodel.BackfillCheckpoint, error)
+	Save(ctx context.Context, checkpoint appmodel.BackfillCheckpoint) error
+}
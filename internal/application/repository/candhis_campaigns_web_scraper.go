@@ -1,11 +1,21 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	appmodel "github.com/tul1/candhis_api/internal/application/model"
-	"github.com/tul1/candhis_api/internal/domain/model"
 )
 
 //go:generate mockgen -package clientmock -destination=./client_mock/candhis_campaigns_web_scraper.go -source=candhis_campaigns_web_scraper.go CandhisCampaignsWebScraper
 type CandhisCampaignsWebScraper interface {
-	GatherWavesDataFromWebTable(candhisSessionID appmodel.CandhisSessionID, candhisURL string) ([]model.WaveData, error)
+	GatherWavesDataFromWebTable(
+		ctx context.Context, candhisSessionID appmodel.CandhisSessionID, candhisURL string) (appmodel.WaveDataTable, error)
+	// GatherArchivedWavesDataFromWebTable gathers the observations of the campaign between the from and to days included.
+	GatherArchivedWavesDataFromWebTable(
+		ctx context.Context,
+		candhisSessionID appmodel.CandhisSessionID,
+		candhisURL string,
+		from, to time.Time,
+	) (appmodel.WaveDataTable, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package clientmock -destination=./client_mock/candhis_catalogue_web_scraper.go -source=candhis_catalogue_web_scraper.go CandhisCatalogueWebScraper
+type CandhisCatalogueWebScraper interface {
+	GatherStationsFromWebCatalogue(
+		ctx context.Context, candhisSessionID appmodel.CandhisSessionID, catalogueURL string,
+	) (appmodel.StationCatalogue, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package clientmock -destination=./client_mock/candhis_spectra_downloader.go -source=candhis_spectra_downloader.go CandhisSpectraDownloader
+type CandhisSpectraDownloader interface {
+	// DownloadSpectra downloads the spectra of the campaign between the from and to days included.
+	DownloadSpectra(
+		ctx context.Context,
+		candhisSessionID appmodel.CandhisSessionID,
+		candhisURL string,
+		from, to time.Time,
+	) (appmodel.SpectrumFile, error)
+}
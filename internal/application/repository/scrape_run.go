@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/scrape_run.go -source=scrape_run.go ScrapeRun
+type ScrapeRun interface {
+	Add(ctx context.Context, run appmodel.ScrapeRun) error
+	// ListRecent returns the latest runs, most recent first.
+	ListRecent(ctx context.Context, limit int) ([]appmodel.ScrapeRun, error)
+	// LatestByCampaign returns the latest run of the scraper for each campaign, by buoy ID.
+	LatestByCampaign(ctx context.Context, scraper appmodel.Scraper) (map[string]appmodel.ScrapeRun, error)
+	// LastRunFinishedAt returns when the latest run of the scrapers for the campaign finished, the zero time when none
+	// ran.
+	LastRunFinishedAt(ctx context.Context, campaign string, scrapers ...appmodel.Scraper) (time.Time, error)
+	// LastLayoutFingerprint returns the layout fingerprint of the latest run of the scrapers that parsed a table of the
+	// campaign, empty when none did.
+	LastLayoutFingerprint(ctx context.Context, campaign string, scrapers ...appmodel.Scraper) (string, error)
+}
@@ -8,6 +8,10 @@ import (
 
 //go:generate mockgen -package persistencemock -destination=./persistence_mock/sessionid.go -source=sessionid.go SessionID
 type SessionID interface {
+	// Get returns the newest valid session ID and records its use, appmodel.ErrNoValidCandhisSession when the pool
+	// has none.
 	Get(ctx context.Context) (*appmodel.CandhisSessionID, error)
-	Update(ctx context.Context, sessionID appmodel.CandhisSessionID) error
+	Add(ctx context.Context, session appmodel.CandhisSession) error
+	// Invalidate marks a session ID rejected by Candhis, so that Get no longer returns it.
+	Invalidate(ctx context.Context, sessionID appmodel.CandhisSessionID) error
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/spectrum.go -source=spectrum.go Spectrum
+type Spectrum interface {
+	// AddBatch stores all the spectra at once, the result tells which spectra were rejected.
+	AddBatch(ctx context.Context, spectra []model.Spectrum, indexName string) (appmodel.BatchResult, error)
+	// Nearest returns the spectrum closest in time to timestamp, at most maxDistance away from it, and nil when there
+	// is none.
+	Nearest(ctx context.Context, indexName string, timestamp time.Time, maxDistance time.Duration) (*model.Spectrum, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+//go:generate mockgen -package persistencemock -destination=./persistence_mock/station.go -source=station.go Station
+type Station interface {
+	// UpsertBatch stores the stations, replacing the metadata of the ones already known.
+	UpsertBatch(ctx context.Context, stations []appmodel.Station) error
+	// List returns every known station ordered by name.
+	List(ctx context.Context) ([]appmodel.Station, error)
+	// Get returns the station whose campaign observations are stored in the index, nil when there is none.
+	Get(ctx context.Context, indexName string) (*appmodel.Station, error)
+}
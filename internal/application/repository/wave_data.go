@@ -3,10 +3,23 @@ package repository
 import (
 	"context"
 
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/domain/model"
 )
 
 //go:generate mockgen -package persistencemock -destination=./persistence_mock/wave_data.go -source=wave_data.go WaveData
 type WaveData interface {
-	Add(ctx context.Context, waveData model.WaveData, indexName string) error
+	// AddBatch stores all the observations at once, the result tells which observations were rejected.
+	AddBatch(ctx context.Context, waveData []model.WaveData, indexName string) (appmodel.BatchResult, error)
+	List(ctx context.Context, indexName string, query appmodel.WaveDataQuery) (appmodel.WaveDataPage, error)
+	// Statistics aggregates the observations into a time series of buckets of the query interval.
+	Statistics(
+		ctx context.Context,
+		indexName string,
+		query appmodel.WaveDataStatisticsQuery,
+	) ([]appmodel.WaveDataStatisticsBucket, error)
+	// Summaries describes the observations of each index, indices without observations are not in the map.
+	Summaries(ctx context.Context, indexNames []string) (map[string]appmodel.WaveDataSummary, error)
+	// Export writes the observations of the query time range in timestamp order, without holding them in memory.
+	Export(ctx context.Context, indexName string, query appmodel.WaveDataExportQuery, writer appmodel.WaveDataExportWriter) error
 }
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+)
+
+type AlertEvaluator interface {
+	Evaluate(ctx context.Context) ([]appmodel.AlertEvent, error)
+}
+
+type alertEvaluator struct {
+	alertRule          repository.AlertRule
+	alertEvent         repository.AlertEvent
+	waveData           repository.WaveData
+	alertWebhookClient repository.AlertWebhook
+}
+
+func NewAlertEvaluator(
+	alertRuleRepo repository.AlertRule,
+	alertEventRepo repository.AlertEvent,
+	waveDataRepo repository.WaveData,
+	alertWebhookClient repository.AlertWebhook,
+) *alertEvaluator {
+	return &alertEvaluator{
+		alertRuleRepo,
+		alertEventRepo,
+		waveDataRepo,
+		alertWebhookClient,
+	}
+}
+
+// Evaluate checks every enabled rule against the latest observations of its campaign and returns the recorded state
+// changes. A rule fires when its condition holds and it is not already firing, unless it fired less than its cooldown
+// ago, and resolves when its condition no longer holds. Every state change is posted to the rule webhook and recorded,
+// even when the delivery failed. A failing rule does not prevent the others from being evaluated.
+func (a *alertEvaluator) Evaluate(ctx context.Context) ([]appmodel.AlertEvent, error) {
+	rules, err := a.alertRule.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+
+	latestEvents, err := a.alertEvent.LatestByRule(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest alert events: %w", err)
+	}
+
+	var events []appmodel.AlertEvent
+	var errs []error
+	for _, rule := range rules {
+		if !rule.Enabled() {
+			continue
+		}
+
+		latestEvent, hasEvent := latestEvents[rule.ID()]
+		event, err := a.evaluateRule(ctx, rule, latestEvent, hasEvent)
+		if event != nil {
+			events = append(events, *event)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("alert rule %d: %w", rule.ID(), err))
+		}
+	}
+
+	return events, errors.Join(errs...)
+}
+
+func (a *alertEvaluator) evaluateRule(
+	ctx context.Context,
+	rule appmodel.AlertRule,
+	latestEvent appmodel.AlertEvent,
+	hasEvent bool,
+) (*appmodel.AlertEvent, error) {
+	now := time.Now().UTC()
+
+	// Without window only the most recent observation is evaluated.
+	var from *time.Time
+	limit := 1
+	if rule.Window() > 0 {
+		windowStart := now.Add(-rule.Window())
+		from = &windowStart
+		limit = appmodel.MaxWaveDataQueryLimit
+	}
+	query, err := appmodel.NewWaveDataQuery(from, nil, "", limit, appmodel.SortOrderDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observations query: %w", err)
+	}
+
+	page, err := a.waveData.List(ctx, rule.Campaign(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign observations: %w", err)
+	}
+
+	value, ok := rule.Value(page.WaveData)
+	if !ok {
+		return nil, nil
+	}
+
+	firing := hasEvent && latestEvent.State() == appmodel.AlertStateFiring
+	breached := rule.Breached(value)
+
+	var state appmodel.AlertState
+	var firedAt time.Time
+	switch {
+	case breached && !firing:
+		if hasEvent && now.Before(latestEvent.FiredAt().Add(rule.Cooldown())) {
+			return nil, nil
+		}
+		state, firedAt = appmodel.AlertStateFiring, now
+	case !breached && firing:
+		state, firedAt = appmodel.AlertStateResolved, latestEvent.FiredAt()
+	default:
+		return nil, nil
+	}
+
+	observedAt := page.WaveData[0].Timestamp().UTC()
+	attempts, deliveryErr := a.alertWebhookClient.Send(ctx, rule.WebhookURL(), appmodel.AlertNotification{
+		Rule:       rule,
+		State:      state,
+		Value:      value,
+		ObservedAt: observedAt,
+		FiredAt:    firedAt,
+	})
+	var deliveryError string
+	if deliveryErr != nil {
+		deliveryError = deliveryErr.Error()
+	}
+
+	event, err := appmodel.NewAlertEvent(rule.ID(), state, value, observedAt, firedAt, now, attempts, deliveryError)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alert event: %w", err)
+	}
+
+	if err := a.alertEvent.Add(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to record alert event: %w", err)
+	}
+	if deliveryErr != nil {
+		return &event, fmt.Errorf("failed to deliver alert to webhook: %w", deliveryErr)
+	}
+
+	return &event, nil
+}
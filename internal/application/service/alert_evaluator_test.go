@@ -0,0 +1,287 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	clientmock "github.com/tul1/candhis_api/internal/application/repository/client_mock"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+const alertWebhookURL = "https://hooks.example.com/candhis"
+
+func TestAlertEvaluator_Evaluate_Fires(t *testing.T) {
+	mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+	rule := bigSwellRule(t, true)
+	observation := mustCreateWaveDataWithHeights(t, time.Now().UTC().Add(-10*time.Minute), "2.9", "4.6")
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{rule}, nil)
+	mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+	expectAlertObservations(mocks, "les-pierres-noires", observation)
+	mocks.alertWebhook.EXPECT().Send(gomock.Any(), alertWebhookURL, gomock.Cond(func(x any) bool {
+		notification, ok := x.(appmodel.AlertNotification)
+		return ok && notification.Rule == rule && notification.State == appmodel.AlertStateFiring &&
+			notification.Value == 4.6 && notification.ObservedAt.Equal(observation.Timestamp())
+	})).Return(1, nil)
+	mocks.alertEvent.EXPECT().Add(gomock.Any(), alertEventMatching(7, appmodel.AlertStateFiring, 4.6, 1, "")).Return(nil)
+
+	events, err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, appmodel.AlertStateFiring, events[0].State())
+	assert.Equal(t, events[0].CreatedAt(), events[0].FiredAt())
+}
+
+func TestAlertEvaluator_Evaluate_Resolves(t *testing.T) {
+	mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+	firedAt := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Microsecond)
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, true)}, nil)
+	mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(map[int64]appmodel.AlertEvent{
+		7: appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateFiring, 4.6, firedAt, firedAt, firedAt, 1, ""),
+	}, nil)
+	expectAlertObservations(mocks, "les-pierres-noires",
+		mustCreateWaveDataWithHeights(t, time.Now().UTC().Add(-10*time.Minute), "1.9", "3.1"))
+	mocks.alertWebhook.EXPECT().Send(gomock.Any(), alertWebhookURL, gomock.Any()).Return(1, nil)
+	mocks.alertEvent.EXPECT().Add(gomock.Any(), alertEventMatching(7, appmodel.AlertStateResolved, 3.1, 1, "")).Return(nil)
+
+	events, err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, appmodel.AlertStateResolved, events[0].State())
+	assert.Equal(t, firedAt, events[0].FiredAt())
+}
+
+func TestAlertEvaluator_Evaluate_NoStateChange(t *testing.T) {
+	now := time.Now().UTC()
+	firedAt := now.Add(-time.Hour)
+
+	testCases := map[string]struct {
+		latestEvent *appmodel.AlertEvent
+		maxHeight   string
+	}{
+		"still firing": {
+			latestEvent: ptr(appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateFiring, 4.6, firedAt, firedAt,
+				firedAt, 1, "")),
+			maxHeight: "4.8",
+		},
+		"still resolved": {
+			latestEvent: ptr(appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateResolved, 3.1, firedAt, firedAt,
+				now, 1, "")),
+			maxHeight: "3.0",
+		},
+		"never fired": {
+			maxHeight: "3.0",
+		},
+		"cooling down": {
+			latestEvent: ptr(appmodeltest.MustCreateAlertEvent(t, 7, appmodel.AlertStateResolved, 3.1, firedAt, firedAt,
+				now, 1, "")),
+			maxHeight: "4.8",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+			latestEvents := map[int64]appmodel.AlertEvent{}
+			if tc.latestEvent != nil {
+				latestEvents[7] = *tc.latestEvent
+			}
+			mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, true)}, nil)
+			mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(latestEvents, nil)
+			expectAlertObservations(mocks, "les-pierres-noires",
+				mustCreateWaveDataWithHeights(t, now.Add(-10*time.Minute), "2.0", tc.maxHeight))
+
+			events, err := evaluator.Evaluate(context.Background())
+			require.NoError(t, err)
+			assert.Empty(t, events)
+		})
+	}
+}
+
+func TestAlertEvaluator_Evaluate_RiseOverWindow(t *testing.T) {
+	mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+	now := time.Now().UTC()
+	rule := appmodeltest.MustCreateAlertRule(t, 8, "Rising swell", "les-pierres-noires",
+		appmodel.AlertMetricAverageTopThirdWaveHeightRise, appmodel.AlertComparatorGreaterThan, 1, 3*time.Hour, 0,
+		alertWebhookURL, true)
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{rule}, nil)
+	mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+	mocks.waveData.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, query appmodel.WaveDataQuery) (appmodel.WaveDataPage, error) {
+			require.NotNil(t, query.From())
+			assert.WithinDuration(t, now.Add(-3*time.Hour), *query.From(), time.Minute)
+			assert.Equal(t, appmodel.MaxWaveDataQueryLimit, query.Limit())
+			return appmodel.WaveDataPage{WaveData: []model.WaveData{
+				mustCreateWaveDataWithHeights(t, now.Add(-10*time.Minute), "3.1", "4.2"),
+				mustCreateWaveDataWithHeights(t, now.Add(-70*time.Minute), "2.4", "3.6"),
+				mustCreateWaveDataWithHeights(t, now.Add(-130*time.Minute), "1.9", "3.2"),
+			}}, nil
+		})
+	mocks.alertWebhook.EXPECT().Send(gomock.Any(), alertWebhookURL, gomock.Any()).Return(1, nil)
+	mocks.alertEvent.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	events, err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.InDelta(t, 1.2, events[0].Value(), 1e-9)
+}
+
+func TestAlertEvaluator_Evaluate_SkipsDisabledRulesAndMissingObservations(t *testing.T) {
+	mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+	coldWater := appmodeltest.MustCreateAlertRule(t, 9, "Cold water", "belle-ile", appmodel.AlertMetricTemperature,
+		appmodel.AlertComparatorLessThan, 10, 0, 0, alertWebhookURL, true)
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, false), coldWater}, nil)
+	mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+	mocks.waveData.EXPECT().List(gomock.Any(), "belle-ile", gomock.Any()).Return(appmodel.WaveDataPage{}, nil)
+
+	events, err := evaluator.Evaluate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestAlertEvaluator_Evaluate_DeliveryFailure(t *testing.T) {
+	mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+
+	mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, true)}, nil)
+	mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+	expectAlertObservations(mocks, "les-pierres-noires",
+		mustCreateWaveDataWithHeights(t, time.Now().UTC().Add(-10*time.Minute), "2.9", "4.6"))
+	mocks.alertWebhook.EXPECT().Send(gomock.Any(), alertWebhookURL, gomock.Any()).
+		Return(3, errors.New("unexpected status code 502"))
+	mocks.alertEvent.EXPECT().Add(gomock.Any(),
+		alertEventMatching(7, appmodel.AlertStateFiring, 4.6, 3, "unexpected status code 502")).Return(nil)
+
+	events, err := evaluator.Evaluate(context.Background())
+	assert.EqualError(t, err, "alert rule 7: failed to deliver alert to webhook: unexpected status code 502")
+	assert.Len(t, events, 1)
+}
+
+func TestAlertEvaluator_Evaluate_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		expect        func(mocks alertEvaluatorMocks)
+		expectedError string
+	}{
+		"rules error": {
+			expect: func(mocks alertEvaluatorMocks) {
+				mocks.alertRule.EXPECT().List(gomock.Any()).Return(nil, errors.New("error database"))
+			},
+			expectedError: "failed to list alert rules: error database",
+		},
+		"events error": {
+			expect: func(mocks alertEvaluatorMocks) {
+				mocks.alertRule.EXPECT().List(gomock.Any()).Return(nil, nil)
+				mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, errors.New("error database"))
+			},
+			expectedError: "failed to get latest alert events: error database",
+		},
+		"observations error": {
+			expect: func(mocks alertEvaluatorMocks) {
+				mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, true)}, nil)
+				mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+				mocks.waveData.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+					Return(appmodel.WaveDataPage{}, errors.New("error elasticsearch"))
+			},
+			expectedError: "alert rule 7: failed to list campaign observations: error elasticsearch",
+		},
+		"record error": {
+			expect: func(mocks alertEvaluatorMocks) {
+				mocks.alertRule.EXPECT().List(gomock.Any()).Return([]appmodel.AlertRule{bigSwellRule(t, true)}, nil)
+				mocks.alertEvent.EXPECT().LatestByRule(gomock.Any()).Return(nil, nil)
+				expectAlertObservations(mocks, "les-pierres-noires",
+					mustCreateWaveDataWithHeights(t, time.Now().UTC().Add(-10*time.Minute), "2.9", "4.6"))
+				mocks.alertWebhook.EXPECT().Send(gomock.Any(), alertWebhookURL, gomock.Any()).Return(1, nil)
+				mocks.alertEvent.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New("error database"))
+			},
+			expectedError: "alert rule 7: failed to record alert event: error database",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, evaluator := setupAlertEvaluatorAndMocks(t)
+			tc.expect(mocks)
+
+			_, err := evaluator.Evaluate(context.Background())
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+// bigSwellRule fires when Hmax of the most recent observation exceeds 4 m, at most every 6 hours.
+func bigSwellRule(t *testing.T, enabled bool) appmodel.AlertRule {
+	t.Helper()
+
+	return appmodeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", appmodel.AlertMetricMaxHeight,
+		appmodel.AlertComparatorGreaterThan, 4, 0, 6*time.Hour, alertWebhookURL, enabled)
+}
+
+func mustCreateWaveDataWithHeights(t *testing.T, timestamp time.Time, h13, hmax string) model.WaveData {
+	t.Helper()
+
+	return modeltest.MustCreateWaveData(t, timestamp.Format("02/01/2006"), timestamp.Format("15:04"),
+		h13, hmax, "9.2", "280", "25", "15")
+}
+
+func expectAlertObservations(mocks alertEvaluatorMocks, campaign string, waveData model.WaveData) *gomock.Call {
+	query, _ := appmodel.NewWaveDataQuery(nil, nil, "", 1, appmodel.SortOrderDesc)
+	return mocks.waveData.EXPECT().List(gomock.Any(), campaign, query).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{waveData}}, nil)
+}
+
+// alertEventMatching matches a recorded alert event on everything but its times.
+func alertEventMatching(
+	ruleID int64,
+	state appmodel.AlertState,
+	value float64,
+	deliveryAttempts int,
+	deliveryError string,
+) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		event, ok := x.(appmodel.AlertEvent)
+		return ok &&
+			event.RuleID() == ruleID &&
+			event.State() == state &&
+			event.Value() == value &&
+			event.DeliveryAttempts() == deliveryAttempts &&
+			event.DeliveryError() == deliveryError
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+type alertEvaluatorMocks struct {
+	alertRule    *persistencemock.MockAlertRule
+	alertEvent   *persistencemock.MockAlertEvent
+	waveData     *persistencemock.MockWaveData
+	alertWebhook *clientmock.MockAlertWebhook
+}
+
+func setupAlertEvaluatorAndMocks(t *testing.T) (alertEvaluatorMocks, service.AlertEvaluator) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := alertEvaluatorMocks{
+		alertRule:    persistencemock.NewMockAlertRule(ctrl),
+		alertEvent:   persistencemock.NewMockAlertEvent(ctrl),
+		waveData:     persistencemock.NewMockWaveData(ctrl),
+		alertWebhook: clientmock.NewMockAlertWebhook(ctrl),
+	}
+
+	return mocks, service.NewAlertEvaluator(mocks.alertRule, mocks.alertEvent, mocks.waveData, mocks.alertWebhook)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+const backfillDay = 24 * time.Hour
+
+type CandhisBackfill interface {
+	Backfill(ctx context.Context, campaign appmodel.Campaign, from, to time.Time, chunkDays int) (appmodel.IngestionReport, error)
+}
+
+type candhisBackfill struct {
+	sessionID                        repository.SessionID
+	waveData                         repository.WaveData
+	candhisCampaignsWebScraperClient repository.CandhisCampaignsWebScraper
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+	backfillCheckpoint               repository.BackfillCheckpoint
+	scrapeRun                        repository.ScrapeRun
+	qualityControl                   model.QualityControl
+}
+
+func NewCandhisBackfill(
+	sessionIDRepo repository.SessionID,
+	waveDataRepo repository.WaveData,
+	candhisCampaignsWebScraperClient repository.CandhisCampaignsWebScraper,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	backfillCheckpointRepo repository.BackfillCheckpoint,
+	scrapeRunRepo repository.ScrapeRun,
+	qualityControl model.QualityControl,
+) *candhisBackfill {
+	return &candhisBackfill{
+		sessionIDRepo,
+		waveDataRepo,
+		candhisCampaignsWebScraperClient,
+		candhisSessionIDWebScraperClient,
+		backfillCheckpointRepo,
+		scrapeRunRepo,
+		qualityControl,
+	}
+}
+
+// Backfill ingests the archived observations of a campaign over the [from, to) days, chunkDays at a time. The
+// progress is checkpointed after every chunk, so a failed or interrupted backfill of the same period resumes where it
+// stopped. Chunks ingested twice are not duplicated since observations are indexed by timestamp. A chunk with
+// observations outside its days fails the backfill before being checkpointed. A chunk without observations, as left
+// by a buoy outage, is checkpointed and reported in the EmptyPeriods of the report.
+func (s *candhisBackfill) Backfill(
+	ctx context.Context,
+	campaign appmodel.Campaign,
+	from, to time.Time,
+	chunkDays int,
+) (appmodel.IngestionReport, error) {
+	startedAt := time.Now().UTC()
+
+	report, err := s.backfill(ctx, campaign, from, to, chunkDays)
+	recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperBackfill, campaign.BuoyID(), startedAt, err,
+		appmodel.NewScrapeRunCounts(report))
+
+	return report, errors.Join(err, recordErr)
+}
+
+func (s *candhisBackfill) backfill(
+	ctx context.Context,
+	campaign appmodel.Campaign,
+	from, to time.Time,
+	chunkDays int,
+) (appmodel.IngestionReport, error) {
+	if chunkDays < 1 {
+		return appmodel.IngestionReport{}, errors.New("invalid backfill chunk: must be at least one day")
+	}
+	if !from.Equal(from.Truncate(backfillDay)) || !to.Equal(to.Truncate(backfillDay)) {
+		return appmodel.IngestionReport{}, errors.New("invalid backfill period: from and to must be midnight UTC")
+	}
+
+	checkpoint, err := s.backfillCheckpoint.Get(ctx, campaign.BuoyID(), from, to)
+	if err != nil {
+		return appmodel.IngestionReport{}, fmt.Errorf("failed to get backfill checkpoint: %w", err)
+	}
+	if checkpoint == nil {
+		newCheckpoint, err := appmodel.NewBackfillCheckpoint(campaign.BuoyID(), from, to, from)
+		if err != nil {
+			return appmodel.IngestionReport{}, err
+		}
+		checkpoint = &newCheckpoint
+	}
+	if checkpoint.Done() {
+		return appmodel.IngestionReport{}, nil
+	}
+
+	session, err := newCandhisSession(ctx, s.sessionID, s.candhisSessionIDWebScraperClient)
+	if err != nil {
+		return appmodel.IngestionReport{}, err
+	}
+
+	var report appmodel.IngestionReport
+	for !checkpoint.Done() {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		chunkFrom, chunkTo := checkpoint.NextChunk(time.Duration(chunkDays) * backfillDay)
+		lastDay := chunkTo.Add(-backfillDay)
+
+		table, err := gather(ctx, session, func(candhisSessionID appmodel.CandhisSessionID) (appmodel.WaveDataTable, error) {
+			return s.candhisCampaignsWebScraperClient.GatherArchivedWavesDataFromWebTable(ctx,
+				candhisSessionID, campaign.CandhisURL(), chunkFrom, lastDay)
+		})
+		if err != nil {
+			return report, fmt.Errorf("failed to gather waves data from %s to %s from candhis web: %w",
+				chunkFrom.Format(time.DateOnly), lastDay.Format(time.DateOnly), err)
+		}
+		if err := checkArchiveWindow(table, chunkFrom, chunkTo); err != nil {
+			return report, fmt.Errorf("failed to gather waves data from %s to %s from candhis web: %w",
+				chunkFrom.Format(time.DateOnly), lastDay.Format(time.DateOnly), err)
+		}
+		table, err = compareLayoutFingerprint(ctx, s.scrapeRun, campaign.BuoyID(), table)
+		if err != nil {
+			return report.Add(appmodel.NewIngestionReport(table, appmodel.BatchResult{})), err
+		}
+
+		chunkReport, err := storeWaveDataTable(ctx, s.waveData, s.qualityControl, table, campaign.IndexName())
+		if len(table.WaveData) == 0 {
+			chunkReport.EmptyPeriods = []appmodel.DayRange{{From: chunkFrom, To: chunkTo}}
+		}
+		report = report.Add(chunkReport)
+		if err != nil {
+			return report, fmt.Errorf("failed to store waves data from %s to %s: %w",
+				chunkFrom.Format(time.DateOnly), lastDay.Format(time.DateOnly), err)
+		}
+
+		advanced, err := checkpoint.Advance(chunkTo)
+		if err != nil {
+			return report, err
+		}
+		if err := s.backfillCheckpoint.Save(ctx, advanced); err != nil {
+			return report, fmt.Errorf("failed to save backfill checkpoint: %w", err)
+		}
+		checkpoint = &advanced
+	}
+
+	return report, nil
+}
+
+// checkArchiveWindow makes sure the archive page served the observations of the [from, to) chunk. Candhis ignoring
+// the period of the archive query would serve its latest observations instead, which must not be checkpointed as the
+// chunk. An empty page is a period without observations.
+func checkArchiveWindow(table appmodel.WaveDataTable, from, to time.Time) error {
+	for _, waveData := range table.WaveData {
+		if waveData.Timestamp().Before(from) || !waveData.Timestamp().Before(to) {
+			return fmt.Errorf("%w: archive page has an observation of %s outside the requested period",
+				appmodel.ErrLayoutChanged, waveData.Timestamp().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	clientmock "github.com/tul1/candhis_api/internal/application/repository/client_mock"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	backfillFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	backfillTo   = time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)
+)
+
+func TestCandhisBackfill_Backfill_Success(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+	mocks, backfill := setupCandhisBackfillAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	firstChunk := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "01/01/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+	secondChunk := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "08/01/2024", "09:00", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	checkedFirstChunk := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", firstChunk)
+	checkedSecondChunk := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", secondChunk)
+
+	gomock.InOrder(
+		mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(nil, nil),
+		mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+			backfillFrom, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)).
+			Return(appmodel.WaveDataTable{WaveData: firstChunk, RowsSeen: 1}, nil),
+		mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedFirstChunk, "les-pierres-noires").
+			Return(appmodel.BatchResult{Indexed: 1}, nil),
+		mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 8)).Return(nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+			time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)).
+			Return(appmodel.WaveDataTable{WaveData: secondChunk, RowsSeen: 1}, nil),
+		mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedSecondChunk, "les-pierres-noires").
+			Return(appmodel.BatchResult{Unchanged: 1}, nil),
+		mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 11)).Return(nil),
+		mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", "",
+			appmodel.ScrapeRunCounts{RowsSeen: 2, Parsed: 2, Indexed: 1, Unchanged: 1})).Return(nil),
+	)
+
+	report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 7)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 2, Parsed: 2, Indexed: 1, Unchanged: 1}, report)
+}
+
+func TestCandhisBackfill_Backfill_ResumesFromCheckpoint(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+	mocks, backfill := setupCandhisBackfillAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	checkpoint := mustCreateBackfillCheckpoint(t, 8)
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "10/01/2024", "23:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(&checkpoint, nil)
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+		time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+	mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 11)).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Indexed: 1})).Return(nil)
+
+	report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 7)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1}, report)
+}
+
+func TestCandhisBackfill_Backfill_ResumesAcrossEmptyChunk(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+	mocks, backfill := setupCandhisBackfillAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	checkpoint := mustCreateBackfillCheckpoint(t, 2)
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "09/01/2024", "09:00", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+
+	gomock.InOrder(
+		mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(&checkpoint, nil),
+		mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+			time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)).
+			Return(appmodel.WaveDataTable{}, nil),
+		mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 5)).Return(nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+			time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)).
+			Return(appmodel.WaveDataTable{}, nil),
+		mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 8)).Return(nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+			time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)).
+			Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil),
+		mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+			Return(appmodel.BatchResult{Indexed: 1}, nil),
+		mocks.backfillCheckpoint.EXPECT().Save(gomock.Any(), mustCreateBackfillCheckpoint(t, 11)).Return(nil),
+		mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", "",
+			appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Indexed: 1})).Return(nil),
+	)
+
+	report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 3)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.IngestionReport{
+		RowsSeen: 1, Parsed: 1, Indexed: 1,
+		EmptyPeriods: []appmodel.DayRange{
+			{From: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+			{From: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		},
+	}, report)
+}
+
+func TestCandhisBackfill_Backfill_ChunkOutsideWindowKeepsCheckpoint(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+
+	testCases := map[string]struct {
+		wavesData []model.WaveData
+		errText   string
+	}{
+		"observation after the chunk": {
+			wavesData: []model.WaveData{
+				modeltest.MustCreateWaveData(t, "07/01/2024", "23:30", "0.6", "1.1", "4.7", "8", "32", "15"),
+				modeltest.MustCreateWaveData(t, "08/01/2024", "00:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+			},
+			errText: "failed to gather waves data from 2024-01-01 to 2024-01-07 from candhis web: " +
+				"candhis page layout changed: archive page has an observation of 2024-01-08T00:00:00Z outside the requested period",
+		},
+		"observation before the chunk": {
+			wavesData: []model.WaveData{
+				modeltest.MustCreateWaveData(t, "31/12/2023", "23:30", "0.6", "1.1", "4.7", "8", "32", "15"),
+			},
+			errText: "failed to gather waves data from 2024-01-01 to 2024-01-07 from candhis web: " +
+				"candhis page layout changed: archive page has an observation of 2023-12-31T23:30:00Z outside the requested period",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, backfill := setupCandhisBackfillAndMocks(t)
+			sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+
+			mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(nil, nil)
+			mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+			mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID,
+				lesPierresNoiresURL, backfillFrom, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)).
+				Return(appmodel.WaveDataTable{WaveData: tc.wavesData, RowsSeen: len(tc.wavesData)}, nil)
+			mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", tc.errText,
+				appmodel.ScrapeRunCounts{})).Return(nil)
+
+			report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 7)
+			assert.ErrorIs(t, err, appmodel.ErrLayoutChanged)
+			assert.EqualError(t, err, tc.errText)
+			assert.Equal(t, appmodel.IngestionReport{}, report)
+		})
+	}
+}
+
+func TestCandhisBackfill_Backfill_AlreadyDone(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+	mocks, backfill := setupCandhisBackfillAndMocks(t)
+
+	checkpoint := mustCreateBackfillCheckpoint(t, 11)
+
+	mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(&checkpoint, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", "",
+		appmodel.ScrapeRunCounts{})).Return(nil)
+
+	report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 7)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.IngestionReport{}, report)
+}
+
+func TestCandhisBackfill_Backfill_ChunkFailureKeepsCheckpoint(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+	mocks, backfill := setupCandhisBackfillAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "01/01/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+
+	mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), "02911", backfillFrom, backfillTo).Return(nil, nil)
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().GatherArchivedWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL,
+		backfillFrom, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{}, errors.New("error elasticsearch"))
+
+	errText := "failed to store waves data from 2024-01-01 to 2024-01-07: " +
+		"failed to push wave data to Elasticsearch: error elasticsearch"
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", errText,
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Failed: 1})).Return(nil)
+
+	report, err := backfill.Backfill(context.Background(), campaign, backfillFrom, backfillTo, 7)
+	assert.EqualError(t, err, errText)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Failed: 1}, report)
+}
+
+func TestCandhisBackfill_Backfill_InvalidParameters(t *testing.T) {
+	campaign := testCampaigns(t)[0]
+
+	testCases := map[string]struct {
+		from      time.Time
+		to        time.Time
+		chunkDays int
+		errMsg    string
+	}{
+		"empty chunk": {
+			from: backfillFrom, to: backfillTo, chunkDays: 0,
+			errMsg: "invalid backfill chunk: must be at least one day",
+		},
+		"not whole days": {
+			from: backfillFrom.Add(time.Hour), to: backfillTo, chunkDays: 7,
+			errMsg: "invalid backfill period: from and to must be midnight UTC",
+		},
+		"empty period": {
+			from: backfillTo, to: backfillFrom, chunkDays: 7,
+			errMsg: "invalid backfill checkpoint: from must be before to",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, backfill := setupCandhisBackfillAndMocks(t)
+			mocks.backfillCheckpoint.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, nil).MaxTimes(1)
+			mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperBackfill, "02911", tc.errMsg,
+				appmodel.ScrapeRunCounts{})).Return(nil)
+
+			_, err := backfill.Backfill(context.Background(), campaign, tc.from, tc.to, tc.chunkDays)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func mustCreateBackfillCheckpoint(t *testing.T, doneUntilDay int) appmodel.BackfillCheckpoint {
+	t.Helper()
+
+	checkpoint, err := appmodel.NewBackfillCheckpoint("02911", backfillFrom, backfillTo,
+		time.Date(2024, 1, doneUntilDay, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return checkpoint
+}
+
+type backfillTestingMocks struct {
+	sessionID                  *persistencemock.MockSessionID
+	waveData                   *persistencemock.MockWaveData
+	candhisCampaignsWebScraper *clientmock.MockCandhisCampaignsWebScraper
+	candhisSessionIDWebScraper *clientmock.MockCandhisSessionIDWebScraper
+	backfillCheckpoint         *persistencemock.MockBackfillCheckpoint
+	scrapeRun                  *persistencemock.MockScrapeRun
+}
+
+func setupCandhisBackfillAndMocks(t *testing.T) (backfillTestingMocks, service.CandhisBackfill) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := backfillTestingMocks{
+		sessionID:                  persistencemock.NewMockSessionID(ctrl),
+		waveData:                   persistencemock.NewMockWaveData(ctrl),
+		candhisCampaignsWebScraper: clientmock.NewMockCandhisCampaignsWebScraper(ctrl),
+		candhisSessionIDWebScraper: clientmock.NewMockCandhisSessionIDWebScraper(ctrl),
+		backfillCheckpoint:         persistencemock.NewMockBackfillCheckpoint(ctrl),
+		scrapeRun:                  persistencemock.NewMockScrapeRun(ctrl),
+	}
+
+	return mocks, service.NewCandhisBackfill(mocks.sessionID, mocks.waveData, mocks.candhisCampaignsWebScraper,
+		mocks.candhisSessionIDWebScraper, mocks.backfillCheckpoint, mocks.scrapeRun,
+		modeltest.MustCreateQualityControl(t, modeltest.QCConfig()))
+}
@@ -2,56 +2,228 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
 )
 
 type CandhisCampaignsScraper interface {
-	FetchAndStoreWaveData(ctx context.Context) error
+	FetchAndStoreWaveData(ctx context.Context) ([]CampaignScrapeResult, error)
+}
+
+// CampaignScrapeResult is the outcome of scraping a single campaign.
+type CampaignScrapeResult struct {
+	Campaign appmodel.Campaign
+	Report   appmodel.IngestionReport
+	Err      error
+}
+
+// TotalIngestionReport sums the reports of every scraped campaign of a run.
+func TotalIngestionReport(results []CampaignScrapeResult) appmodel.IngestionReport {
+	var total appmodel.IngestionReport
+	for _, result := range results {
+		total = total.Add(result.Report)
+	}
+	return total
 }
 
 type candhisCampaignsScraper struct {
 	sessionID                        repository.SessionID
 	waveData                         repository.WaveData
 	candhisCampaignsWebScraperClient repository.CandhisCampaignsWebScraper
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+	scrapeRun                        repository.ScrapeRun
+	qualityControl                   model.QualityControl
+	campaigns                        []appmodel.Campaign
 }
 
 func NewCandhisCampaignsScraper(
 	sessionIDRepo repository.SessionID,
 	waveDataRepo repository.WaveData,
 	candhisCampaignsWebScraperClient repository.CandhisCampaignsWebScraper,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	scrapeRunRepo repository.ScrapeRun,
+	qualityControl model.QualityControl,
+	campaigns []appmodel.Campaign,
 ) *candhisCampaignsScraper {
 	return &candhisCampaignsScraper{
 		sessionIDRepo,
 		waveDataRepo,
 		candhisCampaignsWebScraperClient,
+		candhisSessionIDWebScraperClient,
+		scrapeRunRepo,
+		qualityControl,
+		campaigns,
 	}
 }
 
-const (
-	candhisURL                         = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ=="
-	elasticSearchIndexLesPierresNoires = "les-pierres-noires"
-)
+// FetchAndStoreWaveData scrapes every enabled campaign. A failing campaign does not prevent the others from being
+// scraped, its error is reported in its result and the returned error counts the failed campaigns. Every campaign
+// run is recorded in the scrape run history. When Candhis rejects the stored session ID, a new one is fetched and
+// stored, and the campaign is scraped again.
+func (s *candhisCampaignsScraper) FetchAndStoreWaveData(ctx context.Context) ([]CampaignScrapeResult, error) {
+	startedAt := time.Now().UTC()
 
-func (s *candhisCampaignsScraper) FetchAndStoreWaveData(ctx context.Context) error {
-	candhisSessionID, err := s.sessionID.Get(ctx)
+	session, err := newCandhisSession(ctx, s.sessionID, s.candhisSessionIDWebScraperClient)
 	if err != nil {
-		return fmt.Errorf("failed to get session ID from db: %w", err)
+		recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperCampaigns, "", startedAt, err, appmodel.ScrapeRunCounts{})
+		return nil, errors.Join(err, recordErr)
 	}
 
-	waveDataList, err := s.candhisCampaignsWebScraperClient.GatherWavesDataFromWebTable(
-		*candhisSessionID, candhisURL)
+	var results []CampaignScrapeResult
+	var recordErrs []error
+	var campaignErrs []error
+	for _, campaign := range s.campaigns {
+		if !campaign.Enabled() {
+			continue
+		}
+
+		campaignStartedAt := time.Now().UTC()
+		report, err := s.fetchAndStoreCampaign(ctx, session, campaign)
+		if err != nil {
+			campaignErrs = append(campaignErrs, err)
+		}
+		results = append(results, CampaignScrapeResult{Campaign: campaign, Report: report, Err: err})
+
+		recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperCampaigns, campaign.BuoyID(), campaignStartedAt,
+			err, appmodel.NewScrapeRunCounts(report))
+		if recordErr != nil {
+			recordErrs = append(recordErrs, recordErr)
+		}
+	}
+
+	if len(campaignErrs) > 0 {
+		err = &campaignsFailedError{errs: campaignErrs, total: len(results)}
+	}
+
+	return results, errors.Join(append([]error{err}, recordErrs...)...)
+}
+
+// campaignsFailedError counts the failed campaigns of a run, and wraps their errors so that the run can be classified
+// by them.
+type campaignsFailedError struct {
+	errs  []error
+	total int
+}
+
+func (e *campaignsFailedError) Error() string {
+	return fmt.Sprintf("failed to scrape %d of %d campaigns", len(e.errs), e.total)
+}
+
+func (e *campaignsFailedError) Unwrap() []error {
+	return e.errs
+}
+
+func (s *candhisCampaignsScraper) fetchAndStoreCampaign(
+	ctx context.Context,
+	session *candhisSession,
+	campaign appmodel.Campaign,
+) (appmodel.IngestionReport, error) {
+	table, err := gather(ctx, session, func(candhisSessionID appmodel.CandhisSessionID) (appmodel.WaveDataTable, error) {
+		return s.candhisCampaignsWebScraperClient.GatherWavesDataFromWebTable(ctx, candhisSessionID, campaign.CandhisURL())
+	})
 	if err != nil {
-		return fmt.Errorf("failed to gather waves data from candhis web: %w", err)
+		return appmodel.IngestionReport{}, fmt.Errorf("failed to gather waves data from candhis web: %w", err)
 	}
 
-	for _, waveData := range waveDataList {
-		err := s.waveData.Add(ctx, waveData, elasticSearchIndexLesPierresNoires)
-		if err != nil {
-			return fmt.Errorf("failed to push wave data to Elasticsearch: %w", err)
+	table, err = compareLayoutFingerprint(ctx, s.scrapeRun, campaign.BuoyID(), table)
+	if err != nil {
+		return appmodel.NewIngestionReport(table, appmodel.BatchResult{}), err
+	}
+
+	return storeWaveDataTable(ctx, s.waveData, s.qualityControl, table, campaign.IndexName())
+}
+
+// compareLayoutFingerprint flags the layout of a campaign table as changed when its fingerprint differs from the one
+// recorded by the previous run that parsed a table of the campaign.
+func compareLayoutFingerprint(
+	ctx context.Context,
+	scrapeRunRepo repository.ScrapeRun,
+	buoyID string,
+	table appmodel.WaveDataTable,
+) (appmodel.WaveDataTable, error) {
+	if table.LayoutFingerprint == "" {
+		return table, nil
+	}
+
+	previous, err := scrapeRunRepo.LastLayoutFingerprint(ctx, buoyID, appmodel.ScraperCampaigns, appmodel.ScraperBackfill)
+	if err != nil {
+		return table, fmt.Errorf("failed to get previous layout fingerprint: %w", err)
+	}
+	if previous != "" && previous != table.LayoutFingerprint {
+		table.LayoutChanged = true
+	}
+
+	return table, nil
+}
+
+// storeWaveDataTable flags the parsed observations of a table with the quality control tests, indexes them and
+// reports the ingestion of the whole table.
+func storeWaveDataTable(
+	ctx context.Context,
+	waveDataRepo repository.WaveData,
+	qualityControl model.QualityControl,
+	table appmodel.WaveDataTable,
+	indexName string,
+) (appmodel.IngestionReport, error) {
+	if len(table.WaveData) == 0 {
+		return appmodel.NewIngestionReport(table, appmodel.BatchResult{}), nil
+	}
+
+	history, err := qualityControlHistory(ctx, waveDataRepo, qualityControl, table.WaveData, indexName)
+	if err != nil {
+		report := appmodel.NewIngestionReport(table, appmodel.BatchResult{})
+		report.Failed = report.Parsed
+		return report, fmt.Errorf("failed to get previous observations for quality control: %w", err)
+	}
+	waveData := qualityControl.Check(table.WaveData, history)
+
+	batch, err := waveDataRepo.AddBatch(ctx, waveData, indexName)
+	if err != nil {
+		report := appmodel.NewIngestionReport(table, appmodel.BatchResult{})
+		report.Failed = report.Parsed
+		return report, fmt.Errorf("failed to push wave data to Elasticsearch: %w", err)
+	}
+
+	report := appmodel.NewIngestionReport(table, batch)
+	if len(batch.Failures) > 0 {
+		return report, fmt.Errorf("failed to push %d of %d wave data to Elasticsearch, first failure at %s: %s",
+			len(batch.Failures), len(table.WaveData), batch.Failures[0].Timestamp.Format(time.RFC3339), batch.Failures[0].Reason)
+	}
+
+	return report, nil
+}
+
+// qualityControlHistory lists the stored observations preceding the oldest observation of a batch.
+func qualityControlHistory(
+	ctx context.Context,
+	waveDataRepo repository.WaveData,
+	qualityControl model.QualityControl,
+	waveData []model.WaveData,
+	indexName string,
+) ([]model.WaveData, error) {
+	oldest := waveData[0].Timestamp()
+	for _, w := range waveData[1:] {
+		if w.Timestamp().Before(oldest) {
+			oldest = w.Timestamp()
 		}
 	}
 
-	return nil
+	// Timestamps are to the minute and the query bounds are inclusive.
+	to := oldest.Add(-time.Second)
+	query, err := appmodel.NewWaveDataQuery(nil, &to, "", qualityControl.HistorySize(), appmodel.SortOrderDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := waveDataRepo.List(ctx, indexName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.WaveData, nil
 }
@@ -3,12 +3,16 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	clientmock "github.com/tul1/candhis_api/internal/application/repository/client_mock"
 	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
 	"github.com/tul1/candhis_api/internal/application/service"
 	"github.com/tul1/candhis_api/internal/domain/model"
@@ -16,51 +20,287 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+const (
+	lesPierresNoiresURL = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ=="
+	belleIleURL         = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wNTYwMg=="
+)
+
 func TestCandhisCampaignsScraper_FetchAndStoreWaveData_Success(t *testing.T) {
-	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t)
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns)
 
 	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
 	wavesData := []model.WaveData{
 		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
 		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
 	}
+	belleIleWaveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "1.2", "2.1", "6.7", "270", "25", "16")
+
+	rejected := []appmodel.RejectedRow{{Row: 2, Reason: "invalid value for maxHeight"}}
 
 	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
 	mocks.candhisCampaignsWebScraper.EXPECT().
-		GatherWavesDataFromWebTable(sessionID, "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==").
-		Return(wavesData, nil)
-	mocks.waveData.EXPECT().Add(gomock.Any(), wavesData[0], "les-pierres-noires").Return(nil)
-	mocks.waveData.EXPECT().Add(gomock.Any(), wavesData[1], "les-pierres-noires").Return(nil)
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 3, Rejected: rejected}, nil)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{Indexed: 1, Unchanged: 1}, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, belleIleURL).
+		Return(appmodel.WaveDataTable{WaveData: []model.WaveData{belleIleWaveData}, RowsSeen: 1}, nil)
+	checkedBelleIleWaveData := expectQualityControlHistory(t, mocks.waveData, "belle-ile",
+		[]model.WaveData{belleIleWaveData})
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedBelleIleWaveData, "belle-ile").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1})).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "05602", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Indexed: 1})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{
+			Campaign: campaigns[0],
+			Report:   appmodel.IngestionReport{RowsSeen: 3, Parsed: 2, Rejected: rejected, Indexed: 1, Unchanged: 1},
+		},
+		{
+			Campaign: campaigns[2],
+			Report:   appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1},
+		},
+	}, results)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 4, Parsed: 3, Rejected: rejected, Indexed: 2, Unchanged: 1},
+		service.TotalIngestionReport(results))
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_LayoutFingerprint(t *testing.T) {
+	testCases := map[string]struct {
+		previousFingerprint string
+		layoutChanged       bool
+	}{
+		"first run":        {previousFingerprint: "", layoutChanged: false},
+		"same layout":      {previousFingerprint: "6c1b2f0e9d8a7b3c", layoutChanged: false},
+		"different layout": {previousFingerprint: "0f4e5d6c7b8a9123", layoutChanged: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			campaigns := testCampaigns(t)
+			mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
 
-	err := candhisScraper.FetchAndStoreWaveData(context.Background())
-	assert.NoError(t, err)
+			sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+			wavesData := []model.WaveData{
+				modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+			}
+
+			mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+			mocks.candhisCampaignsWebScraper.EXPECT().
+				GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+				Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1, LayoutFingerprint: "6c1b2f0e9d8a7b3c"}, nil)
+			mocks.scrapeRun.EXPECT().LastLayoutFingerprint(gomock.Any(), "02911",
+				appmodel.ScraperCampaigns, appmodel.ScraperBackfill).Return(tc.previousFingerprint, nil)
+			checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+			mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+				Return(appmodel.BatchResult{Indexed: 1}, nil)
+			mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", "",
+				appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Indexed: 1, LayoutFingerprint: "6c1b2f0e9d8a7b3c"})).
+				Return(nil)
+
+			results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1,
+				LayoutFingerprint: "6c1b2f0e9d8a7b3c", LayoutChanged: tc.layoutChanged}, results[0].Report)
+		})
+	}
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_LayoutFingerprintFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1, LayoutFingerprint: "6c1b2f0e9d8a7b3c"}, nil)
+	mocks.scrapeRun.EXPECT().LastLayoutFingerprint(gomock.Any(), "02911", gomock.Any()).
+		Return("", fmt.Errorf("error db: %w", appmodel.ErrStorageUnavailable))
+
+	errText := "failed to get previous layout fingerprint: error db: storage unavailable"
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", errText,
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, LayoutFingerprint: "6c1b2f0e9d8a7b3c"})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, appmodel.ErrStorageUnavailable)
 }
 
 func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionIDFailure(t *testing.T) {
-	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, testCampaigns(t))
+
+	dbErr := errors.New("error db")
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(nil, dbErr)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "",
+		"failed to get session ID from db: error db", appmodel.ScrapeRunCounts{})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+	assert.Nil(t, results)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_NoValidSessionID(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "bootstrap-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+
+	gomock.InOrder(
+		mocks.sessionID.EXPECT().Get(gomock.Any()).Return(nil, appmodel.ErrNoValidCandhisSession),
+		mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(sessionID, nil),
+		mocks.sessionID.EXPECT().
+			Add(gomock.Any(), appmodeltest.MustCreateCandhisSession(t, sessionID, appmodel.CandhisSessionSourceBootstrap)).
+			Return(nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().
+			GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+			Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil),
+	)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{Campaign: campaigns[0], Report: appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1}},
+	}, results)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionIDBootstrapFailure(t *testing.T) {
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, testCampaigns(t))
+
+	chromeErr := errors.New("error chrome")
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(nil, appmodel.ErrNoValidCandhisSession)
+	mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).
+		Return(appmodel.CandhisSessionID{}, chromeErr)
 
-	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(nil, errors.New("error db"))
+	errText := "no valid session ID in db, and failed to bootstrap one: failed to get session ID from candhis web: " +
+		"error chrome"
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "", errText,
+		appmodel.ScrapeRunCounts{})).Return(nil)
 
-	err := candhisScraper.FetchAndStoreWaveData(context.Background())
-	assert.EqualError(t, err, "failed to get session ID from db: error db")
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, chromeErr)
+	assert.Nil(t, results)
 }
 
 func TestCandhisCampaignsScraper_FetchAndStoreWaveData_GatherWavesDataFailure(t *testing.T) {
-	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t)
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns)
 
 	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	belleIleWaveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "1.2", "2.1", "6.7", "270", "25", "16")
+	webErr := errors.New("error web")
 
 	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
 	mocks.candhisCampaignsWebScraper.EXPECT().
-		GatherWavesDataFromWebTable(sessionID, "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==").
-		Return(nil, errors.New("error web"))
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, webErr)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, belleIleURL).
+		Return(appmodel.WaveDataTable{WaveData: []model.WaveData{belleIleWaveData}, RowsSeen: 1}, nil)
+	checkedBelleIleWaveData := expectQualityControlHistory(t, mocks.waveData, "belle-ile",
+		[]model.WaveData{belleIleWaveData})
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedBelleIleWaveData, "belle-ile").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911",
+		"failed to gather waves data from candhis web: error web", appmodel.ScrapeRunCounts{})).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "05602", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Indexed: 1})).Return(nil)
 
-	err := candhisScraper.FetchAndStoreWaveData(context.Background())
-	assert.EqualError(t, err, "failed to gather waves data from candhis web: error web")
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, webErr)
+	require.Len(t, results, 2)
+	assert.ErrorIs(t, results[0].Err, webErr)
+	assert.Equal(t, service.CampaignScrapeResult{
+		Campaign: campaigns[2],
+		Report:   appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1},
+	}, results[1])
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_FailedCampaignsErrorClasses(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	belleIleWaveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "1.2", "2.1", "6.7", "270", "25", "16")
+	upstreamErr := fmt.Errorf("%w: connection refused", appmodel.ErrUpstreamUnavailable)
+	storageErr := fmt.Errorf("%w: connection refused", appmodel.ErrStorageUnavailable)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, upstreamErr)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, belleIleURL).
+		Return(appmodel.WaveDataTable{WaveData: []model.WaveData{belleIleWaveData}, RowsSeen: 1}, nil)
+	checkedBelleIleWaveData := expectQualityControlHistory(t, mocks.waveData, "belle-ile",
+		[]model.WaveData{belleIleWaveData})
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedBelleIleWaveData, "belle-ile").
+		Return(appmodel.BatchResult{}, storageErr)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	_, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, appmodel.ErrUpstreamUnavailable)
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+	assert.Equal(t, appmodel.ExitCodeUpstreamUnavailable, appmodel.ExitCode(err))
 }
 
 func TestCandhisCampaignsScraper_FetchAndStoreWaveData_AddWaveDataFailure(t *testing.T) {
-	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t)
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 2}, nil)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	esErr := errors.New("error elasticsearch")
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{}, esErr)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911",
+		"failed to push wave data to Elasticsearch: error elasticsearch",
+		appmodel.ScrapeRunCounts{RowsSeen: 2, Parsed: 2, Failed: 2})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, esErr)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, esErr)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 2, Parsed: 2, Failed: 2}, results[0].Report)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_AddWaveDataPartialFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
 
 	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
 	wavesData := []model.WaveData{
@@ -70,31 +310,344 @@ func TestCandhisCampaignsScraper_FetchAndStoreWaveData_AddWaveDataFailure(t *tes
 
 	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
 	mocks.candhisCampaignsWebScraper.EXPECT().
-		GatherWavesDataFromWebTable(sessionID, "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==").
-		Return(wavesData, nil)
-	mocks.waveData.EXPECT().Add(gomock.Any(), wavesData[0], "les-pierres-noires").Return(errors.New("error elasticsearch"))
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 2}, nil)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{
+			Indexed:  1,
+			Failures: []appmodel.IndexFailure{{Timestamp: wavesData[1].Timestamp(), Reason: "mapper_parsing_exception"}},
+		}, nil)
 
-	err := candhisScraper.FetchAndStoreWaveData(context.Background())
-	assert.EqualError(t, err, "failed to push wave data to Elasticsearch: error elasticsearch")
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911",
+		"failed to push 1 of 2 wave data to Elasticsearch, first failure at 2024-09-17T08:30:00Z: mapper_parsing_exception",
+		appmodel.ScrapeRunCounts{RowsSeen: 2, Parsed: 2, Indexed: 1, Failed: 1})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, results[0].Err)
+	require.Len(t, results, 1)
+	assert.ErrorContains(t, results[0].Err, "first failure at 2024-09-17T08:30:00Z: mapper_parsing_exception")
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 2, Parsed: 2, Indexed: 1, Failed: 1}, results[0].Report)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_QualityControl(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "3.6", "5.1", "8.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.4", "4.8", "4", "47", "15"),
+	}
+	previous := modeltest.MustCreateWaveData(t, "17/09/2024", "08:00", "0.5", "0.9", "4.8", "4", "47", "15")
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 2}, nil)
+	to := time.Date(2024, 9, 17, 8, 29, 59, 0, time.UTC)
+	historyQuery, err := appmodel.NewWaveDataQuery(nil, &to, "", 11, appmodel.SortOrderDesc)
+	require.NoError(t, err)
+	mocks.waveData.EXPECT().List(gomock.Any(), "les-pierres-noires", historyQuery).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{previous}}, nil)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), []model.WaveData{
+		wavesData[0].WithQCFlags(model.QCFlags{
+			GrossRange: model.QCFlagPass, Consistency: model.QCFlagPass, Spike: model.QCFlagSuspect, FlatLine: model.QCFlagPass,
+		}),
+		wavesData[1].WithQCFlags(model.QCFlags{
+			GrossRange: model.QCFlagPass, Consistency: model.QCFlagFail, Spike: model.QCFlagPass, FlatLine: model.QCFlagPass,
+		}),
+	}, "les-pierres-noires").Return(appmodel.BatchResult{Indexed: 2}, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	_, err = candhisScraper.FetchAndStoreWaveData(context.Background())
+	require.NoError(t, err)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_QualityControlHistoryFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil)
+	esErr := errors.New("error elasticsearch")
+	mocks.waveData.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+		Return(appmodel.WaveDataPage{}, esErr)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911",
+		"failed to get previous observations for quality control: error elasticsearch",
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Parsed: 1, Failed: 1})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, esErr)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, esErr)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_EmptyTable(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	rejected := []appmodel.RejectedRow{{Row: 1, Reason: "expected 8 cells, but got 7"}}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{RowsSeen: 1, Rejected: rejected}, nil)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 1, Rejected: 1})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{Campaign: campaigns[0], Report: appmodel.IngestionReport{RowsSeen: 1, Rejected: rejected}},
+	}, results)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_RecordScrapeRunFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), sessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, nil)
+	dbErr := errors.New("error db")
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(dbErr)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, []service.CampaignScrapeResult{{Campaign: campaigns[0]}}, results)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionExpired(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns)
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	renewedSessionID := appmodeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	renewedSession := appmodeltest.MustCreateCandhisSession(t, renewedSessionID, appmodel.CandhisSessionSourceRenewal)
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+	belleIleWaveData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "1.2", "2.1", "6.7", "270", "25", "16"),
+	}
+	sessionExpiredErr := &appmodel.MissingContentError{Detail: "no wave data table in page"}
+
+	gomock.InOrder(
+		mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().
+			GatherWavesDataFromWebTable(gomock.Any(), expiredSessionID, lesPierresNoiresURL).
+			Return(appmodel.WaveDataTable{}, sessionExpiredErr),
+		mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil),
+		mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(renewedSessionID, nil),
+		mocks.sessionID.EXPECT().Add(gomock.Any(), renewedSession).Return(nil),
+		mocks.candhisCampaignsWebScraper.EXPECT().
+			GatherWavesDataFromWebTable(gomock.Any(), renewedSessionID, lesPierresNoiresURL).
+			Return(appmodel.WaveDataTable{WaveData: wavesData, RowsSeen: 1}, nil),
+	)
+	checkedWavesData := expectQualityControlHistory(t, mocks.waveData, "les-pierres-noires", wavesData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedWavesData, "les-pierres-noires").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), renewedSessionID, belleIleURL).
+		Return(appmodel.WaveDataTable{WaveData: belleIleWaveData, RowsSeen: 1}, nil)
+	checkedBelleIleWaveData := expectQualityControlHistory(t, mocks.waveData, "belle-ile", belleIleWaveData)
+	mocks.waveData.EXPECT().AddBatch(gomock.Any(), checkedBelleIleWaveData, "belle-ile").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{Campaign: campaigns[0], Report: appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1}},
+		{Campaign: campaigns[2], Report: appmodel.IngestionReport{RowsSeen: 1, Parsed: 1, Indexed: 1}},
+	}, results)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionRenewalFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	sessionExpiredErr := &appmodel.MissingContentError{Detail: "no wave data table in page"}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), expiredSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, sessionExpiredErr)
+	mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil)
+	chromeErr := errors.New("error chrome")
+	mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).
+		Return(appmodel.CandhisSessionID{}, chromeErr)
+
+	errText := "failed to gather waves data from candhis web: candhis session expired: no wave data table in page, " +
+		"and failed to renew it: failed to get session ID from candhis web: error chrome"
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", errText,
+		appmodel.ScrapeRunCounts{})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, appmodel.ErrSessionExpired)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, appmodel.ErrSessionExpired)
+	assert.ErrorIs(t, results[0].Err, chromeErr)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionInvalidationFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	sessionExpiredErr := &appmodel.MissingContentError{Detail: "no wave data table in page"}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), expiredSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, sessionExpiredErr)
+	dbErr := errors.New("error db")
+	mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(dbErr)
+
+	errText := "failed to gather waves data from candhis web: candhis session expired: no wave data table in page, " +
+		"and failed to renew it: failed to invalidate session ID in database: error db"
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCampaigns, "02911", errText,
+		appmodel.ScrapeRunCounts{})).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, appmodel.ErrSessionExpired)
+	assert.ErrorIs(t, results[0].Err, dbErr)
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_SessionRenewedOncePerRun(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns)
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	renewedSessionID := appmodeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	renewedSession := appmodeltest.MustCreateCandhisSession(t, renewedSessionID, appmodel.CandhisSessionSourceRenewal)
+	sessionExpiredErr := &appmodel.MissingContentError{Detail: "no wave data table in page"}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), expiredSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, sessionExpiredErr)
+	mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil)
+	mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(renewedSessionID, nil)
+	mocks.sessionID.EXPECT().Add(gomock.Any(), renewedSession).Return(nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), renewedSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, sessionExpiredErr)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), renewedSessionID, belleIleURL).
+		Return(appmodel.WaveDataTable{}, sessionExpiredErr)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, appmodel.ErrLayoutChanged)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, appmodel.ErrLayoutChanged)
+		assert.NotErrorIs(t, result.Err, appmodel.ErrSessionExpired)
+	}
+}
+
+func TestCandhisCampaignsScraper_FetchAndStoreWaveData_RenewedSessionRejected(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, candhisScraper := setupCandhisCampaignsScraperAndMocks(t, campaigns[:1])
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	renewedSessionID := appmodeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	renewedSession := appmodeltest.MustCreateCandhisSession(t, renewedSessionID, appmodel.CandhisSessionSourceRenewal)
+	redirectedErr := fmt.Errorf("%w: redirected to https://candhis.cerema.fr/", appmodel.ErrSessionExpired)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), expiredSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, redirectedErr)
+	mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil)
+	mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(renewedSessionID, nil)
+	mocks.sessionID.EXPECT().Add(gomock.Any(), renewedSession).Return(nil)
+	mocks.candhisCampaignsWebScraper.EXPECT().
+		GatherWavesDataFromWebTable(gomock.Any(), renewedSessionID, lesPierresNoiresURL).
+		Return(appmodel.WaveDataTable{}, redirectedErr)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	results, err := candhisScraper.FetchAndStoreWaveData(context.Background())
+	assert.ErrorIs(t, err, appmodel.ErrSessionExpired)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, appmodel.ErrSessionExpired)
+	assert.NotErrorIs(t, results[0].Err, appmodel.ErrLayoutChanged)
+}
+
+func testCampaigns(t *testing.T) []appmodel.Campaign {
+	t.Helper()
+
+	return []appmodel.Campaign{
+		appmodeltest.MustCreateCampaign(t, "02911", "Les Pierres Noires", lesPierresNoiresURL, "les-pierres-noires", true),
+		appmodeltest.MustCreateCampaign(t, "02204", "Disabled Buoy",
+			"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjIwNA==", "disabled-buoy", false),
+		appmodeltest.MustCreateCampaign(t, "05602", "Belle Ile", belleIleURL, "belle-ile", true),
+	}
 }
 
 type campaignsTestingMocks struct {
 	sessionID                  *persistencemock.MockSessionID
 	waveData                   *persistencemock.MockWaveData
 	candhisCampaignsWebScraper *clientmock.MockCandhisCampaignsWebScraper
+	candhisSessionIDWebScraper *clientmock.MockCandhisSessionIDWebScraper
+	scrapeRun                  *persistencemock.MockScrapeRun
 }
 
-func setupCandhisCampaignsScraperAndMocks(t *testing.T) (campaignsTestingMocks, service.CandhisCampaignsScraper) {
+func setupCandhisCampaignsScraperAndMocks(
+	t *testing.T,
+	campaigns []appmodel.Campaign,
+) (campaignsTestingMocks, service.CandhisCampaignsScraper) {
 	t.Helper()
 
 	ctrl := gomock.NewController(t)
 	mockSessionIDRepo := persistencemock.NewMockSessionID(ctrl)
 	mockWaveDataRepo := persistencemock.NewMockWaveData(ctrl)
 	mockCandhisCampaignsWebScraperClient := clientmock.NewMockCandhisCampaignsWebScraper(ctrl)
+	mockCandhisSessionIDWebScraperClient := clientmock.NewMockCandhisSessionIDWebScraper(ctrl)
+	mockScrapeRunRepo := persistencemock.NewMockScrapeRun(ctrl)
 
 	return campaignsTestingMocks{
 		sessionID:                  mockSessionIDRepo,
 		waveData:                   mockWaveDataRepo,
 		candhisCampaignsWebScraper: mockCandhisCampaignsWebScraperClient,
-	}, service.NewCandhisCampaignsScraper(mockSessionIDRepo, mockWaveDataRepo, mockCandhisCampaignsWebScraperClient)
+		candhisSessionIDWebScraper: mockCandhisSessionIDWebScraperClient,
+		scrapeRun:                  mockScrapeRunRepo,
+	}, service.NewCandhisCampaignsScraper(
+		mockSessionIDRepo, mockWaveDataRepo, mockCandhisCampaignsWebScraperClient, mockCandhisSessionIDWebScraperClient,
+		mockScrapeRunRepo, modeltest.MustCreateQualityControl(t, modeltest.QCConfig()), campaigns)
+}
+
+// expectQualityControlHistory expects the lookup of the observations stored before a batch and returns the batch as
+// flagged by the quality control.
+func expectQualityControlHistory(
+	t *testing.T,
+	waveDataRepo *persistencemock.MockWaveData,
+	indexName string,
+	waveData []model.WaveData,
+	history ...model.WaveData,
+) []model.WaveData {
+	t.Helper()
+
+	waveDataRepo.EXPECT().List(gomock.Any(), indexName, gomock.Any()).
+		Return(appmodel.WaveDataPage{WaveData: history}, nil)
+
+	return modeltest.MustCreateQualityControl(t, modeltest.QCConfig()).Check(waveData, history)
 }
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+)
+
+type CandhisCatalogueScraper interface {
+	FetchAndStoreStations(ctx context.Context) (appmodel.StationCatalogue, error)
+}
+
+type candhisCatalogueScraper struct {
+	sessionID                        repository.SessionID
+	station                          repository.Station
+	candhisCatalogueWebScraperClient repository.CandhisCatalogueWebScraper
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+	scrapeRun                        repository.ScrapeRun
+	catalogueURL                     string
+}
+
+func NewCandhisCatalogueScraper(
+	sessionIDRepo repository.SessionID,
+	stationRepo repository.Station,
+	candhisCatalogueWebScraperClient repository.CandhisCatalogueWebScraper,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	scrapeRunRepo repository.ScrapeRun,
+	catalogueURL string,
+) *candhisCatalogueScraper {
+	return &candhisCatalogueScraper{
+		sessionIDRepo,
+		stationRepo,
+		candhisCatalogueWebScraperClient,
+		candhisSessionIDWebScraperClient,
+		scrapeRunRepo,
+		catalogueURL,
+	}
+}
+
+// FetchAndStoreStations scrapes the catalogue of Candhis campaigns and stores the metadata of its stations. Stations
+// that left the catalogue are kept as they were last seen. The run is recorded in the scrape run history, with the
+// stored stations counted as indexed.
+func (s *candhisCatalogueScraper) FetchAndStoreStations(ctx context.Context) (appmodel.StationCatalogue, error) {
+	startedAt := time.Now().UTC()
+
+	catalogue, counts, err := s.fetchAndStoreStations(ctx)
+	recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperCatalogue, "", startedAt, err, counts)
+
+	return catalogue, errors.Join(err, recordErr)
+}
+
+func (s *candhisCatalogueScraper) fetchAndStoreStations(
+	ctx context.Context,
+) (appmodel.StationCatalogue, appmodel.ScrapeRunCounts, error) {
+	session, err := newCandhisSession(ctx, s.sessionID, s.candhisSessionIDWebScraperClient)
+	if err != nil {
+		return appmodel.StationCatalogue{}, appmodel.ScrapeRunCounts{}, err
+	}
+
+	catalogue, err := gather(ctx, session, func(candhisSessionID appmodel.CandhisSessionID) (appmodel.StationCatalogue, error) {
+		return s.candhisCatalogueWebScraperClient.GatherStationsFromWebCatalogue(ctx, candhisSessionID, s.catalogueURL)
+	})
+	if err != nil {
+		return appmodel.StationCatalogue{}, appmodel.ScrapeRunCounts{},
+			fmt.Errorf("failed to gather stations from candhis web: %w", err)
+	}
+
+	counts := appmodel.ScrapeRunCounts{
+		RowsSeen: catalogue.RowsSeen,
+		Parsed:   len(catalogue.Stations),
+		Rejected: len(catalogue.Rejected),
+	}
+	if len(catalogue.Stations) == 0 {
+		return catalogue, counts, nil
+	}
+
+	if err := s.station.UpsertBatch(ctx, catalogue.Stations); err != nil {
+		counts.Failed = counts.Parsed
+		return catalogue, counts, fmt.Errorf("failed to store stations in database: %w", err)
+	}
+	counts.Indexed = counts.Parsed
+
+	return catalogue, counts, nil
+}
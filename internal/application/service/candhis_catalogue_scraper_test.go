@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	clientmock "github.com/tul1/candhis_api/internal/application/repository/client_mock"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"go.uber.org/mock/gomock"
+)
+
+const catalogueURL = "https://candhis.cerema.fr/_public_/campagnes.php"
+
+func TestCandhisCatalogueScraper_FetchAndStoreStations_Success(t *testing.T) {
+	mocks, catalogueScraper := setupCandhisCatalogueScraperAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	catalogue := testStationCatalogue(t)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCatalogueWebScraper.EXPECT().GatherStationsFromWebCatalogue(gomock.Any(), sessionID, catalogueURL).Return(catalogue, nil)
+	mocks.station.EXPECT().UpsertBatch(gomock.Any(), catalogue.Stations).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCatalogue, "", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 2})).Return(nil)
+
+	result, err := catalogueScraper.FetchAndStoreStations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, catalogue, result)
+}
+
+func TestCandhisCatalogueScraper_FetchAndStoreStations_SessionRenewed(t *testing.T) {
+	mocks, catalogueScraper := setupCandhisCatalogueScraperAndMocks(t)
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	renewedSessionID := appmodeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	renewedSession := appmodeltest.MustCreateCandhisSession(t, renewedSessionID, appmodel.CandhisSessionSourceRenewal)
+	catalogue := testStationCatalogue(t)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil)
+	mocks.candhisCatalogueWebScraper.EXPECT().GatherStationsFromWebCatalogue(gomock.Any(), expiredSessionID, catalogueURL).
+		Return(appmodel.StationCatalogue{}, &appmodel.MissingContentError{Detail: "no station catalogue table in page"})
+	mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil)
+	mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(renewedSessionID, nil)
+	mocks.sessionID.EXPECT().Add(gomock.Any(), renewedSession).Return(nil)
+	mocks.candhisCatalogueWebScraper.EXPECT().GatherStationsFromWebCatalogue(gomock.Any(), renewedSessionID, catalogueURL).
+		Return(catalogue, nil)
+	mocks.station.EXPECT().UpsertBatch(gomock.Any(), catalogue.Stations).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := catalogueScraper.FetchAndStoreStations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, catalogue, result)
+}
+
+func TestCandhisCatalogueScraper_FetchAndStoreStations_GatherFailure(t *testing.T) {
+	mocks, catalogueScraper := setupCandhisCatalogueScraperAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+
+	webErr := errors.New("error web")
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCatalogueWebScraper.EXPECT().GatherStationsFromWebCatalogue(gomock.Any(), sessionID, catalogueURL).
+		Return(appmodel.StationCatalogue{}, webErr)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCatalogue, "",
+		"failed to gather stations from candhis web: error web", appmodel.ScrapeRunCounts{})).Return(nil)
+
+	_, err := catalogueScraper.FetchAndStoreStations(context.Background())
+	assert.ErrorIs(t, err, webErr)
+}
+
+func TestCandhisCatalogueScraper_FetchAndStoreStations_StoreFailure(t *testing.T) {
+	mocks, catalogueScraper := setupCandhisCatalogueScraperAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	catalogue := testStationCatalogue(t)
+	dbErr := errors.New("error db")
+	historyErr := errors.New("error history")
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisCatalogueWebScraper.EXPECT().GatherStationsFromWebCatalogue(gomock.Any(), sessionID, catalogueURL).Return(catalogue, nil)
+	mocks.station.EXPECT().UpsertBatch(gomock.Any(), catalogue.Stations).Return(dbErr)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperCatalogue, "",
+		"failed to store stations in database: error db",
+		appmodel.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Failed: 2})).Return(historyErr)
+
+	_, err := catalogueScraper.FetchAndStoreStations(context.Background())
+	assert.ErrorIs(t, err, dbErr)
+	assert.ErrorIs(t, err, historyErr)
+}
+
+func testStationCatalogue(t *testing.T) appmodel.StationCatalogue {
+	t.Helper()
+
+	return appmodel.StationCatalogue{
+		Stations: []appmodel.Station{
+			appmodeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, nil, "Cerema", true),
+			appmodeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "Cerema", false),
+		},
+		RowsSeen: 3,
+		Rejected: []appmodel.RejectedRow{{Row: 2, Reason: "no campaign link in row"}},
+	}
+}
+
+type catalogueTestingMocks struct {
+	sessionID                  *persistencemock.MockSessionID
+	station                    *persistencemock.MockStation
+	candhisCatalogueWebScraper *clientmock.MockCandhisCatalogueWebScraper
+	candhisSessionIDWebScraper *clientmock.MockCandhisSessionIDWebScraper
+	scrapeRun                  *persistencemock.MockScrapeRun
+}
+
+func setupCandhisCatalogueScraperAndMocks(t *testing.T) (catalogueTestingMocks, service.CandhisCatalogueScraper) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := catalogueTestingMocks{
+		sessionID:                  persistencemock.NewMockSessionID(ctrl),
+		station:                    persistencemock.NewMockStation(ctrl),
+		candhisCatalogueWebScraper: clientmock.NewMockCandhisCatalogueWebScraper(ctrl),
+		candhisSessionIDWebScraper: clientmock.NewMockCandhisSessionIDWebScraper(ctrl),
+		scrapeRun:                  persistencemock.NewMockScrapeRun(ctrl),
+	}
+
+	return mocks, service.NewCandhisCatalogueScraper(mocks.sessionID, mocks.station, mocks.candhisCatalogueWebScraper,
+		mocks.candhisSessionIDWebScraper, mocks.scrapeRun, catalogueURL)
+}
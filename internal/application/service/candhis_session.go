@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+)
+
+// candhisSession is the Candhis session shared by the requests of a run, it is renewed at most once per run.
+type candhisSession struct {
+	id                               appmodel.CandhisSessionID
+	renewed                          bool
+	sessionID                        repository.SessionID
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+}
+
+// newCandhisSession starts from the newest valid session ID of the pool, and fetches a new one when there is none.
+func newCandhisSession(
+	ctx context.Context,
+	sessionIDRepo repository.SessionID,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+) (*candhisSession, error) {
+	s := &candhisSession{
+		sessionID:                        sessionIDRepo,
+		candhisSessionIDWebScraperClient: candhisSessionIDWebScraperClient,
+	}
+
+	candhisSessionID, err := sessionIDRepo.Get(ctx)
+	if errors.Is(err, appmodel.ErrNoValidCandhisSession) {
+		if err := s.fetch(ctx, appmodel.CandhisSessionSourceBootstrap); err != nil {
+			return nil, fmt.Errorf("no valid session ID in db, and failed to bootstrap one: %w", err)
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session ID from db: %w", err)
+	}
+
+	s.id = *candhisSessionID
+	return s, nil
+}
+
+// gather runs a Candhis request with the session ID. When Candhis rejects it, the session ID is invalidated, a new
+// one is fetched and stored, and the request is run again. A page still missing its content with the renewed session
+// is a layout change.
+func gather[T any](
+	ctx context.Context,
+	s *candhisSession,
+	request func(candhisSessionID appmodel.CandhisSessionID) (T, error),
+) (T, error) {
+	result, err := request(s.id)
+	if !errors.Is(err, appmodel.ErrSessionExpired) {
+		return result, err
+	}
+	if s.renewed {
+		return result, renewedSessionError(err)
+	}
+
+	s.renewed = true
+	if renewErr := s.renew(ctx); renewErr != nil {
+		var zero T
+		return zero, fmt.Errorf("%w, and failed to renew it: %w", err, renewErr)
+	}
+
+	result, err = request(s.id)
+	return result, renewedSessionError(err)
+}
+
+// renewedSessionError turns the error of a page missing its content with a renewed session into a layout change,
+// other errors are returned as is.
+func renewedSessionError(err error) error {
+	var missingContent *appmodel.MissingContentError
+	if errors.As(err, &missingContent) {
+		return fmt.Errorf("%w: %s, even with a renewed session", appmodel.ErrLayoutChanged, missingContent.Detail)
+	}
+	return err
+}
+
+func (s *candhisSession) renew(ctx context.Context) error {
+	err := s.sessionID.Invalidate(ctx, s.id)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate session ID in database: %w", err)
+	}
+
+	return s.fetch(ctx, appmodel.CandhisSessionSourceRenewal)
+}
+
+// fetch gets a new session ID from the Candhis web and adds it to the pool.
+func (s *candhisSession) fetch(ctx context.Context, source appmodel.CandhisSessionSource) error {
+	candhisSessionID, err := fetchAndStoreSessionID(ctx, s.sessionID, s.candhisSessionIDWebScraperClient, source)
+	if err != nil {
+		return err
+	}
+
+	s.id = candhisSessionID
+	return nil
+}
+
+func fetchAndStoreSessionID(
+	ctx context.Context,
+	sessionIDRepo repository.SessionID,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	source appmodel.CandhisSessionSource,
+) (appmodel.CandhisSessionID, error) {
+	candhisSessionID, err := candhisSessionIDWebScraperClient.GetCandhisSessionID(ctx)
+	if err != nil {
+		return appmodel.CandhisSessionID{}, fmt.Errorf("failed to get session ID from candhis web: %w", err)
+	}
+
+	session, err := appmodel.NewCandhisSession(candhisSessionID, source)
+	if err != nil {
+		return appmodel.CandhisSessionID{}, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	err = sessionIDRepo.Add(ctx, session)
+	if err != nil {
+		return appmodel.CandhisSessionID{}, fmt.Errorf("failed to store session ID in database: %w", err)
+	}
+
+	return candhisSessionID, nil
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/repository"
 )
 
@@ -14,25 +16,28 @@ type CandhisSessionIDScraper interface {
 type candhisSessionIDScraper struct {
 	sessionID                        repository.SessionID
 	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+	scrapeRun                        repository.ScrapeRun
 }
 
 func NewCandhisSessionIDScraper(
 	sessionID repository.SessionID,
 	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	scrapeRun repository.ScrapeRun,
 ) *candhisSessionIDScraper {
-	return &candhisSessionIDScraper{sessionID, candhisSessionIDWebScraperClient}
+	return &candhisSessionIDScraper{sessionID, candhisSessionIDWebScraperClient, scrapeRun}
 }
 
 func (s *candhisSessionIDScraper) FetchAndStoreSessionID(ctx context.Context) error {
-	candhisSessionID, err := s.candhisSessionIDWebScraperClient.GetCandhisSessionID(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get session ID from candhis web: %w", err)
-	}
+	startedAt := time.Now().UTC()
 
-	err = s.sessionID.Update(ctx, candhisSessionID)
-	if err != nil {
-		return fmt.Errorf("failed to update session ID in database: %w", err)
-	}
+	err := s.fetchAndStoreSessionID(ctx)
+	recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperSessionID, "", startedAt, err, appmodel.ScrapeRunCounts{})
 
-	return nil
+	return errors.Join(err, recordErr)
+}
+
+func (s *candhisSessionIDScraper) fetchAndStoreSessionID(ctx context.Context) error {
+	_, err := fetchAndStoreSessionID(ctx, s.sessionID, s.candhisSessionIDWebScraperClient,
+		appmodel.CandhisSessionSourceScraper)
+	return err
 }
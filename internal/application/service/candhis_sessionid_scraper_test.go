@@ -22,7 +22,12 @@ func TestCandhisSessionIDScraper_FetchAndStoreSessionID_Success(t *testing.T) {
 	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
 
 	mocks.candhisSessionIDWebScraperClient.EXPECT().GetCandhisSessionID(gomock.Any()).Return(sessionID, nil)
-	mocks.sessionID.EXPECT().Update(gomock.Any(), sessionID).Return(nil)
+	mocks.sessionID.EXPECT().
+		Add(gomock.Any(), appmodeltest.MustCreateCandhisSession(t, sessionID, appmodel.CandhisSessionSourceScraper)).
+		Return(nil)
+	mocks.scrapeRun.EXPECT().
+		Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSessionID, "", "", appmodel.ScrapeRunCounts{})).
+		Return(nil)
 
 	err := candhisScraper.FetchAndStoreSessionID(context.Background())
 	assert.NoError(t, err)
@@ -33,26 +38,52 @@ func TestCandhisSessionIDScraper_FetchAndStoreSessionID_ScrapingBeeClientFailure
 
 	mocks.candhisSessionIDWebScraperClient.EXPECT().GetCandhisSessionID(gomock.Any()).Return(
 		appmodel.CandhisSessionID{}, errors.New("error scraping bee"))
+	mocks.scrapeRun.EXPECT().
+		Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSessionID, "",
+			"failed to get session ID from candhis web: error scraping bee", appmodel.ScrapeRunCounts{})).
+		Return(nil)
 
 	err := candhisScraper.FetchAndStoreSessionID(context.Background())
 	assert.EqualError(t, err, "failed to get session ID from candhis web: error scraping bee")
 }
 
-func TestCandhisSessionIDScraper_FetchAndStoreSessionID_UpdateSessionIDFailure(t *testing.T) {
+func TestCandhisSessionIDScraper_FetchAndStoreSessionID_StoreSessionIDFailure(t *testing.T) {
 	mocks, candhisScraper := setupCandhisSessionIDScraperAndMocks(t)
 
 	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
 
 	mocks.candhisSessionIDWebScraperClient.EXPECT().GetCandhisSessionID(gomock.Any()).Return(sessionID, nil)
-	mocks.sessionID.EXPECT().Update(gomock.Any(), sessionID).Return(errors.New("error db"))
+	mocks.sessionID.EXPECT().
+		Add(gomock.Any(), appmodeltest.MustCreateCandhisSession(t, sessionID, appmodel.CandhisSessionSourceScraper)).
+		Return(errors.New("error db"))
+	mocks.scrapeRun.EXPECT().
+		Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSessionID, "",
+			"failed to store session ID in database: error db", appmodel.ScrapeRunCounts{})).
+		Return(nil)
 
 	err := candhisScraper.FetchAndStoreSessionID(context.Background())
-	assert.EqualError(t, err, "failed to update session ID in database: error db")
+	assert.EqualError(t, err, "failed to store session ID in database: error db")
+}
+
+func TestCandhisSessionIDScraper_FetchAndStoreSessionID_RecordScrapeRunFailure(t *testing.T) {
+	mocks, candhisScraper := setupCandhisSessionIDScraperAndMocks(t)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+
+	mocks.candhisSessionIDWebScraperClient.EXPECT().GetCandhisSessionID(gomock.Any()).Return(sessionID, nil)
+	mocks.sessionID.EXPECT().
+		Add(gomock.Any(), appmodeltest.MustCreateCandhisSession(t, sessionID, appmodel.CandhisSessionSourceScraper)).
+		Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New("error db"))
+
+	err := candhisScraper.FetchAndStoreSessionID(context.Background())
+	assert.EqualError(t, err, "failed to record scrape run: error db")
 }
 
 type sessionIDTestingMocks struct {
 	sessionID                        *persistencemock.MockSessionID
 	candhisSessionIDWebScraperClient *clientmock.MockCandhisSessionIDWebScraper
+	scrapeRun                        *persistencemock.MockScrapeRun
 }
 
 func setupCandhisSessionIDScraperAndMocks(t *testing.T) (sessionIDTestingMocks, service.CandhisSessionIDScraper) {
@@ -61,9 +92,11 @@ func setupCandhisSessionIDScraperAndMocks(t *testing.T) (sessionIDTestingMocks,
 	ctrl := gomock.NewController(t)
 	mockSessionIDRepo := persistencemock.NewMockSessionID(ctrl)
 	mockCandhisSessionIDWebScraperClient := clientmock.NewMockCandhisSessionIDWebScraper(ctrl)
+	mockScrapeRunRepo := persistencemock.NewMockScrapeRun(ctrl)
 
 	return sessionIDTestingMocks{
 		sessionID:                        mockSessionIDRepo,
 		candhisSessionIDWebScraperClient: mockCandhisSessionIDWebScraperClient,
-	}, service.NewCandhisSessionIDScraper(mockSessionIDRepo, mockCandhisSessionIDWebScraperClient)
+		scrapeRun:                        mockScrapeRunRepo,
+	}, service.NewCandhisSessionIDScraper(mockSessionIDRepo, mockCandhisSessionIDWebScraperClient, mockScrapeRunRepo)
 }
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+)
+
+type CandhisSpectraScraper interface {
+	FetchAndStoreSpectra(ctx context.Context) ([]CampaignScrapeResult, error)
+}
+
+type candhisSpectraScraper struct {
+	sessionID                        repository.SessionID
+	spectrum                         repository.Spectrum
+	candhisSpectraDownloaderClient   repository.CandhisSpectraDownloader
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper
+	scrapeRun                        repository.ScrapeRun
+	campaigns                        []appmodel.Campaign
+}
+
+func NewCandhisSpectraScraper(
+	sessionIDRepo repository.SessionID,
+	spectrumRepo repository.Spectrum,
+	candhisSpectraDownloaderClient repository.CandhisSpectraDownloader,
+	candhisSessionIDWebScraperClient repository.CandhisSessionIDWebScraper,
+	scrapeRunRepo repository.ScrapeRun,
+	campaigns []appmodel.Campaign,
+) *candhisSpectraScraper {
+	return &candhisSpectraScraper{
+		sessionIDRepo,
+		spectrumRepo,
+		candhisSpectraDownloaderClient,
+		candhisSessionIDWebScraperClient,
+		scrapeRunRepo,
+		campaigns,
+	}
+}
+
+// FetchAndStoreSpectra downloads the spectra of yesterday and today of every enabled campaign, and stores them in the
+// spectra index of the campaign. Failures are handled and recorded like in FetchAndStoreWaveData.
+func (s *candhisSpectraScraper) FetchAndStoreSpectra(ctx context.Context) ([]CampaignScrapeResult, error) {
+	startedAt := time.Now().UTC()
+
+	session, err := newCandhisSession(ctx, s.sessionID, s.candhisSessionIDWebScraperClient)
+	if err != nil {
+		recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperSpectra, "", startedAt, err, appmodel.ScrapeRunCounts{})
+		return nil, errors.Join(err, recordErr)
+	}
+
+	to := startedAt.Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -1)
+
+	var results []CampaignScrapeResult
+	var recordErrs []error
+	var campaignErrs []error
+	for _, campaign := range s.campaigns {
+		if !campaign.Enabled() {
+			continue
+		}
+
+		campaignStartedAt := time.Now().UTC()
+		report, err := s.fetchAndStoreCampaign(ctx, session, campaign, from, to)
+		if err != nil {
+			campaignErrs = append(campaignErrs, err)
+		}
+		results = append(results, CampaignScrapeResult{Campaign: campaign, Report: report, Err: err})
+
+		recordErr := recordScrapeRun(ctx, s.scrapeRun, appmodel.ScraperSpectra, campaign.BuoyID(), campaignStartedAt,
+			err, appmodel.NewScrapeRunCounts(report))
+		if recordErr != nil {
+			recordErrs = append(recordErrs, recordErr)
+		}
+	}
+
+	if len(campaignErrs) > 0 {
+		err = &campaignsFailedError{errs: campaignErrs, total: len(results)}
+	}
+
+	return results, errors.Join(append([]error{err}, recordErrs...)...)
+}
+
+func (s *candhisSpectraScraper) fetchAndStoreCampaign(
+	ctx context.Context,
+	session *candhisSession,
+	campaign appmodel.Campaign,
+	from, to time.Time,
+) (appmodel.IngestionReport, error) {
+	file, err := gather(ctx, session, func(candhisSessionID appmodel.CandhisSessionID) (appmodel.SpectrumFile, error) {
+		return s.candhisSpectraDownloaderClient.DownloadSpectra(ctx, candhisSessionID, campaign.CandhisURL(), from, to)
+	})
+	if err != nil {
+		return appmodel.IngestionReport{}, fmt.Errorf("failed to download spectra from candhis web: %w", err)
+	}
+
+	report := appmodel.IngestionReport{
+		RowsSeen: file.RowsSeen,
+		Parsed:   len(file.Spectra),
+		Rejected: file.Rejected,
+	}
+	if len(file.Spectra) == 0 {
+		return report, nil
+	}
+
+	batch, err := s.spectrum.AddBatch(ctx, file.Spectra, appmodel.SpectraIndexName(campaign.IndexName()))
+	if err != nil {
+		report.Failed = report.Parsed
+		return report, fmt.Errorf("failed to push spectra to Elasticsearch: %w", err)
+	}
+
+	report.Indexed, report.Unchanged, report.Failed = batch.Indexed, batch.Unchanged, len(batch.Failures)
+	if len(batch.Failures) > 0 {
+		return report, fmt.Errorf("failed to push %d of %d spectra to Elasticsearch, first failure at %s: %s",
+			len(batch.Failures), len(file.Spectra), batch.Failures[0].Timestamp.Format(time.RFC3339), batch.Failures[0].Reason)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	clientmock "github.com/tul1/candhis_api/internal/application/repository/client_mock"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCandhisSpectraScraper_FetchAndStoreSpectra_Success(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, spectraScraper := setupCandhisSpectraScraperAndMocks(t, campaigns)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	spectra := []model.Spectrum{
+		testSpectrum(t, time.Date(2024, 9, 17, 8, 30, 0, 0, time.UTC)),
+		testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)),
+	}
+	rejected := []appmodel.RejectedRow{{Row: 3, Reason: "invalid observation: invalid value for Densité (m2/Hz)"}}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisSpectraDownloader.EXPECT().
+		DownloadSpectra(gomock.Any(), sessionID, lesPierresNoiresURL, yesterday, today).
+		Return(appmodel.SpectrumFile{Spectra: spectra, RowsSeen: 5, Rejected: rejected}, nil)
+	mocks.spectrum.EXPECT().AddBatch(gomock.Any(), spectra, "les-pierres-noires-spectra").
+		Return(appmodel.BatchResult{Indexed: 1, Unchanged: 1}, nil)
+	mocks.candhisSpectraDownloader.EXPECT().
+		DownloadSpectra(gomock.Any(), sessionID, belleIleURL, yesterday, today).
+		Return(appmodel.SpectrumFile{}, nil)
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "02911", "",
+		appmodel.ScrapeRunCounts{RowsSeen: 5, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1})).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "05602", "",
+		appmodel.ScrapeRunCounts{})).Return(nil)
+
+	results, err := spectraScraper.FetchAndStoreSpectra(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{
+			Campaign: campaigns[0],
+			Report:   appmodel.IngestionReport{RowsSeen: 5, Parsed: 2, Rejected: rejected, Indexed: 1, Unchanged: 1},
+		},
+		{Campaign: campaigns[2]},
+	}, results)
+}
+
+func TestCandhisSpectraScraper_FetchAndStoreSpectra_SessionIDFailure(t *testing.T) {
+	mocks, spectraScraper := setupCandhisSpectraScraperAndMocks(t, testCampaigns(t))
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(nil, errors.New("error db"))
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "",
+		"failed to get session ID from db: error db", appmodel.ScrapeRunCounts{})).Return(nil)
+
+	results, err := spectraScraper.FetchAndStoreSpectra(context.Background())
+	assert.EqualError(t, err, "failed to get session ID from db: error db")
+	assert.Nil(t, results)
+}
+
+func TestCandhisSpectraScraper_FetchAndStoreSpectra_CampaignFailures(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, spectraScraper := setupCandhisSpectraScraperAndMocks(t, campaigns)
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	spectra := []model.Spectrum{testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC))}
+	layoutErr := fmt.Errorf("%w: none of the 3 rows of the spectral file has as many cells as its header",
+		appmodel.ErrLayoutChanged)
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisSpectraDownloader.EXPECT().
+		DownloadSpectra(gomock.Any(), sessionID, lesPierresNoiresURL, gomock.Any(), gomock.Any()).
+		Return(appmodel.SpectrumFile{}, layoutErr)
+	mocks.candhisSpectraDownloader.EXPECT().
+		DownloadSpectra(gomock.Any(), sessionID, belleIleURL, gomock.Any(), gomock.Any()).
+		Return(appmodel.SpectrumFile{Spectra: spectra, RowsSeen: 2}, nil)
+	mocks.spectrum.EXPECT().AddBatch(gomock.Any(), spectra, "belle-ile-spectra").
+		Return(appmodel.BatchResult{}, errors.New("error elasticsearch"))
+
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "02911",
+		"failed to download spectra from candhis web: "+layoutErr.Error(), appmodel.ScrapeRunCounts{})).Return(nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "05602",
+		"failed to push spectra to Elasticsearch: error elasticsearch",
+		appmodel.ScrapeRunCounts{RowsSeen: 2, Parsed: 1, Failed: 1})).Return(nil)
+
+	results, err := spectraScraper.FetchAndStoreSpectra(context.Background())
+	assert.EqualError(t, err, "failed to scrape 2 of 2 campaigns")
+	assert.ErrorIs(t, err, appmodel.ErrLayoutChanged)
+	require.Len(t, results, 2)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 2, Parsed: 1, Failed: 1}, results[1].Report)
+}
+
+func TestCandhisSpectraScraper_FetchAndStoreSpectra_AddSpectraPartialFailure(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, spectraScraper := setupCandhisSpectraScraperAndMocks(t, campaigns[:1])
+
+	sessionID := appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id")
+	spectra := []model.Spectrum{
+		testSpectrum(t, time.Date(2024, 9, 17, 8, 30, 0, 0, time.UTC)),
+		testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)),
+	}
+
+	mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&sessionID, nil)
+	mocks.candhisSpectraDownloader.EXPECT().
+		DownloadSpectra(gomock.Any(), sessionID, lesPierresNoiresURL, gomock.Any(), gomock.Any()).
+		Return(appmodel.SpectrumFile{Spectra: spectra, RowsSeen: 4}, nil)
+	mocks.spectrum.EXPECT().AddBatch(gomock.Any(), spectra, "les-pierres-noires-spectra").
+		Return(appmodel.BatchResult{
+			Indexed:  1,
+			Failures: []appmodel.IndexFailure{{Timestamp: spectra[1].Timestamp(), Reason: "mapper_parsing_exception"}},
+		}, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), scrapeRunMatching(appmodel.ScraperSpectra, "02911",
+		"failed to push 1 of 2 spectra to Elasticsearch, first failure at 2024-09-17T09:00:00Z: mapper_parsing_exception",
+		appmodel.ScrapeRunCounts{RowsSeen: 4, Parsed: 2, Indexed: 1, Failed: 1})).Return(nil)
+
+	results, err := spectraScraper.FetchAndStoreSpectra(context.Background())
+	assert.EqualError(t, err, "failed to scrape 1 of 1 campaigns")
+	require.Len(t, results, 1)
+	assert.Equal(t, appmodel.IngestionReport{RowsSeen: 4, Parsed: 2, Indexed: 1, Failed: 1}, results[0].Report)
+}
+
+func TestCandhisSpectraScraper_FetchAndStoreSpectra_SessionExpired(t *testing.T) {
+	campaigns := testCampaigns(t)
+	mocks, spectraScraper := setupCandhisSpectraScraperAndMocks(t, campaigns[:1])
+
+	expiredSessionID := appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id")
+	renewedSessionID := appmodeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	renewedSession := appmodeltest.MustCreateCandhisSession(t, renewedSessionID, appmodel.CandhisSessionSourceRenewal)
+	spectra := []model.Spectrum{testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC))}
+	sessionExpiredErr := &appmodel.MissingContentError{Detail: "no spectral file in response"}
+
+	gomock.InOrder(
+		mocks.sessionID.EXPECT().Get(gomock.Any()).Return(&expiredSessionID, nil),
+		mocks.candhisSpectraDownloader.EXPECT().
+			DownloadSpectra(gomock.Any(), expiredSessionID, lesPierresNoiresURL, gomock.Any(), gomock.Any()).
+			Return(appmodel.SpectrumFile{}, sessionExpiredErr),
+		mocks.sessionID.EXPECT().Invalidate(gomock.Any(), expiredSessionID).Return(nil),
+		mocks.candhisSessionIDWebScraper.EXPECT().GetCandhisSessionID(gomock.Any()).Return(renewedSessionID, nil),
+		mocks.sessionID.EXPECT().Add(gomock.Any(), renewedSession).Return(nil),
+		mocks.candhisSpectraDownloader.EXPECT().
+			DownloadSpectra(gomock.Any(), renewedSessionID, lesPierresNoiresURL, gomock.Any(), gomock.Any()).
+			Return(appmodel.SpectrumFile{Spectra: spectra, RowsSeen: 2}, nil),
+	)
+	mocks.spectrum.EXPECT().AddBatch(gomock.Any(), spectra, "les-pierres-noires-spectra").
+		Return(appmodel.BatchResult{Indexed: 1}, nil)
+	mocks.scrapeRun.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	results, err := spectraScraper.FetchAndStoreSpectra(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []service.CampaignScrapeResult{
+		{Campaign: campaigns[0], Report: appmodel.IngestionReport{RowsSeen: 2, Parsed: 1, Indexed: 1}},
+	}, results)
+}
+
+func testSpectrum(t *testing.T, timestamp time.Time) model.Spectrum {
+	t.Helper()
+
+	return modeltest.MustCreateSpectrum(t, timestamp,
+		model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		model.SpectrumBin{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+	)
+}
+
+type spectraTestingMocks struct {
+	sessionID                  *persistencemock.MockSessionID
+	spectrum                   *persistencemock.MockSpectrum
+	candhisSpectraDownloader   *clientmock.MockCandhisSpectraDownloader
+	candhisSessionIDWebScraper *clientmock.MockCandhisSessionIDWebScraper
+	scrapeRun                  *persistencemock.MockScrapeRun
+}
+
+func setupCandhisSpectraScraperAndMocks(
+	t *testing.T,
+	campaigns []appmodel.Campaign,
+) (spectraTestingMocks, service.CandhisSpectraScraper) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := spectraTestingMocks{
+		sessionID:                  persistencemock.NewMockSessionID(ctrl),
+		spectrum:                   persistencemock.NewMockSpectrum(ctrl),
+		candhisSpectraDownloader:   clientmock.NewMockCandhisSpectraDownloader(ctrl),
+		candhisSessionIDWebScraper: clientmock.NewMockCandhisSessionIDWebScraper(ctrl),
+		scrapeRun:                  persistencemock.NewMockScrapeRun(ctrl),
+	}
+
+	return mocks, service.NewCandhisSpectraScraper(mocks.sessionID, mocks.spectrum, mocks.candhisSpectraDownloader,
+		mocks.candhisSessionIDWebScraper, mocks.scrapeRun, campaigns)
+}
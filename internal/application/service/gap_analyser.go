@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+type GapAnalyser interface {
+	Analyse(ctx context.Context, campaign string, query appmodel.GapQuery) (appmodel.GapReport, error)
+}
+
+type gapAnalyser struct {
+	waveData repository.WaveData
+}
+
+func NewGapAnalyser(waveDataRepo repository.WaveData) *gapAnalyser {
+	return &gapAnalyser{waveDataRepo}
+}
+
+// Analyse reports the missing observations of a campaign over the query time range. The observations are streamed
+// from the campaign index, only their timestamps are kept.
+func (g *gapAnalyser) Analyse(ctx context.Context, campaign string, query appmodel.GapQuery) (appmodel.GapReport, error) {
+	from, to := query.From(), query.To()
+	exportQuery, err := appmodel.NewWaveDataExportQuery(&from, &to)
+	if err != nil {
+		return appmodel.GapReport{}, err
+	}
+
+	var collector timestampCollector
+	if err := g.waveData.Export(ctx, campaign, exportQuery, &collector); err != nil {
+		return appmodel.GapReport{}, fmt.Errorf("failed to read campaign observations: %w", err)
+	}
+
+	return appmodel.NewGapReport(query, collector.timestamps), nil
+}
+
+// timestampCollector is an export writer keeping the timestamps of the exported observations.
+type timestampCollector struct {
+	timestamps []time.Time
+}
+
+func (c *timestampCollector) Begin(count int) error {
+	c.timestamps = make([]time.Time, 0, count)
+	return nil
+}
+
+func (c *timestampCollector) Write(waveData model.WaveData) error {
+	c.timestamps = append(c.timestamps, waveData.Timestamp())
+	return nil
+}
+
+func (c *timestampCollector) End() error {
+	return nil
+}
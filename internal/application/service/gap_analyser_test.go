@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGapAnalyser_Analyse_Success(t *testing.T) {
+	waveDataRepo, gapAnalyser := setupGapAnalyserAndMocks(t)
+
+	from := time.Date(2024, 9, 17, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	query, err := appmodel.NewGapQuery(&from, &to, to)
+	require.NoError(t, err)
+	exportQuery, err := appmodel.NewWaveDataExportQuery(&from, &to)
+	require.NoError(t, err)
+
+	observations := []model.WaveData{
+		mustCreateWaveDataAt(t, time.Date(2024, 9, 17, 8, 0, 0, 0, time.UTC)),
+		mustCreateWaveDataAt(t, time.Date(2024, 9, 17, 9, 30, 0, 0, time.UTC)),
+	}
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", exportQuery, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ appmodel.WaveDataExportQuery,
+			writer appmodel.WaveDataExportWriter,
+		) error {
+			require.NoError(t, writer.Begin(len(observations)))
+			for _, waveData := range observations {
+				require.NoError(t, writer.Write(waveData))
+			}
+			return writer.End()
+		})
+
+	report, err := gapAnalyser.Analyse(context.Background(), "les-pierres-noires", query)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.NewGapReport(query, []time.Time{observations[0].Timestamp(), observations[1].Timestamp()}),
+		report)
+	assert.Equal(t, []appmodel.ObservationGap{{From: from.Add(30 * time.Minute), To: from.Add(90 * time.Minute)}},
+		report.Gaps)
+}
+
+func TestGapAnalyser_Analyse_Failure(t *testing.T) {
+	waveDataRepo, gapAnalyser := setupGapAnalyserAndMocks(t)
+
+	query, err := appmodel.NewGapQuery(nil, nil, time.Now())
+	require.NoError(t, err)
+	waveDataRepo.EXPECT().Export(gomock.Any(), "les-pierres-noires", gomock.Any(), gomock.Any()).
+		Return(errors.New("error elasticsearch"))
+
+	_, err = gapAnalyser.Analyse(context.Background(), "les-pierres-noires", query)
+	assert.EqualError(t, err, "failed to read campaign observations: error elasticsearch")
+}
+
+func setupGapAnalyserAndMocks(t *testing.T) (*persistencemock.MockWaveData, service.GapAnalyser) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	waveDataRepo := persistencemock.NewMockWaveData(ctrl)
+
+	return waveDataRepo, service.NewGapAnalyser(waveDataRepo)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+type LatestWaveData interface {
+	Get(ctx context.Context, station appmodel.Station) (*appmodel.LatestWaveData, error)
+}
+
+type latestWaveData struct {
+	waveData   repository.WaveData
+	scrapeRun  repository.ScrapeRun
+	staleAfter time.Duration
+	cacheTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedWaveData
+}
+
+// cachedWaveData is the latest observation of a campaign, when it was read and when the last scrape of the campaign
+// finished at that time.
+type cachedWaveData struct {
+	waveData   model.WaveData
+	readAt     time.Time
+	lastScrape time.Time
+}
+
+func NewLatestWaveData(
+	waveDataRepo repository.WaveData,
+	scrapeRunRepo repository.ScrapeRun,
+	staleAfter, cacheTTL time.Duration,
+) *latestWaveData {
+	return &latestWaveData{
+		waveData:   waveDataRepo,
+		scrapeRun:  scrapeRunRepo,
+		staleAfter: staleAfter,
+		cacheTTL:   cacheTTL,
+		cache:      make(map[string]cachedWaveData),
+	}
+}
+
+// Get returns the most recent observation of the campaign of the station with its age, nil when the campaign has none.
+// The observations are cached in memory for at most cacheTTL. The scrapers run in other processes, so a cached
+// observation is dropped as soon as the scrape run history shows a campaigns or backfill run of the campaign that
+// finished since it was read.
+func (l *latestWaveData) Get(ctx context.Context, station appmodel.Station) (*appmodel.LatestWaveData, error) {
+	lastScrape, err := l.scrapeRun.LastRunFinishedAt(ctx, station.BuoyID(), appmodel.ScraperCampaigns,
+		appmodel.ScraperBackfill)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check scrape runs: %w", err)
+	}
+
+	campaign := station.IndexName()
+	waveData, ok := l.cached(campaign, lastScrape)
+	if !ok {
+		query, err := appmodel.NewWaveDataQuery(nil, nil, "", 1, appmodel.SortOrderDesc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create latest observation query: %w", err)
+		}
+
+		page, err := l.waveData.List(ctx, campaign, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list campaign observations: %w", err)
+		}
+		if len(page.WaveData) == 0 {
+			return nil, nil
+		}
+
+		waveData = page.WaveData[0]
+		l.store(campaign, waveData, lastScrape)
+	}
+
+	age := time.Since(waveData.Timestamp())
+	return &appmodel.LatestWaveData{
+		WaveData:   waveData,
+		Age:        age,
+		Stale:      age > l.staleAfter,
+		StaleAfter: l.staleAfter,
+	}, nil
+}
+
+func (l *latestWaveData) cached(campaign string, lastScrape time.Time) (model.WaveData, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.cache[campaign]
+	if !ok || time.Since(entry.readAt) >= l.cacheTTL || !entry.lastScrape.Equal(lastScrape) {
+		return model.WaveData{}, false
+	}
+	return entry.waveData, true
+}
+
+func (l *latestWaveData) store(campaign string, waveData model.WaveData, lastScrape time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cache[campaign] = cachedWaveData{waveData: waveData, readAt: time.Now(), lastScrape: lastScrape}
+}
@@ -0,0 +1,203 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	persistencemock "github.com/tul1/candhis_api/internal/application/repository/persistence_mock"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	latestStaleAfter = time.Hour
+	latestCacheTTL   = time.Minute
+)
+
+var lastScrapeFinishedAt = time.Date(2024, 9, 17, 9, 0, 2, 0, time.UTC)
+
+func TestLatestWaveData_Get_Fresh(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+
+	waveData := mustCreateWaveDataAt(t, time.Now().UTC().Add(-10*time.Minute))
+	expectLastScrape(mocks, "02911", lastScrapeFinishedAt)
+	expectLatestObservation(mocks, "les-pierres-noires", waveData)
+
+	latest, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+
+	assert.Equal(t, waveData, latest.WaveData)
+	assert.GreaterOrEqual(t, latest.Age, 10*time.Minute)
+	assert.False(t, latest.Stale)
+	assert.Equal(t, latestStaleAfter, latest.StaleAfter)
+}
+
+func TestLatestWaveData_Get_Stale(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+
+	waveData := mustCreateWaveDataAt(t, time.Now().UTC().Add(-2*time.Hour))
+	expectLastScrape(mocks, "02911", lastScrapeFinishedAt)
+	expectLatestObservation(mocks, "les-pierres-noires", waveData)
+
+	latest, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+
+	assert.True(t, latest.Stale)
+}
+
+func TestLatestWaveData_Get_Cached(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+
+	waveData := mustCreateWaveDataAt(t, time.Now().UTC().Add(-10*time.Minute))
+	expectLastScrape(mocks, "02911", lastScrapeFinishedAt).Times(2)
+	expectLatestObservation(mocks, "les-pierres-noires", waveData).Times(1)
+
+	for range 2 {
+		latest, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+		require.NoError(t, err)
+		assert.Equal(t, waveData, latest.WaveData)
+	}
+}
+
+func TestLatestWaveData_Get_InvalidatedByScrape(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+
+	waveData1 := mustCreateWaveDataAt(t, time.Now().UTC().Add(-40*time.Minute))
+	waveData2 := mustCreateWaveDataAt(t, time.Now().UTC().Add(-10*time.Minute))
+	gomock.InOrder(
+		expectLastScrape(mocks, "02911", lastScrapeFinishedAt),
+		expectLatestObservation(mocks, "les-pierres-noires", waveData1),
+		expectLastScrape(mocks, "02911", lastScrapeFinishedAt.Add(30*time.Minute)),
+		expectLatestObservation(mocks, "les-pierres-noires", waveData2),
+	)
+
+	latest, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	assert.Equal(t, waveData1, latest.WaveData)
+
+	latest, err = latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	assert.Equal(t, waveData2, latest.WaveData)
+}
+
+func TestLatestWaveData_Get_CacheExpired(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, time.Millisecond)
+
+	waveData1 := mustCreateWaveDataAt(t, time.Now().UTC().Add(-40*time.Minute))
+	waveData2 := mustCreateWaveDataAt(t, time.Now().UTC().Add(-10*time.Minute))
+	expectLastScrape(mocks, "02911", lastScrapeFinishedAt).Times(2)
+	gomock.InOrder(
+		expectLatestObservation(mocks, "les-pierres-noires", waveData1),
+		expectLatestObservation(mocks, "les-pierres-noires", waveData2),
+	)
+
+	latest, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	assert.Equal(t, waveData1, latest.WaveData)
+
+	time.Sleep(2 * time.Millisecond)
+
+	latest, err = latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+	require.NoError(t, err)
+	assert.Equal(t, waveData2, latest.WaveData)
+}
+
+func TestLatestWaveData_Get_NoObservation(t *testing.T) {
+	mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+
+	station := appmodeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.2855, -3.2847, nil, "", false)
+	expectLastScrape(mocks, "05602", time.Time{})
+	mocks.waveData.EXPECT().List(gomock.Any(), "belle-ile", gomock.Any()).Return(appmodel.WaveDataPage{}, nil)
+
+	latest, err := latestWaveData.Get(context.Background(), station)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+}
+
+func TestLatestWaveData_Get_Failures(t *testing.T) {
+	dbErr := errors.New("error database")
+	esErr := errors.New("error elasticsearch")
+
+	testCases := map[string]struct {
+		expect      func(mocks latestWaveDataMocks)
+		expectedErr error
+	}{
+		"scrape runs error": {
+			expect: func(mocks latestWaveDataMocks) {
+				mocks.scrapeRun.EXPECT().LastRunFinishedAt(gomock.Any(), "02911", gomock.Any(), gomock.Any()).
+					Return(time.Time{}, dbErr)
+			},
+			expectedErr: dbErr,
+		},
+		"observations error": {
+			expect: func(mocks latestWaveDataMocks) {
+				expectLastScrape(mocks, "02911", lastScrapeFinishedAt)
+				mocks.waveData.EXPECT().List(gomock.Any(), "les-pierres-noires", gomock.Any()).
+					Return(appmodel.WaveDataPage{}, esErr)
+			},
+			expectedErr: esErr,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mocks, latestWaveData := setupLatestWaveDataAndMocks(t, latestCacheTTL)
+			tc.expect(mocks)
+
+			_, err := latestWaveData.Get(context.Background(), lesPierresNoiresStation(t))
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func lesPierresNoiresStation(t *testing.T) appmodel.Station {
+	t.Helper()
+
+	return appmodeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.2908, -4.9678, nil, "Cerema", true)
+}
+
+func mustCreateWaveDataAt(t *testing.T, timestamp time.Time) model.WaveData {
+	t.Helper()
+
+	return modeltest.MustCreateWaveData(t, timestamp.Format("02/01/2006"), timestamp.Format("15:04"),
+		"0.6", "1.1", "4.7", "8", "32", "15")
+}
+
+func expectLastScrape(mocks latestWaveDataMocks, buoyID string, finishedAt time.Time) *gomock.Call {
+	return mocks.scrapeRun.EXPECT().
+		LastRunFinishedAt(gomock.Any(), buoyID, appmodel.ScraperCampaigns, appmodel.ScraperBackfill).
+		Return(finishedAt, nil)
+}
+
+func expectLatestObservation(mocks latestWaveDataMocks, campaign string, waveData model.WaveData) *gomock.Call {
+	query, _ := appmodel.NewWaveDataQuery(nil, nil, "", 1, appmodel.SortOrderDesc)
+	return mocks.waveData.EXPECT().List(gomock.Any(), campaign, query).
+		Return(appmodel.WaveDataPage{WaveData: []model.WaveData{waveData}}, nil)
+}
+
+type latestWaveDataMocks struct {
+	waveData  *persistencemock.MockWaveData
+	scrapeRun *persistencemock.MockScrapeRun
+}
+
+func setupLatestWaveDataAndMocks(t *testing.T, cacheTTL time.Duration) (latestWaveDataMocks, service.LatestWaveData) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := latestWaveDataMocks{
+		waveData:  persistencemock.NewMockWaveData(ctrl),
+		scrapeRun: persistencemock.NewMockScrapeRun(ctrl),
+	}
+
+	return mocks, service.NewLatestWaveData(mocks.waveData, mocks.scrapeRun, latestStaleAfter, cacheTTL)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+)
+
+// recordScrapeRun stores the history entry of a run that started at startedAt and just finished with runErr.
+func recordScrapeRun(
+	ctx context.Context,
+	scrapeRunRepo repository.ScrapeRun,
+	scraper appmodel.Scraper,
+	campaign string,
+	startedAt time.Time,
+	runErr error,
+	counts appmodel.ScrapeRunCounts,
+) error {
+	var errorText string
+	if runErr != nil {
+		errorText = runErr.Error()
+	}
+
+	run, err := appmodel.NewScrapeRun(scraper, campaign, startedAt, time.Now().UTC(), errorText, counts)
+	if err != nil {
+		return fmt.Errorf("failed to create scrape run: %w", err)
+	}
+
+	if err := scrapeRunRepo.Add(ctx, run); err != nil {
+		return fmt.Errorf("failed to record scrape run: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package service_test
+
+import (
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"go.uber.org/mock/gomock"
+)
+
+// scrapeRunMatching matches a recorded scrape run on everything but its start and finish times.
+func scrapeRunMatching(
+	scraper appmodel.Scraper,
+	campaign string,
+	errorText string,
+	counts appmodel.ScrapeRunCounts,
+) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		run, ok := x.(appmodel.ScrapeRun)
+		return ok &&
+			run.Scraper() == scraper &&
+			run.Campaign() == campaign &&
+			run.ErrorText() == errorText &&
+			run.Counts() == counts &&
+			!run.FinishedAt().Before(run.StartedAt())
+	})
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+)
+
+// Measurement is an optional parameter of an observation, only published by some Candhis buoys.
+type Measurement string
+
+const (
+	// Period of the peak of the energy spectrum, Tp.
+	MeasurementPeakPeriod Measurement = "tp"
+	// Mean period of the zero up-crossing waves, Tz.
+	MeasurementMeanPeriod Measurement = "tz"
+	// Mean direction of wave origin over the whole spectrum, measured like the peak direction.
+	MeasurementMeanDirection Measurement = "mean_direction"
+	// Significant wave height estimated from the energy spectrum, Hm0.
+	MeasurementSpectralWaveHeight Measurement = "hm0"
+	MeasurementWindSpeed          Measurement = "wind_speed"
+	// Direction the wind blows from, measured like the peak direction.
+	MeasurementWindDirection Measurement = "wind_direction"
+)
+
+// Measurements lists the optional measurements in the order they are written.
+var Measurements = []Measurement{
+	MeasurementPeakPeriod,
+	MeasurementMeanPeriod,
+	MeasurementMeanDirection,
+	MeasurementSpectralWaveHeight,
+	MeasurementWindSpeed,
+	MeasurementWindDirection,
+}
+
+// Unit returns the unit of the values of the measurement.
+func (m Measurement) Unit() string {
+	switch m {
+	case MeasurementPeakPeriod, MeasurementMeanPeriod:
+		return "s"
+	case MeasurementMeanDirection, MeasurementWindDirection:
+		return "°"
+	case MeasurementSpectralWaveHeight:
+		return "m"
+	case MeasurementWindSpeed:
+		return "m/s"
+	default:
+		return ""
+	}
+}
+
+func (m Measurement) validate(value float64) error {
+	switch m {
+	case MeasurementPeakPeriod, MeasurementMeanPeriod, MeasurementSpectralWaveHeight, MeasurementWindSpeed:
+		if value < 0 {
+			return fmt.Errorf("%w: negative value for %s", ErrInvalidObservation, m)
+		}
+	case MeasurementMeanDirection, MeasurementWindDirection:
+		if value < 0 || value > 360 {
+			return fmt.Errorf("%w: value for %s out of 0 to 360 degrees", ErrInvalidObservation, m)
+		}
+	default:
+		return fmt.Errorf("%w: unknown measurement %s", ErrInvalidObservation, m)
+	}
+	return nil
+}
+
+// Measurement returns the value of an optional measurement, and whether the observation has it.
+func (w WaveData) Measurement(m Measurement) (float64, bool) {
+	value, ok := w.measurements[m]
+	return value, ok
+}
+
+// WithMeasurement parses and sets the value of an optional measurement.
+func (w WaveData) WithMeasurement(m Measurement, valueStr string) (WaveData, error) {
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return WaveData{}, fmt.Errorf("%w: invalid value for %s", ErrInvalidObservation, m)
+	}
+	if err := m.validate(value); err != nil {
+		return WaveData{}, err
+	}
+
+	// Copied so that the observations w was copied from are left untouched.
+	measurements := maps.Clone(w.measurements)
+	if measurements == nil {
+		measurements = make(map[Measurement]float64, 1)
+	}
+	measurements[m] = value
+	w.measurements = measurements
+	return w, nil
+}
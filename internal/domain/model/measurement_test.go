@@ -0,0 +1,123 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+func TestWaveDataWithMeasurement(t *testing.T) {
+	waveData := modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0")
+
+	withPeakPeriod, err := waveData.WithMeasurement(model.MeasurementPeakPeriod, "11.2")
+	require.NoError(t, err)
+	withWind, err := withPeakPeriod.WithMeasurement(model.MeasurementWindDirection, "270")
+	require.NoError(t, err)
+
+	value, ok := withWind.Measurement(model.MeasurementPeakPeriod)
+	assert.True(t, ok)
+	assert.Equal(t, 11.2, value)
+	value, ok = withWind.Measurement(model.MeasurementWindDirection)
+	assert.True(t, ok)
+	assert.Equal(t, 270.0, value)
+
+	// The observations it was set on are left untouched.
+	_, ok = withPeakPeriod.Measurement(model.MeasurementWindDirection)
+	assert.False(t, ok)
+	_, ok = waveData.Measurement(model.MeasurementPeakPeriod)
+	assert.False(t, ok)
+}
+
+func TestWaveDataWithMeasurementFailure(t *testing.T) {
+	testCases := map[string]struct {
+		measurement model.Measurement
+		value       string
+		errMsg      string
+	}{
+		"not a number": {
+			measurement: model.MeasurementMeanPeriod,
+			value:       "-",
+			errMsg:      "invalid observation: invalid value for tz",
+		},
+		"negative height": {
+			measurement: model.MeasurementSpectralWaveHeight,
+			value:       "-0.5",
+			errMsg:      "invalid observation: negative value for hm0",
+		},
+		"direction out of range": {
+			measurement: model.MeasurementMeanDirection,
+			value:       "361",
+			errMsg:      "invalid observation: value for mean_direction out of 0 to 360 degrees",
+		},
+		"unknown measurement": {
+			measurement: model.Measurement("pressure"),
+			value:       "1013",
+			errMsg:      "invalid observation: unknown measurement pressure",
+		},
+	}
+
+	waveData := modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0")
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := waveData.WithMeasurement(tc.measurement, tc.value)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.ErrorIs(t, err, model.ErrInvalidObservation)
+		})
+	}
+}
+
+func TestMeasurementUnit(t *testing.T) {
+	units := map[model.Measurement]string{
+		model.MeasurementPeakPeriod:         "s",
+		model.MeasurementMeanPeriod:         "s",
+		model.MeasurementMeanDirection:      "°",
+		model.MeasurementSpectralWaveHeight: "m",
+		model.MeasurementWindSpeed:          "m/s",
+		model.MeasurementWindDirection:      "°",
+	}
+
+	for _, m := range model.Measurements {
+		assert.Equal(t, units[m], m.Unit(), m)
+	}
+}
+
+func TestWaveDataJSON_Measurements(t *testing.T) {
+	waveData := modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0")
+	for m, value := range map[model.Measurement]string{
+		model.MeasurementPeakPeriod:         "11.2",
+		model.MeasurementMeanPeriod:         "7.4",
+		model.MeasurementMeanDirection:      "285",
+		model.MeasurementSpectralWaveHeight: "2.6",
+		model.MeasurementWindSpeed:          "8.5",
+		model.MeasurementWindDirection:      "0",
+	} {
+		waveData = modeltest.MustWithMeasurement(t, waveData, m, value)
+	}
+
+	jsonData, err := json.Marshal(waveData)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"timestamp": "2024-10-07T14:00:00Z",
+		"h1_3": 2.5,
+		"hmax": 4.0,
+		"th1_3": 10.5,
+		"peak_direction": 90,
+		"peak_directional_spread": 30,
+		"temperature": 20.0,
+		"tp": 11.2,
+		"tz": 7.4,
+		"mean_direction": 285,
+		"hm0": 2.6,
+		"wind_speed": 8.5,
+		"wind_direction": 0
+	}`, string(jsonData))
+
+	var decoded model.WaveData
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, waveData, decoded)
+}
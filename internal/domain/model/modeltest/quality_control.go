@@ -0,0 +1,35 @@
+package modeltest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// QCConfig is the quality control config of conf/campaigns_scrapper.yml.
+func QCConfig() model.QCConfig {
+	return model.QCConfig{
+		HeightFail:           model.QCRange{Min: 0, Max: 30},
+		HeightSuspect:        model.QCRange{Min: 0, Max: 20},
+		PeriodFail:           model.QCRange{Min: 0, Max: 40},
+		PeriodSuspect:        model.QCRange{Min: 1, Max: 25},
+		TemperatureFail:      model.QCRange{Min: -5, Max: 40},
+		TemperatureSuspect:   model.QCRange{Min: 0, Max: 30},
+		HeightSpike:          model.QCThresholds{Suspect: 2, Fail: 4},
+		TemperatureSpike:     model.QCThresholds{Suspect: 2, Fail: 5},
+		SpikeMaxGap:          90 * time.Minute,
+		FlatLineSuspectCount: 6,
+		FlatLineFailCount:    12,
+	}
+}
+
+func MustCreateQualityControl(t *testing.T, config model.QCConfig) model.QualityControl {
+	t.Helper()
+
+	qualityControl, err := model.NewQualityControl(config)
+	require.NoError(t, err, "failed to create QualityControl")
+
+	return qualityControl
+}
@@ -2,6 +2,7 @@ package modeltest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tul1/candhis_api/internal/domain/model"
@@ -15,3 +16,21 @@ func MustCreateWaveData(t *testing.T, dateStr, timeStr, h13, hmax, th13, directi
 
 	return waveData
 }
+
+func MustWithMeasurement(t *testing.T, waveData model.WaveData, m model.Measurement, value string) model.WaveData {
+	t.Helper()
+
+	waveData, err := waveData.WithMeasurement(m, value)
+	require.NoError(t, err, "failed to set WaveData measurement")
+
+	return waveData
+}
+
+func MustCreateSpectrum(t *testing.T, timestamp time.Time, bins ...model.SpectrumBin) model.Spectrum {
+	t.Helper()
+
+	spectrum, err := model.NewSpectrum(timestamp, bins)
+	require.NoError(t, err, "failed to create Spectrum")
+
+	return spectrum
+}
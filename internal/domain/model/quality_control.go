@@ -0,0 +1,282 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// QCFlag is a quality control flag of the IOOS QARTOD manuals.
+type QCFlag int
+
+const (
+	QCFlagPass         QCFlag = 1
+	QCFlagNotEvaluated QCFlag = 2
+	QCFlagSuspect      QCFlag = 3
+	QCFlagFail         QCFlag = 4
+)
+
+// severity orders the flags from the least to the most severe, a test that did not run does not hide a passed one.
+func (f QCFlag) severity() int {
+	switch f {
+	case QCFlagPass:
+		return 1
+	case QCFlagSuspect:
+		return 2
+	case QCFlagFail:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func worstQCFlag(flags ...QCFlag) QCFlag {
+	worst := QCFlagNotEvaluated
+	for _, flag := range flags {
+		if flag.severity() > worst.severity() {
+			worst = flag
+		}
+	}
+	return worst
+}
+
+// QCFlags holds the flag of each quality control test of an observation. The zero value is an observation that was
+// stored before quality control existed.
+type QCFlags struct {
+	// Values outside the possible or the plausible range of the buoy.
+	GrossRange QCFlag
+	// Values contradicting each other, e.g. a maximum height below the significant wave height.
+	Consistency QCFlag
+	// Sudden change from the previous observation.
+	Spike QCFlag
+	// Values repeated over consecutive observations, as sent by a stuck sensor.
+	FlatLine QCFlag
+}
+
+func (f QCFlags) Evaluated() bool {
+	return f != QCFlags{}
+}
+
+// Aggregate is the most severe flag of the tests, QCFlagNotEvaluated when none ran.
+func (f QCFlags) Aggregate() QCFlag {
+	return worstQCFlag(f.GrossRange, f.Consistency, f.Spike, f.FlatLine)
+}
+
+// QCRange is an inclusive range of values.
+type QCRange struct {
+	Min float64
+	Max float64
+}
+
+func (r QCRange) contains(value float64) bool {
+	return value >= r.Min && value <= r.Max
+}
+
+func (r QCRange) within(other QCRange) bool {
+	return r.Min >= other.Min && r.Max <= other.Max
+}
+
+// QCThresholds are the limits from which a test flags an observation as suspect and as failed.
+type QCThresholds struct {
+	Suspect float64
+	Fail    float64
+}
+
+func (t QCThresholds) flag(value float64) QCFlag {
+	switch {
+	case value > t.Fail:
+		return QCFlagFail
+	case value > t.Suspect:
+		return QCFlagSuspect
+	default:
+		return QCFlagPass
+	}
+}
+
+type QCConfig struct {
+	// Values outside the Fail ranges are impossible for the buoy, values outside the Suspect ranges are implausible
+	// at sea. Heights apply to both the significant and the maximum wave height.
+	HeightFail         QCRange
+	HeightSuspect      QCRange
+	PeriodFail         QCRange
+	PeriodSuspect      QCRange
+	TemperatureFail    QCRange
+	TemperatureSuspect QCRange
+	// Largest changes of the significant wave height and of the temperature from the previous observation.
+	HeightSpike      QCThresholds
+	TemperatureSpike QCThresholds
+	// The spike test is not evaluated when the previous observation is older than SpikeMaxGap.
+	SpikeMaxGap time.Duration
+	// Number of consecutive observations with the same heights and period flagged as suspect and as failed.
+	FlatLineSuspectCount int
+	FlatLineFailCount    int
+}
+
+// The directions are angles, they are checked against fixed ranges rather than configured ones.
+var (
+	peakDirectionRange         = QCRange{Min: 0, Max: 360}
+	peakDirectionalSpreadRange = QCRange{Min: 0, Max: 180}
+)
+
+// QualityControl flags observations with the range, consistency, spike and flat line tests of its config.
+type QualityControl struct {
+	config QCConfig
+}
+
+func NewQualityControl(config QCConfig) (QualityControl, error) {
+	ranges := []struct {
+		name          string
+		fail, suspect QCRange
+	}{
+		{"height", config.HeightFail, config.HeightSuspect},
+		{"period", config.PeriodFail, config.PeriodSuspect},
+		{"temperature", config.TemperatureFail, config.TemperatureSuspect},
+	}
+	for _, r := range ranges {
+		if r.fail.Min >= r.fail.Max || r.suspect.Min >= r.suspect.Max {
+			return QualityControl{}, fmt.Errorf("invalid quality control config: %s ranges must have min below max", r.name)
+		}
+		if !r.suspect.within(r.fail) {
+			return QualityControl{}, fmt.Errorf("invalid quality control config: %s suspect range must be within the fail range",
+				r.name)
+		}
+	}
+
+	for name, thresholds := range map[string]QCThresholds{"height": config.HeightSpike, "temperature": config.TemperatureSpike} {
+		if thresholds.Suspect <= 0 || thresholds.Fail < thresholds.Suspect {
+			return QualityControl{}, fmt.Errorf(
+				"invalid quality control config: %s spike thresholds must be positive with fail not below suspect", name)
+		}
+	}
+
+	if config.SpikeMaxGap <= 0 {
+		return QualityControl{}, errors.New("invalid quality control config: spike max gap must be positive")
+	}
+	if config.FlatLineSuspectCount < 2 || config.FlatLineFailCount < config.FlatLineSuspectCount {
+		return QualityControl{}, errors.New(
+			"invalid quality control config: flat line counts must be at least 2 with fail not below suspect")
+	}
+
+	return QualityControl{config}, nil
+}
+
+// HistorySize is the number of observations preceding a batch that Check needs to evaluate all its tests.
+func (qc QualityControl) HistorySize() int {
+	return qc.config.FlatLineFailCount - 1
+}
+
+// Check returns the observations with their QC flags, in their order. The history holds the observations stored
+// before them, it is only used by the spike and flat line tests. Both may be given in any order.
+func (qc QualityControl) Check(observations, history []WaveData) []WaveData {
+	type entry struct {
+		waveData WaveData
+		// Position of the observation in the result, -1 for the history.
+		position int
+	}
+
+	series := make([]entry, 0, len(history)+len(observations))
+	for _, waveData := range history {
+		series = append(series, entry{waveData, -1})
+	}
+	for i, waveData := range observations {
+		series = append(series, entry{waveData, i})
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].waveData.Timestamp().Before(series[j].waveData.Timestamp())
+	})
+
+	previous := make([]WaveData, 0, len(series))
+	checked := make([]WaveData, len(observations))
+	for _, e := range series {
+		if e.position >= 0 {
+			checked[e.position] = e.waveData.WithQCFlags(QCFlags{
+				GrossRange:  qc.grossRange(e.waveData),
+				Consistency: consistency(e.waveData),
+				Spike:       qc.spike(e.waveData, previous),
+				FlatLine:    qc.flatLine(e.waveData, previous),
+			})
+		}
+		previous = append(previous, e.waveData)
+	}
+
+	return checked
+}
+
+func (qc QualityControl) grossRange(w WaveData) QCFlag {
+	rangeFlag := func(value float64, fail, suspect QCRange) QCFlag {
+		switch {
+		case !fail.contains(value):
+			return QCFlagFail
+		case !suspect.contains(value):
+			return QCFlagSuspect
+		default:
+			return QCFlagPass
+		}
+	}
+	angleFlag := func(value int, valid QCRange) QCFlag {
+		if !valid.contains(float64(value)) {
+			return QCFlagFail
+		}
+		return QCFlagPass
+	}
+
+	return worstQCFlag(
+		rangeFlag(w.averageTopThirdWaveHeight, qc.config.HeightFail, qc.config.HeightSuspect),
+		rangeFlag(w.maxHeight, qc.config.HeightFail, qc.config.HeightSuspect),
+		rangeFlag(w.averageTopThirdWavePeriod, qc.config.PeriodFail, qc.config.PeriodSuspect),
+		rangeFlag(w.temperature, qc.config.TemperatureFail, qc.config.TemperatureSuspect),
+		angleFlag(w.peakDirection, peakDirectionRange),
+		angleFlag(w.peakDirectionalSpread, peakDirectionalSpreadRange),
+	)
+}
+
+func consistency(w WaveData) QCFlag {
+	if w.maxHeight < w.averageTopThirdWaveHeight {
+		return QCFlagFail
+	}
+	return QCFlagPass
+}
+
+// spike compares an observation to the previous one, previous being sorted by timestamp.
+func (qc QualityControl) spike(w WaveData, previous []WaveData) QCFlag {
+	if len(previous) == 0 {
+		return QCFlagNotEvaluated
+	}
+	last := previous[len(previous)-1]
+	if w.timestamp.Sub(last.timestamp) > qc.config.SpikeMaxGap {
+		return QCFlagNotEvaluated
+	}
+
+	return worstQCFlag(
+		qc.config.HeightSpike.flag(math.Abs(w.averageTopThirdWaveHeight-last.averageTopThirdWaveHeight)),
+		qc.config.TemperatureSpike.flag(math.Abs(w.temperature-last.temperature)),
+	)
+}
+
+// flatLine counts the observations repeating the heights and period of an observation, previous being sorted by
+// timestamp.
+func (qc QualityControl) flatLine(w WaveData, previous []WaveData) QCFlag {
+	count := 1
+	for i := len(previous) - 1; i >= 0 && count < qc.config.FlatLineFailCount; i-- {
+		p := previous[i]
+		if p.averageTopThirdWaveHeight != w.averageTopThirdWaveHeight || p.maxHeight != w.maxHeight ||
+			p.averageTopThirdWavePeriod != w.averageTopThirdWavePeriod {
+			break
+		}
+		count++
+	}
+
+	switch {
+	case count >= qc.config.FlatLineFailCount:
+		return QCFlagFail
+	case count >= qc.config.FlatLineSuspectCount:
+		return QCFlagSuspect
+	case count == len(previous)+1:
+		// Every known observation repeats the values, the run may have started before them.
+		return QCFlagNotEvaluated
+	default:
+		return QCFlagPass
+	}
+}
@@ -0,0 +1,213 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+func TestQCFlags_Aggregate(t *testing.T) {
+	testCases := map[string]struct {
+		flags    model.QCFlags
+		expected model.QCFlag
+	}{
+		"not checked": {
+			flags:    model.QCFlags{},
+			expected: model.QCFlagNotEvaluated,
+		},
+		"not evaluated tests do not hide passed ones": {
+			flags:    model.QCFlags{GrossRange: model.QCFlagPass, Spike: model.QCFlagNotEvaluated},
+			expected: model.QCFlagPass,
+		},
+		"suspect": {
+			flags:    model.QCFlags{GrossRange: model.QCFlagPass, Spike: model.QCFlagSuspect},
+			expected: model.QCFlagSuspect,
+		},
+		"fail": {
+			flags:    model.QCFlags{GrossRange: model.QCFlagFail, Spike: model.QCFlagSuspect},
+			expected: model.QCFlagFail,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.flags.Aggregate())
+		})
+	}
+}
+
+func TestQualityControl_Check_GrossRangeAndConsistency(t *testing.T) {
+	qc := modeltest.MustCreateQualityControl(t, modeltest.QCConfig())
+
+	testCases := map[string]struct {
+		waveData    model.WaveData
+		grossRange  model.QCFlag
+		consistency model.QCFlag
+	}{
+		"plausible": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0"),
+			grossRange:  model.QCFlagPass,
+			consistency: model.QCFlagPass,
+		},
+		"implausible height": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "22.0", "10.5", "90", "30", "20.0"),
+			grossRange:  model.QCFlagSuspect,
+			consistency: model.QCFlagPass,
+		},
+		"impossible temperature": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "200.0"),
+			grossRange:  model.QCFlagFail,
+			consistency: model.QCFlagPass,
+		},
+		"absurd period": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "95.0", "90", "30", "20.0"),
+			grossRange:  model.QCFlagFail,
+			consistency: model.QCFlagPass,
+		},
+		"direction outside 0-360": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "400", "30", "20.0"),
+			grossRange:  model.QCFlagFail,
+			consistency: model.QCFlagPass,
+		},
+		"spread outside 0-180": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "-5", "20.0"),
+			grossRange:  model.QCFlagFail,
+			consistency: model.QCFlagPass,
+		},
+		"max height below significant height": {
+			waveData:    modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "2.0", "10.5", "90", "30", "20.0"),
+			grossRange:  model.QCFlagPass,
+			consistency: model.QCFlagFail,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			checked := qc.Check([]model.WaveData{tc.waveData}, nil)
+			require.Len(t, checked, 1)
+			assert.Equal(t, model.QCFlags{
+				GrossRange:  tc.grossRange,
+				Consistency: tc.consistency,
+				Spike:       model.QCFlagNotEvaluated,
+				FlatLine:    model.QCFlagNotEvaluated,
+			}, checked[0].QCFlags())
+		})
+	}
+}
+
+func TestQualityControl_Check_Spike(t *testing.T) {
+	qc := modeltest.MustCreateQualityControl(t, modeltest.QCConfig())
+	history := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "07/10/2024", "13:30", "2.5", "4.0", "10.5", "90", "30", "20.0"),
+	}
+
+	testCases := map[string]struct {
+		waveData model.WaveData
+		expected model.QCFlag
+	}{
+		"steady": {
+			waveData: modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.7", "4.2", "10.5", "90", "30", "20.1"),
+			expected: model.QCFlagPass,
+		},
+		"height jump": {
+			waveData: modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "5.0", "7.0", "10.5", "90", "30", "20.0"),
+			expected: model.QCFlagSuspect,
+		},
+		"temperature jump": {
+			waveData: modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.1", "10.5", "90", "30", "26.0"),
+			expected: model.QCFlagFail,
+		},
+		"previous observation too old": {
+			waveData: modeltest.MustCreateWaveData(t, "07/10/2024", "16:00", "5.0", "7.0", "10.5", "90", "30", "20.0"),
+			expected: model.QCFlagNotEvaluated,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			checked := qc.Check([]model.WaveData{tc.waveData}, history)
+			require.Len(t, checked, 1)
+			assert.Equal(t, tc.expected, checked[0].QCFlags().Spike)
+		})
+	}
+}
+
+func TestQualityControl_Check_FlatLine(t *testing.T) {
+	config := modeltest.QCConfig()
+	config.FlatLineSuspectCount = 3
+	config.FlatLineFailCount = 4
+	qc := modeltest.MustCreateQualityControl(t, config)
+
+	start := time.Date(2024, 10, 7, 12, 0, 0, 0, time.UTC)
+	observation := func(i int, h13 string) model.WaveData {
+		timestamp := start.Add(time.Duration(i) * 30 * time.Minute)
+		return modeltest.MustCreateWaveData(t, timestamp.Format("02/01/2006"), timestamp.Format("15:04"),
+			h13, "4.0", "10.5", "90", "30", "20.0")
+	}
+	history := []model.WaveData{observation(1, "2.5"), observation(0, "2.4")}
+	// Most recent first, as in the Candhis table.
+	observations := []model.WaveData{observation(4, "2.5"), observation(3, "2.5"), observation(2, "2.5")}
+
+	checked := qc.Check(observations, history)
+
+	require.Len(t, checked, 3)
+	assert.Equal(t, observations[0].Timestamp(), checked[0].Timestamp())
+	assert.Equal(t, model.QCFlagFail, checked[0].QCFlags().FlatLine)
+	assert.Equal(t, model.QCFlagSuspect, checked[1].QCFlags().FlatLine)
+	assert.Equal(t, model.QCFlagPass, checked[2].QCFlags().FlatLine)
+}
+
+func TestQualityControl_Check_FlatLineWithoutHistory(t *testing.T) {
+	qc := modeltest.MustCreateQualityControl(t, modeltest.QCConfig())
+
+	checked := qc.Check([]model.WaveData{
+		modeltest.MustCreateWaveData(t, "07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0"),
+		modeltest.MustCreateWaveData(t, "07/10/2024", "13:30", "2.3", "4.0", "10.5", "90", "30", "20.0"),
+	}, nil)
+
+	require.Len(t, checked, 2)
+	assert.Equal(t, model.QCFlagPass, checked[0].QCFlags().FlatLine)
+	assert.Equal(t, model.QCFlagNotEvaluated, checked[1].QCFlags().FlatLine)
+}
+
+func TestNewQualityControl_InvalidConfig(t *testing.T) {
+	testCases := map[string]struct {
+		update func(config *model.QCConfig)
+		errMsg string
+	}{
+		"empty range": {
+			update: func(config *model.QCConfig) { config.PeriodFail = model.QCRange{Min: 10, Max: 10} },
+			errMsg: "invalid quality control config: period ranges must have min below max",
+		},
+		"suspect range wider than fail range": {
+			update: func(config *model.QCConfig) { config.TemperatureSuspect = model.QCRange{Min: -10, Max: 30} },
+			errMsg: "invalid quality control config: temperature suspect range must be within the fail range",
+		},
+		"fail spike below suspect spike": {
+			update: func(config *model.QCConfig) { config.HeightSpike = model.QCThresholds{Suspect: 3, Fail: 2} },
+			errMsg: "invalid quality control config: height spike thresholds must be positive with fail not below suspect",
+		},
+		"no spike gap": {
+			update: func(config *model.QCConfig) { config.SpikeMaxGap = 0 },
+			errMsg: "invalid quality control config: spike max gap must be positive",
+		},
+		"fail count below suspect count": {
+			update: func(config *model.QCConfig) { config.FlatLineFailCount = 4 },
+			errMsg: "invalid quality control config: flat line counts must be at least 2 with fail not below suspect",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config := modeltest.QCConfig()
+			tc.update(&config)
+
+			_, err := model.NewQualityControl(config)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
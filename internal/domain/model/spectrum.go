@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SpectrumBin is the energy of the waves of a frequency band of a spectrum.
+type SpectrumBin struct {
+	// Center of the frequency band in Hz.
+	Frequency float64
+	// Energy density of the band in m²/Hz.
+	EnergyDensity float64
+	// Mean direction of wave origin of the band in degrees, measured like the peak direction of WaveData.
+	Direction float64
+	// Directional spread of the band in degrees.
+	Spread float64
+}
+
+// Spectrum is a directional wave spectrum measured by a buoy, its bins are ordered by increasing frequency.
+type Spectrum struct {
+	timestamp time.Time
+	bins      []SpectrumBin
+}
+
+func NewSpectrum(timestamp time.Time, bins []SpectrumBin) (Spectrum, error) {
+	if timestamp.Location() != time.UTC {
+		return Spectrum{}, fmt.Errorf("%w: spectrum time must be in UTC format", ErrInvalidObservation)
+	}
+	if len(bins) == 0 {
+		return Spectrum{}, fmt.Errorf("%w: spectrum has no frequency bin", ErrInvalidObservation)
+	}
+
+	for i, bin := range bins {
+		if bin.Frequency <= 0 || (i > 0 && bin.Frequency <= bins[i-1].Frequency) {
+			return Spectrum{}, fmt.Errorf("%w: spectrum frequencies must be positive and increasing", ErrInvalidObservation)
+		}
+		if bin.EnergyDensity < 0 || bin.Spread < 0 {
+			return Spectrum{}, fmt.Errorf("%w: negative energy density or spread at %g Hz", ErrInvalidObservation, bin.Frequency)
+		}
+		if bin.Direction < 0 || bin.Direction > 360 {
+			return Spectrum{}, fmt.Errorf("%w: direction out of 0 to 360 degrees at %g Hz", ErrInvalidObservation, bin.Frequency)
+		}
+	}
+
+	return Spectrum{
+		timestamp: timestamp,
+		bins:      slices.Clone(bins),
+	}, nil
+}
+
+func (s Spectrum) Timestamp() time.Time {
+	return s.timestamp
+}
+
+func (s Spectrum) Bins() []SpectrumBin {
+	return slices.Clone(s.bins)
+}
+
+// The bins are written as parallel arrays, one per quantity, which Elasticsearch stores as is.
+type spectrumJSON struct {
+	Timestamp     string    `json:"timestamp"`
+	Frequency     []float64 `json:"frequency"`
+	EnergyDensity []float64 `json:"energy_density"`
+	Direction     []float64 `json:"direction"`
+	Spread        []float64 `json:"spread"`
+}
+
+func (s Spectrum) MarshalJSON() ([]byte, error) {
+	data := spectrumJSON{
+		Timestamp:     s.timestamp.Format(time.RFC3339),
+		Frequency:     make([]float64, len(s.bins)),
+		EnergyDensity: make([]float64, len(s.bins)),
+		Direction:     make([]float64, len(s.bins)),
+		Spread:        make([]float64, len(s.bins)),
+	}
+	for i, bin := range s.bins {
+		data.Frequency[i] = bin.Frequency
+		data.EnergyDensity[i] = bin.EnergyDensity
+		data.Direction[i] = bin.Direction
+		data.Spread[i] = bin.Spread
+	}
+	return json.Marshal(data)
+}
+
+func (s *Spectrum) UnmarshalJSON(data []byte) error {
+	var aux spectrumJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, aux.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	n := len(aux.Frequency)
+	if len(aux.EnergyDensity) != n || len(aux.Direction) != n || len(aux.Spread) != n {
+		return fmt.Errorf("spectrum arrays of different lengths")
+	}
+	bins := make([]SpectrumBin, n)
+	for i := range bins {
+		bins[i] = SpectrumBin{
+			Frequency:     aux.Frequency[i],
+			EnergyDensity: aux.EnergyDensity[i],
+			Direction:     aux.Direction[i],
+			Spread:        aux.Spread[i],
+		}
+	}
+
+	*s = Spectrum{
+		timestamp: timestamp.UTC(),
+		bins:      bins,
+	}
+
+	return nil
+}
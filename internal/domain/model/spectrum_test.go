@@ -0,0 +1,108 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+var spectrumTimestamp = time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+
+func TestNewSpectrumSuccess(t *testing.T) {
+	bins := []model.SpectrumBin{
+		{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+	}
+
+	spectrum, err := model.NewSpectrum(spectrumTimestamp, bins)
+	require.NoError(t, err)
+
+	assert.Equal(t, spectrumTimestamp, spectrum.Timestamp())
+	assert.Equal(t, bins, spectrum.Bins())
+
+	// The spectrum keeps its own bins.
+	bins[0].EnergyDensity = 1
+	spectrum.Bins()[1].EnergyDensity = 1
+	assert.Equal(t, 0.012, spectrum.Bins()[0].EnergyDensity)
+	assert.Equal(t, 0.35, spectrum.Bins()[1].EnergyDensity)
+}
+
+func TestNewSpectrumFailure(t *testing.T) {
+	testCases := map[string]struct {
+		timestamp time.Time
+		bins      []model.SpectrumBin
+		errMsg    string
+	}{
+		"not UTC": {
+			timestamp: spectrumTimestamp.In(time.FixedZone("CEST", 2*60*60)),
+			bins:      []model.SpectrumBin{{Frequency: 0.04}},
+			errMsg:    "invalid observation: spectrum time must be in UTC format",
+		},
+		"no bin": {
+			timestamp: spectrumTimestamp,
+			errMsg:    "invalid observation: spectrum has no frequency bin",
+		},
+		"zero frequency": {
+			timestamp: spectrumTimestamp,
+			bins:      []model.SpectrumBin{{Frequency: 0}},
+			errMsg:    "invalid observation: spectrum frequencies must be positive and increasing",
+		},
+		"decreasing frequencies": {
+			timestamp: spectrumTimestamp,
+			bins:      []model.SpectrumBin{{Frequency: 0.05}, {Frequency: 0.04}},
+			errMsg:    "invalid observation: spectrum frequencies must be positive and increasing",
+		},
+		"negative energy density": {
+			timestamp: spectrumTimestamp,
+			bins:      []model.SpectrumBin{{Frequency: 0.04, EnergyDensity: -0.1}},
+			errMsg:    "invalid observation: negative energy density or spread at 0.04 Hz",
+		},
+		"direction out of range": {
+			timestamp: spectrumTimestamp,
+			bins:      []model.SpectrumBin{{Frequency: 0.04, Direction: 400}},
+			errMsg:    "invalid observation: direction out of 0 to 360 degrees at 0.04 Hz",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := model.NewSpectrum(tc.timestamp, tc.bins)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.ErrorIs(t, err, model.ErrInvalidObservation)
+		})
+	}
+}
+
+func TestSpectrumJSON(t *testing.T) {
+	spectrum, err := model.NewSpectrum(spectrumTimestamp, []model.SpectrumBin{
+		{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+	})
+	require.NoError(t, err)
+
+	jsonData, err := json.Marshal(spectrum)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"timestamp": "2024-09-17T09:00:00Z",
+		"frequency": [0.04, 0.05],
+		"energy_density": [0.012, 0.35],
+		"direction": [285, 280],
+		"spread": [32, 25]
+	}`, string(jsonData))
+
+	var decoded model.Spectrum
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, spectrum, decoded)
+}
+
+func TestSpectrumUnmarshalJSON_DifferentLengths(t *testing.T) {
+	var spectrum model.Spectrum
+	err := json.Unmarshal([]byte(`{"timestamp": "2024-09-17T09:00:00Z", "frequency": [0.04, 0.05],
+		"energy_density": [0.012], "direction": [285, 280], "spread": [32, 25]}`), &spectrum)
+	assert.EqualError(t, err, "spectrum arrays of different lengths")
+}
@@ -3,10 +3,14 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 )
 
+// ErrInvalidObservation is returned for an observation whose values cannot be parsed or are impossible.
+var ErrInvalidObservation = errors.New("invalid observation")
+
 type WaveData struct {
 	// timestamp of the observation.
 	timestamp time.Time
@@ -25,6 +29,10 @@ type WaveData struct {
 	peakDirectionalSpread int
 	// Water temperature in degrees Celsius at the time of the observation.
 	temperature float64
+	// Optional measurements, nil when the buoy publishes none.
+	measurements map[Measurement]float64
+	// Quality control flags, set by QualityControl.Check.
+	qcFlags QCFlags
 }
 
 func NewWaveData(
@@ -40,41 +48,42 @@ func NewWaveData(
 	datetimeStr := dateStr + " " + timeStr
 	timestamp, err := time.Parse("02/01/2006 15:04", datetimeStr)
 	if err != nil {
-		return WaveData{}, errors.New("invalid date or time format, expected DD/MM/YYYY and HH:MM")
+		return WaveData{}, fmt.Errorf("%w: invalid date or time format, expected DD/MM/YYYY and HH:MM", ErrInvalidObservation)
 	}
 
 	averageTopThirdWaveHeight, err := strconv.ParseFloat(averageTopThirdWaveHeightStr, 64)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for averageTopThirdWaveHeight")
+		return WaveData{}, fmt.Errorf("%w: invalid value for averageTopThirdWaveHeight", ErrInvalidObservation)
 	}
 
 	maxHeight, err := strconv.ParseFloat(maxHeightStr, 64)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for maxHeight")
+		return WaveData{}, fmt.Errorf("%w: invalid value for maxHeight", ErrInvalidObservation)
 	}
 
 	averageTopThirdWavePeriod, err := strconv.ParseFloat(averageTopThirdWavePeriodStr, 64)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for averageTopThirdWavePeriod")
+		return WaveData{}, fmt.Errorf("%w: invalid value for averageTopThirdWavePeriod", ErrInvalidObservation)
 	}
 
 	peakDirection, err := strconv.Atoi(peakDirectionStr)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for peakDirection")
+		return WaveData{}, fmt.Errorf("%w: invalid value for peakDirection", ErrInvalidObservation)
 	}
 
 	peakDirectionalSpread, err := strconv.Atoi(peakDirectionalSpreadStr)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for peakDirectionalSpread")
+		return WaveData{}, fmt.Errorf("%w: invalid value for peakDirectionalSpread", ErrInvalidObservation)
 	}
 
 	temperature, err := strconv.ParseFloat(temperatureStr, 64)
 	if err != nil {
-		return WaveData{}, errors.New("invalid value for temperature")
+		return WaveData{}, fmt.Errorf("%w: invalid value for temperature", ErrInvalidObservation)
 	}
 
 	if averageTopThirdWaveHeight < 0 || maxHeight < 0 || averageTopThirdWavePeriod < 0 || temperature < -273.15 {
-		return WaveData{}, errors.New("invalid input: negative values for heights, periods, or temperature below absolute zero")
+		return WaveData{}, fmt.Errorf(
+			"%w: invalid input: negative values for heights, periods, or temperature below absolute zero", ErrInvalidObservation)
 	}
 
 	return WaveData{
@@ -85,6 +94,8 @@ func NewWaveData(
 		peakDirection,
 		peakDirectionalSpread,
 		temperature,
+		nil,
+		QCFlags{},
 	}, nil
 }
 
@@ -116,6 +127,15 @@ func (w WaveData) Temperature() float64 {
 	return w.temperature
 }
 
+func (w WaveData) QCFlags() QCFlags {
+	return w.qcFlags
+}
+
+func (w WaveData) WithQCFlags(flags QCFlags) WaveData {
+	w.qcFlags = flags
+	return w
+}
+
 type waveDataJSON struct {
 	Timestamp                 string  `json:"timestamp"`
 	AverageTopThirdWaveHeight float64 `json:"h1_3"`
@@ -124,6 +144,39 @@ type waveDataJSON struct {
 	PeakDirection             int     `json:"peak_direction"`
 	PeakDirectionalSpread     int     `json:"peak_directional_spread"`
 	Temperature               float64 `json:"temperature"`
+	// The optional measurements are left out when the buoy does not publish them.
+	PeakPeriod         *float64 `json:"tp,omitempty"`
+	MeanPeriod         *float64 `json:"tz,omitempty"`
+	MeanDirection      *float64 `json:"mean_direction,omitempty"`
+	SpectralWaveHeight *float64 `json:"hm0,omitempty"`
+	WindSpeed          *float64 `json:"wind_speed,omitempty"`
+	WindDirection      *float64 `json:"wind_direction,omitempty"`
+	// The QC fields are left out of observations that were not checked. The aggregated flag is only written to be
+	// searched on.
+	QCFlag        QCFlag `json:"qc_flag,omitempty"`
+	QCGrossRange  QCFlag `json:"qc_gross_range,omitempty"`
+	QCConsistency QCFlag `json:"qc_consistency,omitempty"`
+	QCSpike       QCFlag `json:"qc_spike,omitempty"`
+	QCFlatLine    QCFlag `json:"qc_flat_line,omitempty"`
+}
+
+func (j *waveDataJSON) measurement(m Measurement) **float64 {
+	switch m {
+	case MeasurementPeakPeriod:
+		return &j.PeakPeriod
+	case MeasurementMeanPeriod:
+		return &j.MeanPeriod
+	case MeasurementMeanDirection:
+		return &j.MeanDirection
+	case MeasurementSpectralWaveHeight:
+		return &j.SpectralWaveHeight
+	case MeasurementWindSpeed:
+		return &j.WindSpeed
+	case MeasurementWindDirection:
+		return &j.WindDirection
+	default:
+		return nil
+	}
 }
 
 func (w WaveData) MarshalJSON() ([]byte, error) {
@@ -136,6 +189,16 @@ func (w WaveData) MarshalJSON() ([]byte, error) {
 		PeakDirectionalSpread:     w.peakDirectionalSpread,
 		Temperature:               w.temperature,
 	}
+	for m, value := range w.measurements {
+		*data.measurement(m) = &value
+	}
+	if w.qcFlags.Evaluated() {
+		data.QCFlag = w.qcFlags.Aggregate()
+		data.QCGrossRange = w.qcFlags.GrossRange
+		data.QCConsistency = w.qcFlags.Consistency
+		data.QCSpike = w.qcFlags.Spike
+		data.QCFlatLine = w.qcFlags.FlatLine
+	}
 	return json.Marshal(data)
 }
 
@@ -158,6 +221,20 @@ func (w *WaveData) UnmarshalJSON(data []byte) error {
 		peakDirection:             aux.PeakDirection,
 		peakDirectionalSpread:     aux.PeakDirectionalSpread,
 		temperature:               aux.Temperature,
+		qcFlags: QCFlags{
+			GrossRange:  aux.QCGrossRange,
+			Consistency: aux.QCConsistency,
+			Spike:       aux.QCSpike,
+			FlatLine:    aux.QCFlatLine,
+		},
+	}
+	for _, m := range Measurements {
+		if value := *aux.measurement(m); value != nil {
+			if w.measurements == nil {
+				w.measurements = make(map[Measurement]float64)
+			}
+			w.measurements[m] = *value
+		}
 	}
 
 	return nil
@@ -186,7 +186,8 @@ func TestNewWaveDataFailure(t *testing.T) {
 				tc.peakDirectionalSpread,
 				tc.temperature,
 			)
-			assert.EqualError(t, err, tc.errMsg)
+			assert.ErrorIs(t, err, model.ErrInvalidObservation)
+			assert.ErrorContains(t, err, tc.errMsg)
 			assert.Equal(t, model.WaveData{}, waveData)
 		})
 	}
@@ -237,3 +238,36 @@ func TestWaveDataUnmarshalJSON(t *testing.T) {
 	assert.Equal(t, 30, waveData.PeakDirectionalSpread())
 	assert.Equal(t, 20.0, waveData.Temperature())
 }
+
+func TestWaveDataJSON_QCFlags(t *testing.T) {
+	waveData, err := model.NewWaveData("07/10/2024", "14:00", "2.5", "4.0", "10.5", "90", "30", "20.0")
+	require.NoError(t, err)
+	flags := model.QCFlags{
+		GrossRange:  model.QCFlagPass,
+		Consistency: model.QCFlagPass,
+		Spike:       model.QCFlagSuspect,
+		FlatLine:    model.QCFlagNotEvaluated,
+	}
+
+	jsonData, err := json.Marshal(waveData.WithQCFlags(flags))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"timestamp": "2024-10-07T14:00:00Z",
+		"h1_3": 2.5,
+		"hmax": 4.0,
+		"th1_3": 10.5,
+		"peak_direction": 90,
+		"peak_directional_spread": 30,
+		"temperature": 20.0,
+		"qc_flag": 3,
+		"qc_gross_range": 1,
+		"qc_consistency": 1,
+		"qc_spike": 3,
+		"qc_flat_line": 2
+	}`, string(jsonData))
+
+	var decoded model.WaveData
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, flags, decoded.QCFlags())
+}
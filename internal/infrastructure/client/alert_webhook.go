@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+const (
+	AlertWebhookTimestampHeader = "X-Candhis-Timestamp"
+	AlertWebhookSignatureHeader = "X-Candhis-Signature"
+
+	alertWebhookRequestTimeout = 10 * time.Second
+)
+
+type alertNotificationJSON struct {
+	RuleID     int64     `json:"rule_id"`
+	RuleName   string    `json:"rule_name"`
+	Campaign   string    `json:"campaign"`
+	Metric     string    `json:"metric"`
+	Comparator string    `json:"comparator"`
+	Threshold  float64   `json:"threshold"`
+	State      string    `json:"state"`
+	Value      float64   `json:"value"`
+	ObservedAt time.Time `json:"observed_at"`
+	FiredAt    time.Time `json:"fired_at"`
+}
+
+type alertWebhook struct {
+	client *http.Client
+	secret []byte
+	// Hosts the notifications may be posted to, the rules stored before their host was removed from them are not sent.
+	allowedHosts appmodel.AlertWebhookHosts
+	// Number of requests sent before giving up, the wait before a retry doubles from backoff.
+	attempts int
+	backoff  time.Duration
+}
+
+func NewAlertWebhook(
+	client *http.Client,
+	secret string,
+	allowedHosts appmodel.AlertWebhookHosts,
+	attempts int,
+	backoff time.Duration,
+) *alertWebhook {
+	return &alertWebhook{client, []byte(secret), allowedHosts, max(attempts, 1), backoff}
+}
+
+// Send posts the notification as JSON. The request is signed with an HMAC-SHA256 of its timestamp header, a dot and
+// its body, keyed by the shared secret. Network errors, 429 and 5xx responses are retried. A webhook URL whose host is
+// not allowed is not requested.
+func (w *alertWebhook) Send(
+	ctx context.Context,
+	webhookURL string,
+	notification appmodel.AlertNotification,
+) (int, error) {
+	if err := w.allowedHosts.Check(webhookURL); err != nil {
+		return 0, err
+	}
+
+	rule := notification.Rule
+	body, err := json.Marshal(alertNotificationJSON{
+		RuleID:     rule.ID(),
+		RuleName:   rule.Name(),
+		Campaign:   rule.Campaign(),
+		Metric:     string(rule.Metric()),
+		Comparator: string(rule.Comparator()),
+		Threshold:  rule.Threshold(),
+		State:      string(notification.State),
+		Value:      notification.Value,
+		ObservedAt: notification.ObservedAt,
+		FiredAt:    notification.FiredAt,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal alert notification to JSON: %w", err)
+	}
+
+	backoff := w.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(ctx, webhookURL, body)
+		if err == nil {
+			return attempt, nil
+		}
+		if !retry || attempt == w.attempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, fmt.Errorf("%w, retry cancelled: %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends a single request, retry tells whether a failed request may succeed later.
+func (w *alertWebhook) post(ctx context.Context, webhookURL string, body []byte) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, alertWebhookRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request, url: %s, error: %w", webhookURL, err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AlertWebhookTimestampHeader, timestamp)
+	req.Header.Set(AlertWebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to perform request, url: %s, error: %w", webhookURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("unexpected status code %d, url: %s", resp.StatusCode, webhookURL)
+	}
+	return false, nil
+}
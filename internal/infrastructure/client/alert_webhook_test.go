@@ -0,0 +1,131 @@
+package client_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+)
+
+const (
+	alertWebhookURL    = "https://hooks.example.com/candhis"
+	alertWebhookSecret = "webhook-secret"
+)
+
+var alertWebhookHosts = appmodel.AlertWebhookHosts{"hooks.example.com"}
+
+func TestAlertWebhook_Send_Success(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	webhook := client.NewAlertWebhook(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			body, _ := io.ReadAll(req.Body)
+			requests = append(requests, req)
+			bodies = append(bodies, string(body))
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}
+		},
+	}}, alertWebhookSecret, alertWebhookHosts, 3, time.Millisecond)
+
+	attempts, err := webhook.Send(context.Background(), alertWebhookURL, testAlertNotification(t))
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+
+	require.Len(t, requests, 1)
+	req := requests[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, alertWebhookURL, req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"rule_id": 7, "rule_name": "Big swell", "campaign": "les-pierres-noires", "metric": "hmax", "comparator": "gt",
+		"threshold": 4, "state": "firing", "value": 4.6,
+		"observed_at": "2024-09-17T10:00:00Z", "fired_at": "2024-09-17T10:05:00Z"
+	}`, bodies[0])
+
+	mac := hmac.New(sha256.New, []byte(alertWebhookSecret))
+	mac.Write([]byte(req.Header.Get(client.AlertWebhookTimestampHeader) + "." + bodies[0]))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get(client.AlertWebhookSignatureHeader))
+}
+
+func TestAlertWebhook_Send_Retries(t *testing.T) {
+	testCases := map[string]struct {
+		statusCodes      []int
+		expectedAttempts int
+		expectedError    string
+	}{
+		"server error then success": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK},
+			expectedAttempts: 3,
+		},
+		"attempts exhausted": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusServiceUnavailable},
+			expectedAttempts: 3,
+			expectedError:    "unexpected status code 503, url: " + alertWebhookURL,
+		},
+		"client error not retried": {
+			statusCodes:      []int{http.StatusBadRequest},
+			expectedAttempts: 1,
+			expectedError:    "unexpected status code 400, url: " + alertWebhookURL,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			webhook := client.NewAlertWebhook(&http.Client{Transport: &mockRoundTripper{
+				mockHandler: func(_ *http.Request) *http.Response {
+					statusCode := tc.statusCodes[calls]
+					calls++
+					return &http.Response{StatusCode: statusCode, Body: io.NopCloser(strings.NewReader(""))}
+				},
+			}}, alertWebhookSecret, alertWebhookHosts, 3, time.Millisecond)
+
+			attempts, err := webhook.Send(context.Background(), alertWebhookURL, testAlertNotification(t))
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.Equal(t, tc.expectedAttempts, calls)
+		})
+	}
+}
+
+func TestAlertWebhook_Send_HostNotAllowed(t *testing.T) {
+	calls := 0
+	webhook := client.NewAlertWebhook(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(_ *http.Request) *http.Response {
+			calls++
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+		},
+	}}, alertWebhookSecret, alertWebhookHosts, 3, time.Millisecond)
+
+	attempts, err := webhook.Send(context.Background(), "https://internal.example.org/candhis", testAlertNotification(t))
+	assert.EqualError(t, err, "invalid alert rule: webhook host is not allowed: internal.example.org")
+	assert.Equal(t, 0, attempts)
+	assert.Equal(t, 0, calls)
+}
+
+func testAlertNotification(t *testing.T) appmodel.AlertNotification {
+	t.Helper()
+
+	return appmodel.AlertNotification{
+		Rule: appmodeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", appmodel.AlertMetricMaxHeight,
+			appmodel.AlertComparatorGreaterThan, 4, 0, 6*time.Hour, alertWebhookURL, true),
+		State:      appmodel.AlertStateFiring,
+		Value:      4.6,
+		ObservedAt: time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC),
+		FiredAt:    time.Date(2024, 9, 17, 10, 5, 0, 0, time.UTC),
+	}
+}
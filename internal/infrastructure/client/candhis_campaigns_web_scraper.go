@@ -1,75 +1,291 @@
 package client
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
-	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/domain/model"
 )
 
-const expectedCellsNum = 8
+const (
+	waveDataTableSelector = "table.table-striped.table-bordered.table-sm"
+	archiveDateLayout     = "02/01/2006"
+)
+
+type waveDataColumn int
+
+const (
+	waveDataColumnDate waveDataColumn = iota
+	waveDataColumnTime
+	waveDataColumnAverageTopThirdWaveHeight
+	waveDataColumnMaxHeight
+	waveDataColumnAverageTopThirdWavePeriod
+	waveDataColumnPeakDirection
+	waveDataColumnPeakDirectionalSpread
+	waveDataColumnTemperature
+	// The columns of the optional measurements follow, only some buoys publish them.
+	waveDataColumnPeakPeriod
+	waveDataColumnMeanPeriod
+	waveDataColumnMeanDirection
+	waveDataColumnSpectralWaveHeight
+	waveDataColumnWindSpeed
+	waveDataColumnWindDirection
+)
+
+// waveDataHeaders are the header cells of the wave data columns, in the order Candhis serves them.
+var waveDataHeaders = []string{
+	waveDataColumnDate:                      "Date",
+	waveDataColumnTime:                      "Heure (TU)",
+	waveDataColumnAverageTopThirdWaveHeight: "H1/3 (m)",
+	waveDataColumnMaxHeight:                 "Hmax (m)",
+	waveDataColumnAverageTopThirdWavePeriod: "Th1/3 (s)",
+	waveDataColumnPeakDirection:             "Dir. au pic (°)",
+	waveDataColumnPeakDirectionalSpread:     "Etal. au pic (°)",
+	waveDataColumnTemperature:               "Temp. mer (°C)",
+	waveDataColumnPeakPeriod:                "Tp (s)",
+	waveDataColumnMeanPeriod:                "Tz (s)",
+	waveDataColumnMeanDirection:             "Dir. moy. (°)",
+	waveDataColumnSpectralWaveHeight:        "Hm0 (m)",
+	waveDataColumnWindSpeed:                 "Vent (m/s)",
+	waveDataColumnWindDirection:             "Dir. vent (°)",
+}
+
+var waveDataMeasurements = map[waveDataColumn]model.Measurement{
+	waveDataColumnPeakPeriod:         model.MeasurementPeakPeriod,
+	waveDataColumnMeanPeriod:         model.MeasurementMeanPeriod,
+	waveDataColumnMeanDirection:      model.MeasurementMeanDirection,
+	waveDataColumnSpectralWaveHeight: model.MeasurementSpectralWaveHeight,
+	waveDataColumnWindSpeed:          model.MeasurementWindSpeed,
+	waveDataColumnWindDirection:      model.MeasurementWindDirection,
+}
 
 type candhisCampaignsWebScraper struct {
-	client *http.Client
+	client *candhisHTTPClient
 }
 
-func NewCandhisCampaignsWebScraper(client *http.Client) *candhisCampaignsWebScraper {
+func NewCandhisCampaignsWebScraper(client *candhisHTTPClient) *candhisCampaignsWebScraper {
 	return &candhisCampaignsWebScraper{client}
 }
 
 func (c *candhisCampaignsWebScraper) GatherWavesDataFromWebTable(
+	ctx context.Context,
 	candhisSessionID appmodel.CandhisSessionID,
 	candhisURL string,
-) ([]model.WaveData, error) {
-	req, err := http.NewRequest(http.MethodGet, candhisURL, http.NoBody)
+) (appmodel.WaveDataTable, error) {
+	doc, err := getCandhisPage(ctx, c.client, candhisSessionID, candhisURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request, url: %s, error: %w", candhisURL, err)
+		return appmodel.WaveDataTable{}, err
 	}
 
-	req.Header.Set("Accept", "text/html")
-	req.Header.Set("Cookie", fmt.Sprintf("acceptCookies=true; %s", candhisSessionID.ID()))
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to perform request, url: %s, error: %w", candhisURL, err)
+	tables := doc.Find(waveDataTableSelector)
+	if tables.Length() == 0 {
+		// Without a valid session Candhis serves its cookie page instead of the campaign.
+		return appmodel.WaveDataTable{}, &appmodel.MissingContentError{Detail: "no wave data table in page"}
 	}
-	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed parse HTML: %w", err)
+	headers := make([][]string, tables.Length())
+	tables.Each(func(index int, t *goquery.Selection) {
+		t.Find("th").Each(func(_ int, th *goquery.Selection) {
+			headers[index] = append(headers[index], strings.TrimSpace(th.Text()))
+		})
+	})
+
+	var table appmodel.WaveDataTable
+	fingerprint := layoutFingerprint(headers)
+	if tables.Find("th, td").Length() > 0 {
+		table.LayoutFingerprint = fingerprint
 	}
 
-	var waveDataList []model.WaveData
-	doc.Find("table.table-striped.table-bordered.table-sm").Each(func(index int, table *goquery.Selection) {
-		table.Find("tr").Each(func(rowIndex int, row *goquery.Selection) {
-			waveData, err := c.parseRowOfWebTable(row.Find("td"))
+	layoutRejected := 0
+	for index := range tables.Length() {
+		rows := tables.Eq(index).Find("tr").FilterFunction(func(_ int, row *goquery.Selection) bool {
+			// Header rows only hold th cells.
+			return row.Find("td").Length() > 0
+		})
+		if rows.Length() == 0 {
+			continue
+		}
+
+		columns, err := waveDataColumns(headers[index], fingerprint)
+		if err != nil {
+			return appmodel.WaveDataTable{}, err
+		}
+		if !columnsInOrder(columns) {
+			table.LayoutChanged = true
+		}
+
+		rows.Each(func(_ int, row *goquery.Selection) {
+			table.RowsSeen++
+			waveData, err := parseRowOfWebTable(row.Find("td"), len(headers[index]), columns)
+			if errors.Is(err, appmodel.ErrLayoutChanged) {
+				layoutRejected++
+			}
 			if err != nil {
-				log.Printf("Skipping row due to error: %v", err)
+				table.Rejected = append(table.Rejected, appmodel.RejectedRow{Row: table.RowsSeen, Reason: err.Error()})
 				return
 			}
 
-			waveDataList = append(waveDataList, waveData)
+			table.WaveData = append(table.WaveData, waveData)
 		})
-	})
+	}
 
-	return waveDataList, nil
+	if table.RowsSeen > 0 && layoutRejected == table.RowsSeen {
+		return appmodel.WaveDataTable{}, fmt.Errorf("%w: none of the %d rows of the wave data table has as many cells "+
+			"as its header", appmodel.ErrLayoutChanged, table.RowsSeen)
+	}
+
+	return table, nil
 }
 
-func (c *candhisCampaignsWebScraper) parseRowOfWebTable(cells *goquery.Selection) (model.WaveData, error) {
-	if cells.Length() != expectedCellsNum {
-		return model.WaveData{}, fmt.Errorf("expected %d cells, but got %d", expectedCellsNum, cells.Length())
+func (c *candhisCampaignsWebScraper) GatherArchivedWavesDataFromWebTable(
+	ctx context.Context,
+	candhisSessionID appmodel.CandhisSessionID,
+	candhisURL string,
+	from, to time.Time,
+) (appmodel.WaveDataTable, error) {
+	archiveURL, err := archiveURL(candhisURL, from, to)
+	if err != nil {
+		return appmodel.WaveDataTable{}, err
 	}
 
-	values := make([]string, expectedCellsNum)
-	cells.Each(func(cellIndex int, cell *goquery.Selection) {
-		values[cellIndex] = strings.TrimSpace(cell.Text())
-	})
+	return c.GatherWavesDataFromWebTable(ctx, candhisSessionID, archiveURL)
+}
+
+// archiveURL builds the page of a past period of a campaign. Candhis encodes the query of its campaign pages in
+// base64, e.g. camp=02911, and serves older periods when the dateDeb and dateFin days are added to it. The backfill
+// rejects the pages whose observations are not in the requested period, in case Candhis ignores these days.
+func archiveURL(candhisURL string, from, to time.Time) (string, error) {
+	u, err := url.Parse(candhisURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse campaign url: %s, error: %w", candhisURL, err)
+	}
 
-	return model.NewWaveData(values[0], values[1], values[2], values[3],
-		values[4], values[5], values[6], values[7])
+	query, err := base64.StdEncoding.DecodeString(u.RawQuery)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode campaign url query: %s, error: %w", candhisURL, err)
+	}
+
+	archiveQuery := fmt.Sprintf("%s&dateDeb=%s&dateFin=%s",
+		query, from.Format(archiveDateLayout), to.Format(archiveDateLayout))
+	u.RawQuery = base64.StdEncoding.EncodeToString([]byte(archiveQuery))
+
+	return u.String(), nil
+}
+
+// waveDataColumns maps the wave data columns to their position in a table by its header cells, the position of an
+// optional column the table does not have is -1. Header cells are compared lowercased, without accents and with their
+// spaces collapsed.
+func waveDataColumns(headers []string, fingerprint string) ([]int, error) {
+	positions := make(map[string]int, len(headers))
+	for index, header := range headers {
+		positions[normalizeHeader(header)] = index
+	}
+
+	columns := make([]int, len(waveDataHeaders))
+	var missing []string
+	for column, header := range waveDataHeaders {
+		index, ok := positions[normalizeHeader(header)]
+		if !ok {
+			columns[column] = -1
+			if _, optional := waveDataMeasurements[waveDataColumn(column)]; !optional {
+				missing = append(missing, header)
+			}
+			continue
+		}
+		columns[column] = index
+		delete(positions, normalizeHeader(header))
+	}
+
+	var unknown []string
+	for _, header := range headers {
+		if _, ok := positions[normalizeHeader(header)]; ok {
+			unknown = append(unknown, header)
+		}
+	}
+
+	if len(missing) > 0 || len(unknown) > 0 {
+		return nil, &appmodel.LayoutChangedError{
+			Table:       "wave data table",
+			Fingerprint: fingerprint,
+			Missing:     missing,
+			Unknown:     unknown,
+		}
+	}
+	return columns, nil
+}
+
+// columnsInOrder tells whether the columns a table has are in the order of waveDataHeaders, the order the scraper was
+// written for.
+func columnsInOrder(columns []int) bool {
+	last := -1
+	for _, index := range columns {
+		if index < 0 {
+			continue
+		}
+		if index < last {
+			return false
+		}
+		last = index
+	}
+	return true
+}
+
+func normalizeHeader(header string) string {
+	return accentsReplacer.Replace(strings.ToLower(strings.Join(strings.Fields(header), " ")))
+}
+
+// layoutFingerprint hashes the header cells of the tables of a page, so that a change of its columns can be told
+// apart in the logs and the scrape run history.
+func layoutFingerprint(headers [][]string) string {
+	tables := make([]string, len(headers))
+	for index, cells := range headers {
+		normalized := make([]string, len(cells))
+		for i, cell := range cells {
+			normalized[i] = normalizeHeader(cell)
+		}
+		tables[index] = strings.Join(normalized, "|")
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(tables, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func parseRowOfWebTable(cells *goquery.Selection, cellsNum int, columns []int) (model.WaveData, error) {
+	if cells.Length() != cellsNum {
+		return model.WaveData{}, fmt.Errorf("%w: expected %d cells, but got %d",
+			appmodel.ErrLayoutChanged, cellsNum, cells.Length())
+	}
+
+	value := func(column waveDataColumn) string {
+		return strings.TrimSpace(cells.Eq(columns[column]).Text())
+	}
+
+	waveData, err := model.NewWaveData(value(waveDataColumnDate), value(waveDataColumnTime),
+		value(waveDataColumnAverageTopThirdWaveHeight), value(waveDataColumnMaxHeight),
+		value(waveDataColumnAverageTopThirdWavePeriod), value(waveDataColumnPeakDirection),
+		value(waveDataColumnPeakDirectionalSpread), value(waveDataColumnTemperature))
+	if err != nil {
+		return model.WaveData{}, err
+	}
+
+	for column := waveDataColumnPeakPeriod; column <= waveDataColumnWindDirection; column++ {
+		// Buoys publishing a measurement leave its cell empty or set to - when it is not available.
+		if columns[column] < 0 || value(column) == "" || value(column) == "-" {
+			continue
+		}
+		waveData, err = waveData.WithMeasurement(waveDataMeasurements[column], value(column))
+		if err != nil {
+			return model.WaveData{}, err
+		}
+	}
+	return waveData, nil
 }
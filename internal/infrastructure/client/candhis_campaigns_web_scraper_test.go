@@ -1,12 +1,20 @@
 package client_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
 	repo "github.com/tul1/candhis_api/internal/application/repository"
 	"github.com/tul1/candhis_api/internal/domain/model"
@@ -52,6 +60,16 @@ const mockHTMLResponse = `
 		<td class="text-center clALGTab"><span class="clALGTab">47</span></td>
 		<td class="text-center clALGTab"><span class="clALGTab">15</span></td>
 		</tr> 
+		<tr>
+		<td class="text-center clALGTab"><span class="clALGTab">17/09/2024</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">08:00</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">0.5</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">-</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">4.8</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">4</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">47</span></td>
+		<td class="text-center clALGTab"><span class="clALGTab">15</span></td>
+		</tr>
 	</tbody>
 	</table>
 </body>
@@ -71,29 +89,268 @@ func TestGatherWavesDataFromWebTable_Success(t *testing.T) {
 	}
 	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
 
-	waveData, err := scraper.GatherWavesDataFromWebTable(
-		appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	table, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
 	assert.NoError(t, err)
-	assert.Equal(t, 2, len(waveData))
+	assert.Equal(t, 2, len(table.WaveData))
 
 	expected := []model.WaveData{
 		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
 		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
 	}
 
-	assert.Equal(t, expected, waveData, "Expected correct parsed wave data")
+	assert.Equal(t, expected, table.WaveData, "Expected correct parsed wave data")
+	assert.Equal(t, 3, table.RowsSeen)
+	assert.Equal(t, []appmodel.RejectedRow{{Row: 3, Reason: "invalid observation: invalid value for maxHeight"}}, table.Rejected)
+	assert.Equal(t, "0a70acdff36f7882", table.LayoutFingerprint)
+	assert.False(t, table.LayoutChanged)
+}
+
+func TestGatherWavesDataFromWebTable_ReorderedColumns(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, waveDataTableHTML(
+			[]string{"Heure (TU)", "Date", "Hmax (m)", "H1/3 (m)", "Th1/3 (s)", "Temp. mer (°C)", "Dir. au pic (°)", "Etal.  au pic (°)"},
+			[]string{"09:00", "17/09/2024", "1.1", "0.6", "4.7", "15", "8", "32"},
+		))
+	}
+	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
+
+	table, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}, table.WaveData)
+	assert.Equal(t, "7dbdf0d80cf1cdcd", table.LayoutFingerprint)
+	assert.True(t, table.LayoutChanged)
+}
+
+func TestGatherWavesDataFromWebTable_SendsSessionCookie(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		assert.Equal(t, "acceptCookies=true; PHPSESSID=valid-session-id", req.Header.Get("Cookie"))
+		return MockHTTPResponse(200, mockHTMLResponse)
+	}
+	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
+
+	_, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	assert.NoError(t, err)
+}
+
+func TestGatherWavesDataFromWebTable_EmptyTable(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, `<table class="table table-striped table-bordered table-sm"></table>`)
+	}
+	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
+
+	table, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	assert.NoError(t, err)
+	assert.Empty(t, table.WaveData)
+	assert.Zero(t, table.RowsSeen)
+}
+
+func TestGatherWavesDataFromWebTable_SessionExpired(t *testing.T) {
+	testCases := map[string]struct {
+		mockHandler func(req *http.Request) *http.Response
+		errMsg      string
+	}{
+		"page without table": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, "<html><body>Veuillez accepter les cookies</body></html>")
+			},
+			errMsg: "no wave data table in page",
+		},
+		"empty response": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, "")
+			},
+			errMsg: "no wave data table in page",
+		},
+		"forbidden": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(403, "")
+			},
+			errMsg: "status code 403",
+		},
+		"redirected": {
+			mockHandler: func(req *http.Request) *http.Response {
+				resp := MockHTTPResponse(200, mockHTMLResponse)
+				resp.Request = httptest.NewRequest(http.MethodGet, "http://fake.url/index.php", http.NoBody)
+				return resp
+			},
+			errMsg: "redirected to http://fake.url/index.php",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scraper := setupMockCandhisCampaignsWebScraper(t, tc.mockHandler)
+
+			table, err := scraper.GatherWavesDataFromWebTable(
+				context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id"), "http://fake.url")
+			assert.ErrorIs(t, err, appmodel.ErrSessionExpired)
+			assert.ErrorContains(t, err, tc.errMsg)
+			assert.Equal(t, appmodel.WaveDataTable{}, table)
+		})
+	}
 }
 
-func TestGatherWavesDataFromWebTable_EmptyResponse(t *testing.T) {
+func TestGatherWavesDataFromWebTable_UnexpectedStatus(t *testing.T) {
 	mockHandler := func(req *http.Request) *http.Response {
-		return MockHTTPResponse(200, "")
+		return MockHTTPResponse(500, "")
 	}
 	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
 
-	waveData, err := scraper.GatherWavesDataFromWebTable(
-		appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	_, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+	assert.ErrorIs(t, err, client.ErrCandhisServerStatus)
+	assert.ErrorIs(t, err, appmodel.ErrUpstreamUnavailable)
+	assert.NotErrorIs(t, err, appmodel.ErrSessionExpired)
+}
+
+func TestGatherWavesDataFromWebTable_OptionalMeasurements(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, waveDataTableHTML(
+			append(slices.Clone(waveDataTableHeaders), "Tp (s)", "Hm0 (m)", "Vent (m/s)", "Dir. vent (°)"),
+			[]string{"17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15", "11.2", "0.7", "8.5", "270"},
+			[]string{"17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15", "10.8", "0.6", "-", ""},
+		))
+	}
+	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
+
+	table, err := scraper.GatherWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
 	assert.NoError(t, err)
-	assert.Empty(t, waveData)
+
+	first := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	first = modeltest.MustWithMeasurement(t, first, model.MeasurementPeakPeriod, "11.2")
+	first = modeltest.MustWithMeasurement(t, first, model.MeasurementSpectralWaveHeight, "0.7")
+	first = modeltest.MustWithMeasurement(t, first, model.MeasurementWindSpeed, "8.5")
+	first = modeltest.MustWithMeasurement(t, first, model.MeasurementWindDirection, "270")
+	second := modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15")
+	second = modeltest.MustWithMeasurement(t, second, model.MeasurementPeakPeriod, "10.8")
+	second = modeltest.MustWithMeasurement(t, second, model.MeasurementSpectralWaveHeight, "0.6")
+
+	assert.Equal(t, []model.WaveData{first, second}, table.WaveData)
+	assert.Empty(t, table.Rejected)
+	assert.False(t, table.LayoutChanged)
+}
+
+func TestGatherWavesDataFromWebTable_LayoutChanged(t *testing.T) {
+	row := []string{"17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"}
+	testCases := map[string]struct {
+		body         string
+		expectedCols *appmodel.LayoutChangedError
+		errMsg       string
+	}{
+		"missing column": {
+			body: waveDataTableHTML(
+				[]string{"Date", "Heure (TU)", "H1/3 (m)", "Th1/3 (s)", "Dir. au pic (°)", "Etal. au pic (°)", "Temp. mer (°C)"},
+				[]string{"17/09/2024", "09:00", "0.6", "4.7", "8", "32", "15"},
+			),
+			expectedCols: &appmodel.LayoutChangedError{Missing: []string{"Hmax (m)"}},
+			errMsg: `wave data table has missing columns ["Hmax (m)"], ` +
+				`fingerprint 399156466e3d01b8`,
+		},
+		"unknown column": {
+			body: waveDataTableHTML(
+				append(slices.Clone(waveDataTableHeaders), "Vent (nd)"),
+				append(slices.Clone(row), "12"),
+			),
+			expectedCols: &appmodel.LayoutChangedError{Unknown: []string{"Vent (nd)"}},
+			errMsg:       `wave data table has unknown columns ["Vent (nd)"], fingerprint e210cdf98fb86fa9`,
+		},
+		"renamed column": {
+			body: waveDataTableHTML(
+				[]string{"Date", "Heure (TU)", "H1/3 (m)", "Hmax (cm)", "Th1/3 (s)", "Dir. au pic (°)", "Etal. au pic (°)", "Temp. mer (°C)"},
+				row,
+			),
+			expectedCols: &appmodel.LayoutChangedError{Missing: []string{"Hmax (m)"}, Unknown: []string{"Hmax (cm)"}},
+			errMsg: `wave data table has missing columns ["Hmax (m)"] and ` +
+				`unknown columns ["Hmax (cm)"], fingerprint 8a16d15f36f24e9d`,
+		},
+		"rows shorter than header": {
+			body:   waveDataTableHTML(waveDataTableHeaders, row[:7]),
+			errMsg: "none of the 1 rows of the wave data table has as many cells as its header",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scraper := setupMockCandhisCampaignsWebScraper(t, func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, tc.body)
+			})
+
+			table, err := scraper.GatherWavesDataFromWebTable(
+				context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), "http://fake.url")
+			assert.ErrorIs(t, err, appmodel.ErrLayoutChanged)
+			assert.ErrorContains(t, err, tc.errMsg)
+			assert.Equal(t, appmodel.WaveDataTable{}, table)
+
+			var layoutErr *appmodel.LayoutChangedError
+			if tc.expectedCols == nil {
+				assert.False(t, errors.As(err, &layoutErr))
+				return
+			}
+			require.ErrorAs(t, err, &layoutErr)
+			assert.Equal(t, tc.expectedCols.Missing, layoutErr.Missing)
+			assert.Equal(t, tc.expectedCols.Unknown, layoutErr.Unknown)
+		})
+	}
+}
+
+func TestGatherArchivedWavesDataFromWebTable_Success(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		assert.Equal(t,
+			"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMSZkYXRlRGViPTAxLzAxLzIwMjQmZGF0ZUZpbj0wNy8wMS8yMDI0",
+			req.URL.String())
+		return MockHTTPResponse(200, mockHTMLResponse)
+	}
+	scraper := setupMockCandhisCampaignsWebScraper(t, mockHandler)
+
+	table, err := scraper.GatherArchivedWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, table.WaveData, 2)
+}
+
+func TestGatherArchivedWavesDataFromWebTable_InvalidCampaignURL(t *testing.T) {
+	scraper := setupMockCandhisCampaignsWebScraper(t, func(req *http.Request) *http.Response {
+		t.Fatal("no request expected")
+		return nil
+	})
+
+	_, err := scraper.GatherArchivedWavesDataFromWebTable(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?camp=02911",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC))
+	assert.ErrorContains(t, err, "failed to decode campaign url query: https://candhis.cerema.fr/_public_/campagne.php?camp=02911")
+}
+
+var waveDataTableHeaders = []string{
+	"Date", "Heure (TU)", "H1/3 (m)", "Hmax (m)", "Th1/3 (s)", "Dir. au pic (°)", "Etal. au pic (°)", "Temp. mer (°C)",
+}
+
+// waveDataTableHTML builds a campaign page whose wave data table has the given header cells and rows.
+func waveDataTableHTML(headers []string, rows ...[]string) string {
+	var b strings.Builder
+	b.WriteString(`<table class="table table-striped table-bordered table-sm"><thead><tr>`)
+	for _, header := range headers {
+		fmt.Fprintf(&b, "<th><strong>%s</strong></th>", header)
+	}
+	b.WriteString("</tr></thead><tbody>")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&b, "<td><span>%s</span></td>", cell)
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</tbody></table>")
+	return b.String()
 }
 
 type mockRoundTripper struct {
@@ -109,5 +366,8 @@ func setupMockCandhisCampaignsWebScraper(t *testing.T, mockHandler func(req *htt
 
 	mockClient := &http.Client{Transport: &mockRoundTripper{mockHandler: mockHandler}}
 
-	return client.NewCandhisCampaignsWebScraper(mockClient)
+	return client.NewCandhisCampaignsWebScraper(client.NewCandhisHTTPClient(mockClient, mockCandhisHTTPOptions))
 }
+
+// mockCandhisHTTPOptions send each request once, with no noticeable wait between requests.
+var mockCandhisHTTPOptions = client.CandhisHTTPOptions{Attempts: 1, MinInterval: time.Nanosecond}
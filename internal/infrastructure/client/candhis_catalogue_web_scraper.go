@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+const campaignLinkSelector = `a[href*="campagne.php?"]`
+
+type catalogueColumn int
+
+const (
+	catalogueColumnName catalogueColumn = iota
+	catalogueColumnLatitude
+	catalogueColumnLongitude
+	catalogueColumnDepth
+	catalogueColumnOperator
+	catalogueColumnState
+)
+
+// catalogueHeaders are the prefixes of the catalogue header cells, lowercased and without accents.
+var catalogueHeaders = map[catalogueColumn][]string{
+	catalogueColumnName:      {"campagne", "nom", "station"},
+	catalogueColumnLatitude:  {"latitude"},
+	catalogueColumnLongitude: {"longitude"},
+	catalogueColumnDepth:     {"profondeur"},
+	catalogueColumnOperator:  {"gestionnaire", "exploitant", "operateur"},
+	catalogueColumnState:     {"etat", "statut"},
+}
+
+// The depth and operator columns are optional, stations are stored without them.
+var requiredCatalogueColumns = []catalogueColumn{
+	catalogueColumnName, catalogueColumnLatitude, catalogueColumnLongitude, catalogueColumnState,
+}
+
+var accentsReplacer = strings.NewReplacer("é", "e", "è", "e", "ê", "e", "à", "a", "ô", "o")
+
+type candhisCatalogueWebScraper struct {
+	client *candhisHTTPClient
+}
+
+func NewCandhisCatalogueWebScraper(client *candhisHTTPClient) *candhisCatalogueWebScraper {
+	return &candhisCatalogueWebScraper{client}
+}
+
+func (c *candhisCatalogueWebScraper) GatherStationsFromWebCatalogue(
+	ctx context.Context,
+	candhisSessionID appmodel.CandhisSessionID,
+	catalogueURL string,
+) (appmodel.StationCatalogue, error) {
+	doc, err := getCandhisPage(ctx, c.client, candhisSessionID, catalogueURL)
+	if err != nil {
+		return appmodel.StationCatalogue{}, err
+	}
+
+	var catalogue appmodel.StationCatalogue
+	found := false
+	doc.Find("table").Each(func(_ int, t *goquery.Selection) {
+		columns, ok := catalogueColumns(t)
+		if !ok {
+			return
+		}
+		found = true
+
+		t.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			cells := row.Find("td")
+			if cells.Length() == 0 {
+				// Header rows only hold th cells.
+				return
+			}
+
+			catalogue.RowsSeen++
+			station, err := parseRowOfCatalogue(row, cells, columns)
+			if err != nil {
+				catalogue.Rejected = append(catalogue.Rejected, appmodel.RejectedRow{Row: catalogue.RowsSeen, Reason: err.Error()})
+				return
+			}
+
+			catalogue.Stations = append(catalogue.Stations, station)
+		})
+	})
+
+	if !found {
+		// Without a valid session Candhis serves its cookie page instead of the catalogue.
+		return appmodel.StationCatalogue{}, &appmodel.MissingContentError{Detail: "no station catalogue table in page"}
+	}
+
+	return catalogue, nil
+}
+
+// catalogueColumns maps the columns of a table to their position, it is not a catalogue table when a required column
+// is missing.
+func catalogueColumns(table *goquery.Selection) (map[catalogueColumn]int, bool) {
+	columns := make(map[catalogueColumn]int)
+	table.Find("th").Each(func(index int, th *goquery.Selection) {
+		header := accentsReplacer.Replace(strings.ToLower(strings.TrimSpace(th.Text())))
+		for column, prefixes := range catalogueHeaders {
+			for _, prefix := range prefixes {
+				if _, ok := columns[column]; !ok && strings.HasPrefix(header, prefix) {
+					columns[column] = index
+				}
+			}
+		}
+	})
+
+	for _, column := range requiredCatalogueColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, false
+		}
+	}
+	return columns, true
+}
+
+func parseRowOfCatalogue(
+	row, cells *goquery.Selection,
+	columns map[catalogueColumn]int,
+) (appmodel.Station, error) {
+	value := func(column catalogueColumn) string {
+		index, ok := columns[column]
+		if !ok || index >= cells.Length() {
+			return ""
+		}
+		return strings.TrimSpace(cells.Eq(index).Text())
+	}
+
+	href, ok := row.Find(campaignLinkSelector).First().Attr("href")
+	if !ok {
+		return appmodel.Station{}, errors.New("no campaign link in row")
+	}
+	link, err := url.Parse(href)
+	if err != nil {
+		return appmodel.Station{}, fmt.Errorf("invalid campaign link: %s", href)
+	}
+
+	latitude, err := parseCoordinate(value(catalogueColumnLatitude))
+	if err != nil {
+		return appmodel.Station{}, fmt.Errorf("invalid latitude: %w", err)
+	}
+	longitude, err := parseCoordinate(value(catalogueColumnLongitude))
+	if err != nil {
+		return appmodel.Station{}, fmt.Errorf("invalid longitude: %w", err)
+	}
+	depth, err := parseDepth(value(catalogueColumnDepth))
+	if err != nil {
+		return appmodel.Station{}, fmt.Errorf("invalid depth: %w", err)
+	}
+	active, err := parseStationState(value(catalogueColumnState))
+	if err != nil {
+		return appmodel.Station{}, err
+	}
+
+	return appmodel.NewStation(
+		link.RawQuery, value(catalogueColumnName), latitude, longitude, depth, value(catalogueColumnOperator), active)
+}
+
+// parseCoordinate reads decimal degrees, e.g. 48.2908 or 48,2908, or degrees and decimal minutes with a hemisphere,
+// e.g. 48°17.45'N or 4°58.07'O.
+func parseCoordinate(value string) (float64, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if s == "" {
+		return 0, errors.New("empty value")
+	}
+
+	sign := 1.0
+	switch s[len(s)-1] {
+	case 'S', 'W', 'O':
+		sign = -1
+		s = strings.TrimSpace(s[:len(s)-1])
+	case 'N', 'E':
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+
+	degrees, minutes, hasMinutes := strings.Cut(s, "°")
+	deg, err := strconv.ParseFloat(strings.TrimSpace(degrees), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a coordinate", value)
+	}
+	if !hasMinutes {
+		return sign * deg, nil
+	}
+
+	minutes = strings.TrimSpace(strings.TrimRight(minutes, "'′ "))
+	if minutes == "" {
+		return sign * deg, nil
+	}
+	mins, err := strconv.ParseFloat(minutes, 64)
+	if err != nil || mins < 0 || mins >= 60 {
+		return 0, fmt.Errorf("%q is not a coordinate", value)
+	}
+	if deg < 0 {
+		return sign * (deg - mins/60), nil
+	}
+	return sign * (deg + mins/60), nil
+}
+
+// parseDepth reads a depth in meters, e.g. 60 or 60 m, it is nil when Candhis does not publish it.
+func parseDepth(value string) (*float64, error) {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "m"))
+	if s == "" || s == "-" {
+		return nil, nil
+	}
+
+	depth, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a depth", value)
+	}
+	return &depth, nil
+}
+
+func parseStationState(value string) (bool, error) {
+	state := accentsReplacer.Replace(strings.ToLower(strings.TrimSpace(value)))
+	switch {
+	case strings.HasPrefix(state, "en service"), strings.HasPrefix(state, "en cours"),
+		strings.HasPrefix(state, "actif"), strings.HasPrefix(state, "active"):
+		return true, nil
+	case strings.HasPrefix(state, "hors service"), strings.HasPrefix(state, "termine"),
+		strings.HasPrefix(state, "arrete"), strings.HasPrefix(state, "inacti"):
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown station state: %q", value)
+	}
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+)
+
+const catalogueURL = "https://candhis.cerema.fr/_public_/campagnes.php"
+
+const mockCatalogueHTMLResponse = `
+<!DOCTYPE html>
+<html>
+<body>
+	<table class="table table-sm">
+	<tr><th>Menu</th></tr>
+	<tr><td><a href="index.php">Accueil</a></td></tr>
+	</table>
+	<table class="table table-striped table-bordered table-sm">
+	<thead>
+		<tr>
+		<th>Campagne</th>
+		<th>Latitude</th>
+		<th>Longitude</th>
+		<th>Profondeur (m)</th>
+		<th>Gestionnaire</th>
+		<th>État</th>
+		</tr>
+	</thead>
+	<tbody>
+		<tr>
+		<td><a href="campagne.php?Y2FtcD0wMjkxMQ==">Les Pierres Noires</a></td>
+		<td>48°17.448'N</td>
+		<td>4°58.068'W</td>
+		<td>60 m</td>
+		<td>Cerema</td>
+		<td>En service</td>
+		</tr>
+		<tr>
+		<td><a href="/_public_/campagne.php?Y2FtcD0wNTYwMg==">Belle Ile</a></td>
+		<td>47,2855</td>
+		<td>-3,2847</td>
+		<td>-</td>
+		<td>DREAL Bretagne</td>
+		<td>Hors service</td>
+		</tr>
+		<tr>
+		<td>Sans lien</td>
+		<td>47.0</td>
+		<td>-3.0</td>
+		<td>20</td>
+		<td>Cerema</td>
+		<td>En service</td>
+		</tr>
+		<tr>
+		<td><a href="campagne.php?Y2FtcD0wMjIwNA==">Bouée inconnue</a></td>
+		<td>48.0</td>
+		<td>-4.0</td>
+		<td>20</td>
+		<td>Cerema</td>
+		<td>En maintenance</td>
+		</tr>
+		<tr>
+		<td><a href="campagne.php?Y2FtcD0wMjIwNQ==">Mauvaise position</a></td>
+		<td>nord</td>
+		<td>-4.0</td>
+		<td>20</td>
+		<td>Cerema</td>
+		<td>En service</td>
+		</tr>
+	</tbody>
+	</table>
+</body>
+</html>
+`
+
+func TestGatherStationsFromWebCatalogue_Success(t *testing.T) {
+	scraper := client.NewCandhisCatalogueWebScraper(client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			assert.Equal(t, catalogueURL, req.URL.String())
+			assert.Equal(t, "acceptCookies=true; PHPSESSID=valid-session-id", req.Header.Get("Cookie"))
+			return MockHTTPResponse(200, mockCatalogueHTMLResponse)
+		},
+	}}, mockCandhisHTTPOptions))
+
+	catalogue, err := scraper.GatherStationsFromWebCatalogue(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"), catalogueURL)
+	require.NoError(t, err)
+
+	depth := 60.0
+	assert.Equal(t, []appmodel.Station{
+		appmodeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48+17.448/60, -(4 + 58.068/60),
+			&depth, "Cerema", true),
+		appmodeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.2855, -3.2847, nil, "DREAL Bretagne", false),
+	}, catalogue.Stations)
+	assert.Equal(t, 5, catalogue.RowsSeen)
+	assert.Equal(t, []appmodel.RejectedRow{
+		{Row: 3, Reason: "no campaign link in row"},
+		{Row: 4, Reason: `unknown station state: "En maintenance"`},
+		{Row: 5, Reason: `invalid latitude: "nord" is not a coordinate`},
+	}, catalogue.Rejected)
+}
+
+func TestGatherStationsFromWebCatalogue_SessionExpired(t *testing.T) {
+	scraper := client.NewCandhisCatalogueWebScraper(client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			return MockHTTPResponse(200, "<html><body>Veuillez accepter les cookies</body></html>")
+		},
+	}}, mockCandhisHTTPOptions))
+
+	catalogue, err := scraper.GatherStationsFromWebCatalogue(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "expired-session-id"), catalogueURL)
+	var missingContent *appmodel.MissingContentError
+	assert.ErrorAs(t, err, &missingContent)
+	assert.ErrorIs(t, err, appmodel.ErrSessionExpired)
+	assert.Equal(t, "no station catalogue table in page", missingContent.Detail)
+	assert.Equal(t, appmodel.StationCatalogue{}, catalogue)
+}
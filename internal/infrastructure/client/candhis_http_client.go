@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+var (
+	// ErrCandhisClientStatus is the kind of the 4xx responses of Candhis, other than the blocked ones.
+	ErrCandhisClientStatus = errors.New("candhis client error")
+	// ErrCandhisServerStatus is the kind of the 5xx responses of Candhis, still failing after the retries.
+	ErrCandhisServerStatus = errors.New("candhis server error")
+	// ErrCandhisBlocked is the kind of the responses of Candhis refusing to serve more requests.
+	ErrCandhisBlocked = errors.New("candhis blocked the request")
+)
+
+// CandhisHTTPError is returned for a response of Candhis with an error status, it wraps the kind of the status, and
+// appmodel.ErrUpstreamUnavailable for the server errors and the blocked requests.
+type CandhisHTTPError struct {
+	Kind       error
+	StatusCode int
+	URL        string
+}
+
+func (e *CandhisHTTPError) Error() string {
+	return fmt.Sprintf("%v: status code %d, url: %s", e.Kind, e.StatusCode, e.URL)
+}
+
+func (e *CandhisHTTPError) Unwrap() []error {
+	if e.Kind == ErrCandhisClientStatus {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, appmodel.ErrUpstreamUnavailable}
+}
+
+func newCandhisHTTPError(statusCode int, pageURL string) *CandhisHTTPError {
+	kind := ErrCandhisClientStatus
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		kind = ErrCandhisBlocked
+	case statusCode >= http.StatusInternalServerError:
+		kind = ErrCandhisServerStatus
+	}
+	return &CandhisHTTPError{Kind: kind, StatusCode: statusCode, URL: pageURL}
+}
+
+// CandhisHTTPOptions tune the requests to Candhis, zero fields take the value of DefaultCandhisHTTPOptions.
+type CandhisHTTPOptions struct {
+	// Timeout bounds a request, from its sending to the end of its body.
+	Timeout   time.Duration
+	UserAgent string
+	// MinInterval is the minimum time between the start of two requests to the same host.
+	MinInterval time.Duration
+	// A request failing with a network error or a 5xx response is sent up to Attempts times, waiting Backoff before
+	// the first retry and twice longer before each next one.
+	Attempts int
+	Backoff  time.Duration
+}
+
+func DefaultCandhisHTTPOptions() CandhisHTTPOptions {
+	return CandhisHTTPOptions{
+		Timeout:     30 * time.Second,
+		UserAgent:   "candhis_api (+https://github.com/tul1/candhis_api)",
+		MinInterval: time.Second,
+		Attempts:    3,
+		Backoff:     2 * time.Second,
+	}
+}
+
+// WithDefaults returns the options with their zero fields set to the value of DefaultCandhisHTTPOptions.
+func (o CandhisHTTPOptions) WithDefaults() CandhisHTTPOptions {
+	defaults := DefaultCandhisHTTPOptions()
+	if o.Timeout <= 0 {
+		o.Timeout = defaults.Timeout
+	}
+	if o.UserAgent == "" {
+		o.UserAgent = defaults.UserAgent
+	}
+	if o.MinInterval <= 0 {
+		o.MinInterval = defaults.MinInterval
+	}
+	if o.Attempts <= 0 {
+		o.Attempts = defaults.Attempts
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = defaults.Backoff
+	}
+	return o
+}
+
+// candhisResponse is a successful response of Candhis, read in full.
+type candhisResponse struct {
+	body []byte
+	// url is the URL of the page served, after the redirects.
+	url *url.URL
+}
+
+// candhisHTTPClient sends the requests of the scrapers to Candhis, spacing the requests to each host and retrying
+// the transient failures.
+type candhisHTTPClient struct {
+	client   *http.Client
+	options  CandhisHTTPOptions
+	schedule *hostSchedule
+}
+
+// hostSchedule books the start of the requests to each host, it is shared by the clients derived with withJar.
+type hostSchedule struct {
+	mu sync.Mutex
+	// nextRequest is the earliest start of the next request to each host.
+	nextRequest map[string]time.Time
+}
+
+func NewCandhisHTTPClient(client *http.Client, options CandhisHTTPOptions) *candhisHTTPClient {
+	return &candhisHTTPClient{
+		client:   client,
+		options:  options.WithDefaults(),
+		schedule: &hostSchedule{nextRequest: make(map[string]time.Time)},
+	}
+}
+
+// withJar returns a client keeping the cookies of its responses in jar. Its requests are spaced with the requests of
+// c.
+func (c *candhisHTTPClient) withJar(jar http.CookieJar) *candhisHTTPClient {
+	client := *c.client
+	client.Jar = jar
+	return &candhisHTTPClient{
+		client:   &client,
+		options:  c.options,
+		schedule: c.schedule,
+	}
+}
+
+// Get requests a Candhis page with the given headers. Responses with an error status are returned as
+// *CandhisHTTPError.
+func (c *candhisHTTPClient) Get(ctx context.Context, pageURL string, header http.Header) (candhisResponse, error) {
+	backoff := c.options.Backoff
+	for attempt := 1; ; attempt++ {
+		resp, retry, err := c.get(ctx, pageURL, header)
+		if err == nil {
+			return resp, nil
+		}
+		if !retry || attempt == c.options.Attempts {
+			return candhisResponse{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return candhisResponse{}, fmt.Errorf("%w, retry cancelled: %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// get sends a single request, retry tells whether a failed request may succeed later.
+func (c *candhisHTTPClient) get(
+	ctx context.Context,
+	pageURL string,
+	header http.Header,
+) (resp candhisResponse, retry bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return candhisResponse{}, false, fmt.Errorf("failed to create request, url: %s, error: %w", pageURL, err)
+	}
+
+	err = c.wait(ctx, req.URL.Host)
+	if err != nil {
+		return candhisResponse{}, false, fmt.Errorf("request cancelled, url: %s, error: %w", pageURL, err)
+	}
+
+	// A request timing out is retried, unlike a request whose ctx is done.
+	requestCtx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+
+	req = req.WithContext(requestCtx)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.options.UserAgent)
+
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return candhisResponse{}, ctx.Err() == nil, upstreamError(ctx,
+			fmt.Errorf("failed to perform request, url: %s, error: %w", pageURL, err))
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		httpErr := newCandhisHTTPError(httpResp.StatusCode, pageURL)
+		return candhisResponse{}, errors.Is(httpErr, ErrCandhisServerStatus), httpErr
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return candhisResponse{}, ctx.Err() == nil, upstreamError(ctx,
+			fmt.Errorf("failed to read response body, url: %s, error: %w", pageURL, err))
+	}
+
+	resp = candhisResponse{body: body, url: req.URL}
+	if httpResp.Request != nil {
+		resp.url = httpResp.Request.URL
+	}
+	return resp, false, nil
+}
+
+// upstreamError wraps appmodel.ErrUpstreamUnavailable into the error of a request that failed while its ctx was not
+// done.
+func upstreamError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", appmodel.ErrUpstreamUnavailable, err)
+}
+
+// wait blocks until a request may be sent to host, and books the next slot.
+func (c *candhisHTTPClient) wait(ctx context.Context, host string) error {
+	c.schedule.mu.Lock()
+	now := time.Now()
+	start := c.schedule.nextRequest[host]
+	if start.Before(now) {
+		start = now
+	}
+	c.schedule.nextRequest[host] = start.Add(c.options.MinInterval)
+	c.schedule.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,196 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+)
+
+const candhisPageURL = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ=="
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCandhisHTTPClient_Get_Success(t *testing.T) {
+	var requests []*http.Request
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			requests = append(requests, req)
+			return MockHTTPResponse(http.StatusOK, mockHTMLResponse)
+		},
+	}}, client.CandhisHTTPOptions{UserAgent: "candhis-test"})
+
+	_, err := candhisClient.Get(context.Background(), candhisPageURL, http.Header{"Cookie": {"acceptCookies=true"}})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, candhisPageURL, requests[0].URL.String())
+	assert.Equal(t, "candhis-test", requests[0].Header.Get("User-Agent"))
+	assert.Equal(t, "acceptCookies=true", requests[0].Header.Get("Cookie"))
+}
+
+func TestCandhisHTTPOptions_WithDefaults(t *testing.T) {
+	assert.Equal(t, client.DefaultCandhisHTTPOptions(), client.CandhisHTTPOptions{}.WithDefaults())
+
+	options := client.CandhisHTTPOptions{
+		Timeout: time.Second, UserAgent: "candhis-test", MinInterval: time.Millisecond, Attempts: 1, Backoff: time.Millisecond,
+	}
+	assert.Equal(t, options, options.WithDefaults())
+}
+
+func TestCandhisHTTPClient_Get_DefaultUserAgent(t *testing.T) {
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			assert.Equal(t, client.DefaultCandhisHTTPOptions().UserAgent, req.Header.Get("User-Agent"))
+			return MockHTTPResponse(http.StatusOK, "")
+		},
+	}}, client.CandhisHTTPOptions{})
+
+	_, err := candhisClient.Get(context.Background(), candhisPageURL, nil)
+	require.NoError(t, err)
+}
+
+func TestCandhisHTTPClient_Get_StatusErrors(t *testing.T) {
+	testCases := map[string]struct {
+		statusCodes      []int
+		expectedRequests int
+		expectedKind     error
+	}{
+		"server error then success": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedRequests: 3,
+		},
+		"attempts exhausted": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusServiceUnavailable},
+			expectedRequests: 3,
+			expectedKind:     client.ErrCandhisServerStatus,
+		},
+		"not found not retried": {
+			statusCodes:      []int{http.StatusNotFound},
+			expectedRequests: 1,
+			expectedKind:     client.ErrCandhisClientStatus,
+		},
+		"blocked not retried": {
+			statusCodes:      []int{http.StatusTooManyRequests},
+			expectedRequests: 1,
+			expectedKind:     client.ErrCandhisBlocked,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+				mockHandler: func(req *http.Request) *http.Response {
+					statusCode := tc.statusCodes[requests]
+					requests++
+					return MockHTTPResponse(statusCode, "")
+				},
+			}}, client.CandhisHTTPOptions{MinInterval: time.Nanosecond, Attempts: 3, Backoff: time.Millisecond})
+
+			_, err := candhisClient.Get(context.Background(), candhisPageURL, nil)
+			assert.Equal(t, tc.expectedRequests, requests)
+			if tc.expectedKind == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedKind)
+			// Only the 4xx responses other than 429 do not mean that Candhis is unavailable.
+			assert.Equal(t, tc.expectedKind != client.ErrCandhisClientStatus, errors.Is(err, appmodel.ErrUpstreamUnavailable))
+
+			var httpErr *client.CandhisHTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tc.statusCodes[len(tc.statusCodes)-1], httpErr.StatusCode)
+			assert.Equal(t, candhisPageURL, httpErr.URL)
+		})
+	}
+}
+
+func TestCandhisHTTPClient_Get_RetriesNetworkErrors(t *testing.T) {
+	requests := 0
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: roundTripperFunc(
+		func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return MockHTTPResponse(http.StatusOK, ""), nil
+		},
+	)}, client.CandhisHTTPOptions{MinInterval: time.Nanosecond, Attempts: 3, Backoff: time.Millisecond})
+
+	_, err := candhisClient.Get(context.Background(), candhisPageURL, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCandhisHTTPClient_Get_Timeout(t *testing.T) {
+	requests := 0
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: roundTripperFunc(
+		func(req *http.Request) (*http.Response, error) {
+			requests++
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		},
+	)}, client.CandhisHTTPOptions{
+		Timeout: 10 * time.Millisecond, MinInterval: time.Nanosecond, Attempts: 2, Backoff: time.Millisecond,
+	})
+
+	_, err := candhisClient.Get(context.Background(), candhisPageURL, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, appmodel.ErrUpstreamUnavailable)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCandhisHTTPClient_Get_RetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			cancel()
+			return MockHTTPResponse(http.StatusBadGateway, "")
+		},
+	}}, client.CandhisHTTPOptions{Attempts: 3, Backoff: time.Hour})
+
+	_, err := candhisClient.Get(ctx, candhisPageURL, nil)
+	assert.ErrorIs(t, err, client.ErrCandhisServerStatus)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCandhisHTTPClient_Get_RateLimitsPerHost(t *testing.T) {
+	var starts []time.Time
+	var otherHostStart time.Time
+	candhisClient := client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			if req.URL.Host == "example.com" {
+				otherHostStart = time.Now()
+			} else {
+				starts = append(starts, time.Now())
+			}
+			return MockHTTPResponse(http.StatusOK, "")
+		},
+	}}, client.CandhisHTTPOptions{MinInterval: 100 * time.Millisecond})
+
+	begin := time.Now()
+	for range 3 {
+		_, err := candhisClient.Get(context.Background(), candhisPageURL, nil)
+		require.NoError(t, err)
+	}
+	_, err := candhisClient.Get(context.Background(), "https://example.com", nil)
+	require.NoError(t, err)
+
+	require.Len(t, starts, 3)
+	for i := 1; i < len(starts); i++ {
+		assert.GreaterOrEqual(t, starts[i].Sub(starts[i-1]), 95*time.Millisecond)
+	}
+	assert.Less(t, otherHostStart.Sub(starts[2]), 95*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(begin), 190*time.Millisecond)
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/PuerkitoBio/goquery"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+// getCandhisPage requests a public Candhis page with the session ID and parses its HTML.
+func getCandhisPage(
+	ctx context.Context,
+	client *candhisHTTPClient,
+	candhisSessionID appmodel.CandhisSessionID,
+	pageURL string,
+) (*goquery.Document, error) {
+	body, err := getCandhisFile(ctx, client, candhisSessionID, pageURL, "text/html")
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed parse HTML: %w", err)
+	}
+
+	return doc, nil
+}
+
+// getCandhisFile requests a public Candhis page or file with the session ID. Candhis redirects the requests of an
+// expired session, or rejects them.
+func getCandhisFile(
+	ctx context.Context,
+	client *candhisHTTPClient,
+	candhisSessionID appmodel.CandhisSessionID,
+	fileURL, accept string,
+) ([]byte, error) {
+	header := http.Header{}
+	header.Set("Accept", accept)
+	header.Set("Cookie", fmt.Sprintf("acceptCookies=true; %s", candhisSessionID.PHPSESSID()))
+
+	resp, err := client.Get(ctx, fileURL, header)
+	var httpErr *CandhisHTTPError
+	if errors.As(err, &httpErr) &&
+		(httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: status code %d", appmodel.ErrSessionExpired, httpErr.StatusCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.url.String() != fileURL {
+		return nil, fmt.Errorf("%w: redirected to %s", appmodel.ErrSessionExpired, resp.url)
+	}
+
+	return resp.body, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+// sessionPageLoads is the number of times the target page is requested to get a session cookie. Candhis may only
+// start the session once the page is reloaded with the cookies accepted, as a browser does.
+const sessionPageLoads = 2
+
+type candhisSessionIDHTTPScraper struct {
+	client    *candhisHTTPClient
+	targetWeb string
+}
+
+// NewCandhisSessionIDHTTPScraper reads the PHPSESSID cookie with plain HTTP requests, without headless Chrome.
+func NewCandhisSessionIDHTTPScraper(client *candhisHTTPClient, targetWeb string) *candhisSessionIDHTTPScraper {
+	return &candhisSessionIDHTTPScraper{client, targetWeb}
+}
+
+func (c *candhisSessionIDHTTPScraper) GetCandhisSessionID(ctx context.Context) (model.CandhisSessionID, error) {
+	targetURL, err := url.Parse(c.targetWeb)
+	if err != nil {
+		return model.CandhisSessionID{}, fmt.Errorf("invalid session target web: %w", err)
+	}
+
+	// Each session gets its own jar, holding the cookie set when the Candhis cookie banner is accepted.
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return model.CandhisSessionID{}, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	jar.SetCookies(targetURL, []*http.Cookie{{Name: "acceptCookies", Value: "true", Path: "/"}})
+
+	client := c.client.withJar(jar)
+
+	for range sessionPageLoads {
+		if err := loadSessionPage(ctx, client, c.targetWeb); err != nil {
+			return model.CandhisSessionID{}, err
+		}
+
+		for _, cookie := range jar.Cookies(targetURL) {
+			if cookie.Name == "PHPSESSID" {
+				return model.NewCandhisSessionID(cookie.Value, nil)
+			}
+		}
+	}
+
+	return model.CandhisSessionID{}, fmt.Errorf("failed to retrieve session id: %w: no PHPSESSID cookie set by candhis web",
+		model.ErrLayoutChanged)
+}
+
+// loadSessionPage requests the target page, the page itself is not needed.
+func loadSessionPage(ctx context.Context, client *candhisHTTPClient, targetWeb string) error {
+	header := http.Header{}
+	header.Set("Accept", "text/html")
+
+	_, err := client.Get(ctx, targetWeb, header)
+	return err
+}
@@ -0,0 +1,116 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+	"github.com/tul1/candhis_api/internal/pkg/chrome"
+)
+
+const sessionTargetWeb = "https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ=="
+
+func TestCandhisSessionIDHTTPScraper_GetCandhisSessionID_Success(t *testing.T) {
+	scraper := client.NewCandhisSessionIDHTTPScraper(client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			assert.Equal(t, sessionTargetWeb, req.URL.String())
+			assert.Equal(t, client.DefaultCandhisHTTPOptions().UserAgent, req.Header.Get("User-Agent"))
+			cookie, err := req.Cookie("acceptCookies")
+			require.NoError(t, err)
+			assert.Equal(t, "true", cookie.Value)
+
+			resp := MockHTTPResponse(http.StatusOK, "<html><body>Les Pierres Noires</body></html>")
+			resp.Header = http.Header{"Set-Cookie": []string{"PHPSESSID=http-session-id; path=/"}}
+			return resp
+		},
+	}}, mockCandhisHTTPOptions), sessionTargetWeb)
+
+	sessionID, err := scraper.GetCandhisSessionID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "http-session-id", sessionID.ID())
+}
+
+func TestCandhisSessionIDHTTPScraper_GetCandhisSessionID_SessionStartedOnReload(t *testing.T) {
+	requests := 0
+	scraper := client.NewCandhisSessionIDHTTPScraper(client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			requests++
+			resp := MockHTTPResponse(http.StatusOK, "<html><body>Veuillez accepter les cookies</body></html>")
+			if requests == 2 {
+				resp.Header = http.Header{"Set-Cookie": []string{"PHPSESSID=reloaded-session-id; path=/"}}
+			}
+			return resp
+		},
+	}}, mockCandhisHTTPOptions), sessionTargetWeb)
+
+	sessionID, err := scraper.GetCandhisSessionID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "reloaded-session-id", sessionID.ID())
+	assert.Equal(t, 2, requests)
+}
+
+func TestCandhisSessionIDHTTPScraper_GetCandhisSessionID_Failures(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode   int
+		expectedKind error
+	}{
+		"no session cookie": {
+			statusCode:   http.StatusOK,
+			expectedKind: appmodel.ErrLayoutChanged,
+		},
+		"unexpected status": {
+			statusCode:   http.StatusServiceUnavailable,
+			expectedKind: appmodel.ErrUpstreamUnavailable,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scraper := client.NewCandhisSessionIDHTTPScraper(client.NewCandhisHTTPClient(&http.Client{Transport: &mockRoundTripper{
+				mockHandler: func(req *http.Request) *http.Response {
+					return MockHTTPResponse(tc.statusCode, "<html></html>")
+				},
+			}}, mockCandhisHTTPOptions), sessionTargetWeb)
+
+			_, err := scraper.GetCandhisSessionID(context.Background())
+			assert.ErrorIs(t, err, tc.expectedKind)
+		})
+	}
+}
+
+func TestCandhisSessionIDHTTPScraper_GetCandhisSessionID_DoesNotKeepCookies(t *testing.T) {
+	httpClient := &http.Client{Transport: &mockRoundTripper{
+		mockHandler: func(req *http.Request) *http.Response {
+			_, err := req.Cookie("PHPSESSID")
+			assert.ErrorIs(t, err, http.ErrNoCookie)
+
+			resp := MockHTTPResponse(http.StatusOK, "<html></html>")
+			resp.Header = http.Header{"Set-Cookie": []string{"PHPSESSID=new-session-id; path=/"}}
+			return resp
+		},
+	}}
+	scraper := client.NewCandhisSessionIDHTTPScraper(
+		client.NewCandhisHTTPClient(httpClient, mockCandhisHTTPOptions), sessionTargetWeb)
+
+	for range 2 {
+		_, err := scraper.GetCandhisSessionID(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Nil(t, httpClient.Jar)
+}
+
+func TestNewSessionIDWebScraper(t *testing.T) {
+	scraper, err := client.NewSessionIDWebScraper(&http.Client{},
+		client.NewCandhisHTTPClient(&http.Client{}, mockCandhisHTTPOptions), client.SessionScraperHTTP, "", chrome.Options{},
+		sessionTargetWeb)
+	require.NoError(t, err)
+	assert.NotNil(t, scraper)
+
+	_, err = client.NewSessionIDWebScraper(&http.Client{},
+		client.NewCandhisHTTPClient(&http.Client{}, mockCandhisHTTPOptions), "firefox", "", chrome.Options{}, sessionTargetWeb)
+	assert.EqualError(t, err, "unknown session scraper firefox")
+}
@@ -3,9 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/chromedp/cdproto/network"
 	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/pkg/chrome"
 )
 
 //go:generate mockgen -package scrapermock -destination=./scraper_mock/scraper_mock.go -source=candhis_sessionid_web_scraper.go ScraperMock
@@ -41,5 +44,35 @@ func (c *candhisSessionIDWebScraper) GetCandhisSessionID(ctx context.Context) (m
 		}
 	}
 
-	return model.CandhisSessionID{}, fmt.Errorf("failed to retrieve session id: %w", err)
+	return model.CandhisSessionID{}, fmt.Errorf("failed to retrieve session id: %w: no PHPSESSID cookie set by candhis web",
+		model.ErrLayoutChanged)
+}
+
+// Session ID scrapers selectable by config.
+const (
+	SessionScraperChrome = "chrome"
+	SessionScraperHTTP   = "http"
+)
+
+// NewSessionIDWebScraper builds the session ID scraper of the given kind, the chromedp one when kind is empty. Only
+// the chromedp scraper connects to Chrome.
+func NewSessionIDWebScraper(
+	httpClient *http.Client,
+	candhisHTTPClient *candhisHTTPClient,
+	kind, chromeURL string,
+	chromeOptions chrome.Options,
+	targetWeb string,
+) (repository.CandhisSessionIDWebScraper, error) {
+	switch kind {
+	case SessionScraperHTTP:
+		return NewCandhisSessionIDHTTPScraper(candhisHTTPClient, targetWeb), nil
+	case SessionScraperChrome, "":
+		chromeScraper, err := chrome.NewChromedpScraper(httpClient, chromeURL, chromeOptions)
+		if err != nil {
+			return nil, fmt.Errorf("chrome scraper initialization error: %w", err)
+		}
+		return NewCandhisSessionIDWebScraper(chromeScraper, targetWeb), nil
+	default:
+		return nil, fmt.Errorf("unknown session scraper %s", kind)
+	}
 }
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+// spectraPage serves the spectral file of a campaign next to its campaign page, for the same query.
+const spectraPage = "spectres.php"
+
+type spectrumColumn int
+
+const (
+	spectrumColumnDate spectrumColumn = iota
+	spectrumColumnTime
+	spectrumColumnFrequency
+	spectrumColumnEnergyDensity
+	spectrumColumnDirection
+	spectrumColumnSpread
+)
+
+// spectrumHeaders are the header cells of the spectral file columns, in the order Candhis serves them.
+var spectrumHeaders = []string{
+	spectrumColumnDate:          "Date",
+	spectrumColumnTime:          "Heure (TU)",
+	spectrumColumnFrequency:     "Fréquence (Hz)",
+	spectrumColumnEnergyDensity: "Densité (m2/Hz)",
+	spectrumColumnDirection:     "Direction (°)",
+	spectrumColumnSpread:        "Etalement (°)",
+}
+
+type candhisSpectraDownloader struct {
+	client *candhisHTTPClient
+}
+
+func NewCandhisSpectraDownloader(client *candhisHTTPClient) *candhisSpectraDownloader {
+	return &candhisSpectraDownloader{client}
+}
+
+// DownloadSpectra downloads and parses the spectral file of a campaign. The file is a semicolon separated table with
+// a header line, and a line per frequency bin of each spectrum; the lines of a spectrum share its date and time.
+func (c *candhisSpectraDownloader) DownloadSpectra(
+	ctx context.Context,
+	candhisSessionID appmodel.CandhisSessionID,
+	candhisURL string,
+	from, to time.Time,
+) (appmodel.SpectrumFile, error) {
+	fileURL, err := spectraURL(candhisURL, from, to)
+	if err != nil {
+		return appmodel.SpectrumFile{}, err
+	}
+
+	body, err := getCandhisFile(ctx, c.client, candhisSessionID, fileURL, "text/csv")
+	if err != nil {
+		return appmodel.SpectrumFile{}, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		// Without a valid session Candhis serves its cookie page instead of the file.
+		return appmodel.SpectrumFile{}, &appmodel.MissingContentError{Detail: "no spectral file in response"}
+	}
+
+	return parseSpectrumFile(body)
+}
+
+// spectraURL builds the spectral file of a campaign between the from and to days, like archiveURL.
+func spectraURL(candhisURL string, from, to time.Time) (string, error) {
+	archive, err := archiveURL(candhisURL, from, to)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(archive)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse campaign url: %s, error: %w", candhisURL, err)
+	}
+	u.Path = path.Join(path.Dir(u.Path), spectraPage)
+
+	return u.String(), nil
+}
+
+func parseSpectrumFile(body []byte) (appmodel.SpectrumFile, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return appmodel.SpectrumFile{}, nil
+	}
+	if err != nil {
+		return appmodel.SpectrumFile{}, fmt.Errorf("%w: failed to read spectral file header: %w", appmodel.ErrLayoutChanged, err)
+	}
+	for i := range headers {
+		headers[i] = strings.TrimSpace(headers[i])
+	}
+
+	columns, err := spectrumColumns(headers)
+	if err != nil {
+		return appmodel.SpectrumFile{}, err
+	}
+
+	var file appmodel.SpectrumFile
+	layoutRejected := 0
+	// Rows of the spectrum being read, with the position of its first row and of its first rejected row. A spectrum
+	// missing one of its bins would be stored as a truncated one, it is rejected as a whole.
+	var bins []model.SpectrumBin
+	var timestamp time.Time
+	firstRow, rejectedRow := 0, 0
+	flush := func() {
+		switch {
+		case rejectedRow > 0:
+			file.Rejected = append(file.Rejected, appmodel.RejectedRow{Row: firstRow, Reason: fmt.Sprintf(
+				"%v: spectrum of %s dropped, its row %d was rejected",
+				model.ErrInvalidObservation, timestamp.Format(time.RFC3339), rejectedRow)})
+		case len(bins) > 0:
+			spectrum, err := model.NewSpectrum(timestamp, bins)
+			if err != nil {
+				file.Rejected = append(file.Rejected, appmodel.RejectedRow{Row: firstRow, Reason: err.Error()})
+			} else {
+				file.Spectra = append(file.Spectra, spectrum)
+			}
+		}
+		bins, rejectedRow = nil, 0
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return appmodel.SpectrumFile{}, fmt.Errorf("%w: failed to read spectral file: %w", appmodel.ErrLayoutChanged, err)
+		}
+
+		file.RowsSeen++
+		rowTimestamp, bin, err := parseRowOfSpectrumFile(record, len(headers), columns)
+		if errors.Is(err, appmodel.ErrLayoutChanged) {
+			layoutRejected++
+		}
+		if err != nil {
+			file.Rejected = append(file.Rejected, appmodel.RejectedRow{Row: file.RowsSeen, Reason: err.Error()})
+			if rowTimestamp.IsZero() {
+				// The spectrum of the row is unknown.
+				continue
+			}
+		}
+
+		if !rowTimestamp.Equal(timestamp) {
+			flush()
+			timestamp, firstRow = rowTimestamp, file.RowsSeen
+		}
+		if err != nil {
+			if rejectedRow == 0 {
+				rejectedRow = file.RowsSeen
+			}
+			continue
+		}
+		bins = append(bins, bin)
+	}
+	flush()
+
+	if file.RowsSeen > 0 && layoutRejected == file.RowsSeen {
+		return appmodel.SpectrumFile{}, fmt.Errorf("%w: none of the %d rows of the spectral file has as many cells "+
+			"as its header", appmodel.ErrLayoutChanged, file.RowsSeen)
+	}
+
+	return file, nil
+}
+
+// spectrumColumns maps the spectral file columns to their position by its header cells, compared like the header
+// cells of the wave data tables.
+func spectrumColumns(headers []string) ([]int, error) {
+	positions := make(map[string]int, len(headers))
+	for index, header := range headers {
+		positions[normalizeHeader(header)] = index
+	}
+
+	columns := make([]int, len(spectrumHeaders))
+	var missing []string
+	for column, header := range spectrumHeaders {
+		index, ok := positions[normalizeHeader(header)]
+		if !ok {
+			missing = append(missing, header)
+			continue
+		}
+		columns[column] = index
+		delete(positions, normalizeHeader(header))
+	}
+
+	var unknown []string
+	for _, header := range headers {
+		if _, ok := positions[normalizeHeader(header)]; ok {
+			unknown = append(unknown, header)
+		}
+	}
+
+	if len(missing) > 0 || len(unknown) > 0 {
+		return nil, &appmodel.LayoutChangedError{
+			Table:       "spectral file",
+			Fingerprint: layoutFingerprint([][]string{headers}),
+			Missing:     missing,
+			Unknown:     unknown,
+		}
+	}
+	return columns, nil
+}
+
+// parseRowOfSpectrumFile parses a bin row, the timestamp of a rejected row is returned when it could be read.
+func parseRowOfSpectrumFile(record []string, cellsNum int, columns []int) (time.Time, model.SpectrumBin, error) {
+	if len(record) != cellsNum {
+		return time.Time{}, model.SpectrumBin{}, fmt.Errorf("%w: expected %d cells, but got %d",
+			appmodel.ErrLayoutChanged, cellsNum, len(record))
+	}
+
+	value := func(column spectrumColumn) string {
+		return strings.TrimSpace(record[columns[column]])
+	}
+
+	timestamp, err := time.Parse("02/01/2006 15:04", value(spectrumColumnDate)+" "+value(spectrumColumnTime))
+	if err != nil {
+		return time.Time{}, model.SpectrumBin{}, fmt.Errorf(
+			"%w: invalid date or time format, expected DD/MM/YYYY and HH:MM", model.ErrInvalidObservation)
+	}
+
+	var values [4]float64
+	for i, column := range []spectrumColumn{
+		spectrumColumnFrequency, spectrumColumnEnergyDensity, spectrumColumnDirection, spectrumColumnSpread,
+	} {
+		// Decimal commas are read like decimal points.
+		values[i], err = strconv.ParseFloat(strings.ReplaceAll(value(column), ",", "."), 64)
+		if err != nil {
+			return timestamp, model.SpectrumBin{}, fmt.Errorf(
+				"%w: invalid value for %s", model.ErrInvalidObservation, spectrumHeaders[column])
+		}
+	}
+
+	return timestamp, model.SpectrumBin{
+		Frequency:     values[0],
+		EnergyDensity: values[1],
+		Direction:     values[2],
+		Spread:        values[3],
+	}, nil
+}
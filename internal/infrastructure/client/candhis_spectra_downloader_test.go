@@ -0,0 +1,188 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	appmodeltest "github.com/tul1/candhis_api/internal/application/model/modeltest"
+	repo "github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"github.com/tul1/candhis_api/internal/infrastructure/client"
+)
+
+const mockSpectralFile = `Date;Heure (TU);Fréquence (Hz);Densité (m2/Hz);Direction (°);Etalement (°)
+17/09/2024;08:30;0,04;0,012;285;32
+17/09/2024;08:30;0,05;0,35;280;25
+17/09/2024;09:00;0.04;0.010;290;30
+17/09/2024;09:00;0.05;abc;282;24
+17/09/2024;09:00;0.06;0.41;278;21
+17/09/2024;09:30;0.05;0.2;270;20
+17/09/2024;09:30;0.04;0.1;270;20
+`
+
+var (
+	spectraFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	spectraTo   = time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+)
+
+func TestDownloadSpectra_Success(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		assert.Equal(t,
+			"https://candhis.cerema.fr/_public_/spectres.php?Y2FtcD0wMjkxMSZkYXRlRGViPTAxLzAxLzIwMjQmZGF0ZUZpbj0wNy8wMS8yMDI0",
+			req.URL.String())
+		assert.Equal(t, "acceptCookies=true; PHPSESSID=valid-session-id", req.Header.Get("Cookie"))
+		return MockHTTPResponse(200, mockSpectralFile)
+	}
+	downloader := setupMockCandhisSpectraDownloader(t, mockHandler)
+
+	file, err := downloader.DownloadSpectra(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==", spectraFrom, spectraTo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.Spectrum{
+		modeltest.MustCreateSpectrum(t, time.Date(2024, 9, 17, 8, 30, 0, 0, time.UTC),
+			model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+			model.SpectrumBin{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+		),
+	}, file.Spectra)
+	assert.Equal(t, 7, file.RowsSeen)
+	assert.Equal(t, []appmodel.RejectedRow{
+		{Row: 4, Reason: "invalid observation: invalid value for Densité (m2/Hz)"},
+		{Row: 3, Reason: "invalid observation: spectrum of 2024-09-17T09:00:00Z dropped, its row 4 was rejected"},
+		{Row: 6, Reason: "invalid observation: spectrum frequencies must be positive and increasing"},
+	}, file.Rejected)
+}
+
+func TestDownloadSpectra_RejectedRowDropsItsSpectrum(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, `Date;Heure (TU);Fréquence (Hz);Densité (m2/Hz);Direction (°);Etalement (°)
+17/09/2024;08:30;0.04;0.012;285;32
+17/09/2024;08:30;0.05;0.35;280;25
+17/09/2024;09:00;0.04;0.010;north;30
+17/09/2024;09:00;0.05;0.2;282;24
+17/09/2024;09:00;0.06;0.41;278;21
+17/09/2024;09:00;0.07;0.3;276
+17/09/2024;09:30;0.04;0.1;270;20
+`)
+	}
+	downloader := setupMockCandhisSpectraDownloader(t, mockHandler)
+
+	file, err := downloader.DownloadSpectra(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==", spectraFrom, spectraTo)
+	require.NoError(t, err)
+
+	// The valid bins of 09:00 are not stored as a truncated spectrum, and the row without its spread cell, whose
+	// spectrum cannot be told, only rejects itself.
+	assert.Equal(t, []model.Spectrum{
+		modeltest.MustCreateSpectrum(t, time.Date(2024, 9, 17, 8, 30, 0, 0, time.UTC),
+			model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+			model.SpectrumBin{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+		),
+		modeltest.MustCreateSpectrum(t, time.Date(2024, 9, 17, 9, 30, 0, 0, time.UTC),
+			model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.1, Direction: 270, Spread: 20},
+		),
+	}, file.Spectra)
+	assert.Equal(t, 7, file.RowsSeen)
+	assert.Equal(t, []appmodel.RejectedRow{
+		{Row: 3, Reason: "invalid observation: invalid value for Direction (°)"},
+		{Row: 6, Reason: "candhis page layout changed: expected 6 cells, but got 5"},
+		{Row: 3, Reason: "invalid observation: spectrum of 2024-09-17T09:00:00Z dropped, its row 3 was rejected"},
+	}, file.Rejected)
+}
+
+func TestDownloadSpectra_ReorderedColumns(t *testing.T) {
+	mockHandler := func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, "Heure (TU);Date;Direction (°);Etalement (°);Frequence (Hz);Densite (m2/Hz)\n"+
+			"09:00;17/09/2024;285;32;0.04;0.012\n")
+	}
+	downloader := setupMockCandhisSpectraDownloader(t, mockHandler)
+
+	file, err := downloader.DownloadSpectra(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==", spectraFrom, spectraTo)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Spectrum{
+		modeltest.MustCreateSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC),
+			model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		),
+	}, file.Spectra)
+}
+
+func TestDownloadSpectra_EmptyFile(t *testing.T) {
+	downloader := setupMockCandhisSpectraDownloader(t, func(req *http.Request) *http.Response {
+		return MockHTTPResponse(200, "")
+	})
+
+	file, err := downloader.DownloadSpectra(
+		context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+		"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==", spectraFrom, spectraTo)
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.SpectrumFile{}, file)
+}
+
+func TestDownloadSpectra_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		mockHandler func(req *http.Request) *http.Response
+		errMsg      string
+		expectedErr error
+	}{
+		"cookie page": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, "<html><body>Veuillez accepter les cookies</body></html>")
+			},
+			errMsg:      "candhis session expired: no spectral file in response",
+			expectedErr: appmodel.ErrSessionExpired,
+		},
+		"forbidden": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(403, "")
+			},
+			errMsg:      "candhis session expired: status code 403",
+			expectedErr: appmodel.ErrSessionExpired,
+		},
+		"unknown column": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, "Date;Heure (TU);Fréquence (Hz);Densité (m2/Hz);Direction (°);Etalement (°);Phase\n")
+			},
+			errMsg:      `candhis page layout changed: spectral file has unknown columns ["Phase"], fingerprint 244cc9eb1ff98aab`,
+			expectedErr: appmodel.ErrLayoutChanged,
+		},
+		"rows without header cells": {
+			mockHandler: func(req *http.Request) *http.Response {
+				return MockHTTPResponse(200, "Date;Heure (TU);Fréquence (Hz);Densité (m2/Hz);Direction (°);Etalement (°)\n"+
+					"17/09/2024;09:00;0.04;0.012;285\n")
+			},
+			errMsg:      "candhis page layout changed: none of the 1 rows of the spectral file has as many cells as its header",
+			expectedErr: appmodel.ErrLayoutChanged,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			downloader := setupMockCandhisSpectraDownloader(t, tc.mockHandler)
+
+			file, err := downloader.DownloadSpectra(
+				context.Background(), appmodeltest.MustCreateCandhisSessionID(t, "valid-session-id"),
+				"https://candhis.cerema.fr/_public_/campagne.php?Y2FtcD0wMjkxMQ==", spectraFrom, spectraTo)
+			assert.EqualError(t, err, tc.errMsg)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, appmodel.SpectrumFile{}, file)
+		})
+	}
+}
+
+func setupMockCandhisSpectraDownloader(t *testing.T, mockHandler func(req *http.Request) *http.Response) repo.CandhisSpectraDownloader {
+	t.Helper()
+
+	mockClient := &http.Client{Transport: &mockRoundTripper{mockHandler: mockHandler}}
+
+	return client.NewCandhisSpectraDownloader(client.NewCandhisHTTPClient(mockClient, mockCandhisHTTPOptions))
+}
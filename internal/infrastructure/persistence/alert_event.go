@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+type alertEvent struct {
+	dbConn *sql.DB
+}
+
+func NewAlertEvent(dbConn *sql.DB) *alertEvent {
+	return &alertEvent{
+		dbConn: dbConn,
+	}
+}
+
+func (r *alertEvent) Add(ctx context.Context, event model.AlertEvent) error {
+	_, err := r.dbConn.ExecContext(ctx,
+		`INSERT INTO alert_events (rule_id, state, value, observed_at, fired_at, created_at, delivery_attempts,
+			delivery_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		event.RuleID(), string(event.State()), event.Value(), event.ObservedAt(), event.FiredAt(), event.CreatedAt(),
+		event.DeliveryAttempts(), event.DeliveryError())
+	if err != nil {
+		return fmt.Errorf("failed to insert alert event: %w", dbError(err))
+	}
+
+	return nil
+}
+
+func (r *alertEvent) LatestByRule(ctx context.Context) (map[int64]model.AlertEvent, error) {
+	rows, err := r.dbConn.QueryContext(ctx,
+		`SELECT DISTINCT ON (rule_id) rule_id, state, value, observed_at, fired_at, created_at, delivery_attempts,
+			delivery_error
+		FROM alert_events ORDER BY rule_id, created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest alert events from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	events := make(map[int64]model.AlertEvent)
+	for rows.Next() {
+		event, err := scanAlertEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events[event.RuleID()] = event
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get latest alert events from database: %w", dbError(err))
+	}
+
+	return events, nil
+}
+
+func (r *alertEvent) ListByRule(ctx context.Context, ruleID int64, limit int) ([]model.AlertEvent, error) {
+	rows, err := r.dbConn.QueryContext(ctx,
+		`SELECT rule_id, state, value, observed_at, fired_at, created_at, delivery_attempts, delivery_error
+		FROM alert_events WHERE rule_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`, ruleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert events from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	events := make([]model.AlertEvent, 0)
+	for rows.Next() {
+		event, err := scanAlertEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list alert events from database: %w", dbError(err))
+	}
+
+	return events, nil
+}
+
+func scanAlertEvent(rows *sql.Rows) (model.AlertEvent, error) {
+	var ruleID int64
+	var state, deliveryError string
+	var value float64
+	var observedAt, firedAt, createdAt time.Time
+	var deliveryAttempts int
+
+	err := rows.Scan(&ruleID, &state, &value, &observedAt, &firedAt, &createdAt, &deliveryAttempts, &deliveryError)
+	if err != nil {
+		return model.AlertEvent{}, fmt.Errorf("failed to scan alert event: %w", dbError(err))
+	}
+
+	event, err := model.NewAlertEvent(ruleID, model.AlertState(state), value, observedAt.UTC(), firedAt.UTC(),
+		createdAt.UTC(), deliveryAttempts, deliveryError)
+	if err != nil {
+		return model.AlertEvent{}, fmt.Errorf("failed to create alert event: %w", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,118 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+var alertEventRows = []string{
+	"rule_id", "state", "value", "observed_at", "fired_at", "created_at", "delivery_attempts", "delivery_error",
+}
+
+func TestAlertEventStore_Add_Success(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	observedAt := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	firedAt := time.Date(2024, 9, 17, 10, 5, 0, 0, time.UTC)
+	event := modeltest.MustCreateAlertEvent(t, 7, model.AlertStateFiring, 4.6, observedAt, firedAt, firedAt, 1, "")
+
+	mock.ExpectExec(`INSERT INTO alert_events`).
+		WithArgs(int64(7), "firing", 4.6, observedAt, firedAt, firedAt, 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, repo.Add(context.Background(), event))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertEventStore_Add_DatabaseError(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	now := time.Now().UTC()
+	event := modeltest.MustCreateAlertEvent(t, 7, model.AlertStateFiring, 4.6, now, now, now, 1, "")
+
+	mock.ExpectExec(`INSERT INTO alert_events`).WillReturnError(errors.New("insert error"))
+
+	err := repo.Add(context.Background(), event)
+	assert.EqualError(t, err, "failed to insert alert event: insert error")
+}
+
+func TestAlertEventStore_LatestByRule_Success(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	observedAt := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	firedAt := time.Date(2024, 9, 17, 10, 5, 0, 0, time.UTC)
+	resolvedAt := firedAt.Add(2 * time.Hour)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(rule_id\) (.+) FROM alert_events ORDER BY rule_id, created_at DESC`).
+		WillReturnRows(sqlmock.NewRows(alertEventRows).
+			AddRow(7, "firing", 4.6, observedAt, firedAt, firedAt, 1, "").
+			AddRow(8, "resolved", 0.4, observedAt, firedAt, resolvedAt, 3, "unexpected status 502 Bad Gateway"))
+
+	events, err := repo.LatestByRule(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]model.AlertEvent{
+		7: modeltest.MustCreateAlertEvent(t, 7, model.AlertStateFiring, 4.6, observedAt, firedAt, firedAt, 1, ""),
+		8: modeltest.MustCreateAlertEvent(t, 8, model.AlertStateResolved, 0.4, observedAt, firedAt, resolvedAt, 3,
+			"unexpected status 502 Bad Gateway"),
+	}, events)
+}
+
+func TestAlertEventStore_LatestByRule_DatabaseError(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(rule_id\)`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.LatestByRule(context.Background())
+	assert.EqualError(t, err, "failed to get latest alert events from database: database error")
+}
+
+func TestAlertEventStore_ListByRule_Success(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	observedAt := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	firedAt := time.Date(2024, 9, 17, 10, 5, 0, 0, time.UTC)
+	resolvedAt := firedAt.Add(2 * time.Hour)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_events WHERE rule_id = \$1 ORDER BY created_at DESC, id DESC LIMIT \$2`).
+		WithArgs(int64(7), 10).
+		WillReturnRows(sqlmock.NewRows(alertEventRows).
+			AddRow(7, "resolved", 3.2, observedAt.Add(2*time.Hour), firedAt, resolvedAt, 1, "").
+			AddRow(7, "firing", 4.6, observedAt, firedAt, firedAt, 1, ""))
+
+	events, err := repo.ListByRule(context.Background(), 7, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.AlertEvent{
+		modeltest.MustCreateAlertEvent(t, 7, model.AlertStateResolved, 3.2, observedAt.Add(2*time.Hour), firedAt,
+			resolvedAt, 1, ""),
+		modeltest.MustCreateAlertEvent(t, 7, model.AlertStateFiring, 4.6, observedAt, firedAt, firedAt, 1, ""),
+	}, events)
+}
+
+func TestAlertEventStore_ListByRule_DatabaseError(t *testing.T) {
+	repo, mock := setupAlertEventSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_events`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.ListByRule(context.Background(), 7, 10)
+	assert.EqualError(t, err, "failed to list alert events from database: database error")
+}
+
+func setupAlertEventSQLMock(t *testing.T) (repository.AlertEvent, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return persistence.NewAlertEvent(db), mock
+}
@@ -0,0 +1,136 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+const alertRuleColumns = `id, name, campaign, metric, comparator, threshold, window_seconds, cooldown_seconds, webhook_url,
+	enabled`
+
+type alertRule struct {
+	dbConn *sql.DB
+}
+
+func NewAlertRule(dbConn *sql.DB) *alertRule {
+	return &alertRule{
+		dbConn: dbConn,
+	}
+}
+
+func (r *alertRule) Add(ctx context.Context, rule model.AlertRule) (model.AlertRule, error) {
+	var id int64
+	err := r.dbConn.QueryRowContext(ctx,
+		`INSERT INTO alert_rules (name, campaign, metric, comparator, threshold, window_seconds, cooldown_seconds,
+			webhook_url, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING id`,
+		rule.Name(), rule.Campaign(), string(rule.Metric()), string(rule.Comparator()), rule.Threshold(),
+		int(rule.Window().Seconds()), int(rule.Cooldown().Seconds()), rule.WebhookURL(), rule.Enabled(),
+		time.Now().UTC()).Scan(&id)
+	if err != nil {
+		return model.AlertRule{}, fmt.Errorf("failed to insert alert rule: %w", dbError(err))
+	}
+
+	return model.NewAlertRule(id, rule.Name(), rule.Campaign(), rule.Metric(), rule.Comparator(), rule.Threshold(),
+		rule.Window(), rule.Cooldown(), rule.WebhookURL(), rule.Enabled())
+}
+
+func (r *alertRule) Update(ctx context.Context, rule model.AlertRule) error {
+	result, err := r.dbConn.ExecContext(ctx,
+		`UPDATE alert_rules SET name = $2, campaign = $3, metric = $4, comparator = $5, threshold = $6,
+			window_seconds = $7, cooldown_seconds = $8, webhook_url = $9, enabled = $10, updated_at = $11
+		WHERE id = $1`,
+		rule.ID(), rule.Name(), rule.Campaign(), string(rule.Metric()), string(rule.Comparator()), rule.Threshold(),
+		int(rule.Window().Seconds()), int(rule.Cooldown().Seconds()), rule.WebhookURL(), rule.Enabled(),
+		time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to update alert rule: %w", dbError(err))
+	}
+
+	return checkAlertRuleAffected(result)
+}
+
+func (r *alertRule) Delete(ctx context.Context, id int64) error {
+	result, err := r.dbConn.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", dbError(err))
+	}
+
+	return checkAlertRuleAffected(result)
+}
+
+func (r *alertRule) Get(ctx context.Context, id int64) (*model.AlertRule, error) {
+	row := r.dbConn.QueryRowContext(ctx, `SELECT `+alertRuleColumns+` FROM alert_rules WHERE id = $1`, id)
+
+	rule, err := scanAlertRule(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alert rule from database: %w", dbError(err))
+	}
+
+	return &rule, nil
+}
+
+func (r *alertRule) List(ctx context.Context) ([]model.AlertRule, error) {
+	rows, err := r.dbConn.QueryContext(ctx, `SELECT `+alertRuleColumns+` FROM alert_rules ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	rules := make([]model.AlertRule, 0)
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list alert rules from database: %w", dbError(err))
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list alert rules from database: %w", dbError(err))
+	}
+
+	return rules, nil
+}
+
+func checkAlertRuleAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to count affected alert rules: %w", dbError(err))
+	}
+	if affected == 0 {
+		return model.ErrAlertRuleNotFound
+	}
+	return nil
+}
+
+func scanAlertRule(row interface{ Scan(dest ...any) error }) (model.AlertRule, error) {
+	var id int64
+	var name, campaign, metric, comparator, webhookURL string
+	var threshold float64
+	var windowSeconds, cooldownSeconds int
+	var enabled bool
+
+	err := row.Scan(&id, &name, &campaign, &metric, &comparator, &threshold, &windowSeconds, &cooldownSeconds,
+		&webhookURL, &enabled)
+	if err != nil {
+		return model.AlertRule{}, err
+	}
+
+	rule, err := model.NewAlertRule(id, name, campaign, model.AlertMetric(metric), model.AlertComparator(comparator),
+		threshold, time.Duration(windowSeconds)*time.Second, time.Duration(cooldownSeconds)*time.Second, webhookURL,
+		enabled)
+	if err != nil {
+		return model.AlertRule{}, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	return rule, nil
+}
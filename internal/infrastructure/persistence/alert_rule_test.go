@@ -0,0 +1,155 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+const alertWebhookURL = "https://hooks.example.com/candhis"
+
+var alertRuleRows = []string{
+	"id", "name", "campaign", "metric", "comparator", "threshold", "window_seconds", "cooldown_seconds", "webhook_url",
+	"enabled",
+}
+
+func TestAlertRuleStore_Add_Success(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	rule := modeltest.MustCreateAlertRule(t, 0, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 4, 2*time.Hour, 6*time.Hour, alertWebhookURL, true)
+
+	mock.ExpectQuery(`INSERT INTO alert_rules (.+) RETURNING id`).
+		WithArgs("Big swell", "les-pierres-noires", "hmax", "gt", 4.0, 7200, 21600, alertWebhookURL, true, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	stored, err := repo.Add(context.Background(), rule)
+	require.NoError(t, err)
+	assert.Equal(t, modeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 4, 2*time.Hour, 6*time.Hour, alertWebhookURL, true), stored)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleStore_Add_DatabaseError(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	rule := modeltest.MustCreateAlertRule(t, 0, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 4, 0, 0, alertWebhookURL, true)
+
+	mock.ExpectQuery(`INSERT INTO alert_rules`).WillReturnError(errors.New("insert error"))
+
+	_, err := repo.Add(context.Background(), rule)
+	assert.EqualError(t, err, "failed to insert alert rule: insert error")
+}
+
+func TestAlertRuleStore_Update_Success(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	rule := modeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThanOrEqual, 5, 0, time.Hour, alertWebhookURL, false)
+
+	mock.ExpectExec(`UPDATE alert_rules SET (.+) WHERE id = \$1`).
+		WithArgs(int64(7), "Big swell", "les-pierres-noires", "hmax", "gte", 5.0, 0, 3600, alertWebhookURL, false,
+			sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Update(context.Background(), rule))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAlertRuleStore_Update_NotFound(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	rule := modeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 4, 0, 0, alertWebhookURL, true)
+
+	mock.ExpectExec(`UPDATE alert_rules`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Update(context.Background(), rule)
+	assert.ErrorIs(t, err, model.ErrAlertRuleNotFound)
+}
+
+func TestAlertRuleStore_Delete(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	mock.ExpectExec(`DELETE FROM alert_rules WHERE id = \$1`).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM alert_rules WHERE id = \$1`).WithArgs(int64(8)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM alert_rules`).WithArgs(int64(9)).WillReturnError(errors.New("delete error"))
+
+	assert.NoError(t, repo.Delete(context.Background(), 7))
+	assert.ErrorIs(t, repo.Delete(context.Background(), 8), model.ErrAlertRuleNotFound)
+	assert.EqualError(t, repo.Delete(context.Background(), 9), "failed to delete alert rule: delete error")
+}
+
+func TestAlertRuleStore_Get_Success(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules WHERE id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(alertRuleRows).
+			AddRow(7, "Rising swell", "les-pierres-noires", "h1_3_rise", "gt", 1.0, 10800, 0, alertWebhookURL, true))
+
+	rule, err := repo.Get(context.Background(), 7)
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.Equal(t, modeltest.MustCreateAlertRule(t, 7, "Rising swell", "les-pierres-noires",
+		model.AlertMetricAverageTopThirdWaveHeightRise, model.AlertComparatorGreaterThan, 1, 3*time.Hour, 0,
+		alertWebhookURL, true), *rule)
+}
+
+func TestAlertRuleStore_Get_NotFound(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules WHERE id = \$1`).WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(alertRuleRows))
+
+	rule, err := repo.Get(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestAlertRuleStore_List_Success(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules ORDER BY id`).
+		WillReturnRows(sqlmock.NewRows(alertRuleRows).
+			AddRow(7, "Big swell", "les-pierres-noires", "hmax", "gt", 4.0, 0, 21600, alertWebhookURL, true).
+			AddRow(8, "Cold water", "belle-ile", "temperature", "lt", 10.0, 0, 0, alertWebhookURL, false))
+
+	rules, err := repo.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []model.AlertRule{
+		modeltest.MustCreateAlertRule(t, 7, "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+			model.AlertComparatorGreaterThan, 4, 0, 6*time.Hour, alertWebhookURL, true),
+		modeltest.MustCreateAlertRule(t, 8, "Cold water", "belle-ile", model.AlertMetricTemperature,
+			model.AlertComparatorLessThan, 10, 0, 0, alertWebhookURL, false),
+	}, rules)
+}
+
+func TestAlertRuleStore_List_DatabaseError(t *testing.T) {
+	repo, mock := setupAlertRuleSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.List(context.Background())
+	assert.EqualError(t, err, "failed to list alert rules from database: database error")
+}
+
+func setupAlertRuleSQLMock(t *testing.T) (repository.AlertRule, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return persistence.NewAlertRule(db), mock
+}
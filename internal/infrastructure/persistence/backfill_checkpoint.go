@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+type backfillCheckpoint struct {
+	dbConn *sql.DB
+}
+
+func NewBackfillCheckpoint(dbConn *sql.DB) *backfillCheckpoint {
+	return &backfillCheckpoint{
+		dbConn: dbConn,
+	}
+}
+
+func (r *backfillCheckpoint) Get(ctx context.Context, buoyID string, from, to time.Time) (*model.BackfillCheckpoint, error) {
+	row := r.dbConn.QueryRowContext(ctx,
+		`SELECT done_until FROM backfill_checkpoints WHERE buoy_id = $1 AND range_from = $2 AND range_to = $3`,
+		buoyID, from, to)
+
+	var doneUntil time.Time
+	err := row.Scan(&doneUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get backfill checkpoint from database: %w", dbError(err))
+	}
+
+	checkpoint, err := model.NewBackfillCheckpoint(buoyID, from, to, doneUntil.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backfill checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+func (r *backfillCheckpoint) Save(ctx context.Context, checkpoint model.BackfillCheckpoint) error {
+	_, err := r.dbConn.ExecContext(ctx,
+		`INSERT INTO backfill_checkpoints (buoy_id, range_from, range_to, done_until, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (buoy_id, range_from, range_to) DO UPDATE SET done_until = $4, updated_at = $5`,
+		checkpoint.BuoyID(), checkpoint.From(), checkpoint.To(), checkpoint.DoneUntil(), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save backfill checkpoint: %w", dbError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+var (
+	backfillFrom = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	backfillTo   = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestBackfillCheckpointStore_Get_Success(t *testing.T) {
+	repo, mock := setupBackfillCheckpointSQLMock(t)
+
+	doneUntil := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT done_until FROM backfill_checkpoints WHERE buoy_id = \$1 AND range_from = \$2 AND range_to = \$3`).
+		WithArgs("02911", backfillFrom, backfillTo).
+		WillReturnRows(sqlmock.NewRows([]string{"done_until"}).AddRow(doneUntil))
+
+	checkpoint, err := repo.Get(context.Background(), "02911", backfillFrom, backfillTo)
+	require.NoError(t, err)
+
+	expected, err := model.NewBackfillCheckpoint("02911", backfillFrom, backfillTo, doneUntil)
+	require.NoError(t, err)
+	assert.Equal(t, &expected, checkpoint)
+}
+
+func TestBackfillCheckpointStore_Get_NotFound(t *testing.T) {
+	repo, mock := setupBackfillCheckpointSQLMock(t)
+
+	mock.ExpectQuery(`SELECT done_until FROM backfill_checkpoints`).
+		WillReturnRows(sqlmock.NewRows([]string{"done_until"}))
+
+	checkpoint, err := repo.Get(context.Background(), "02911", backfillFrom, backfillTo)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestBackfillCheckpointStore_Get_DatabaseError(t *testing.T) {
+	repo, mock := setupBackfillCheckpointSQLMock(t)
+
+	mock.ExpectQuery(`SELECT done_until FROM backfill_checkpoints`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.Get(context.Background(), "02911", backfillFrom, backfillTo)
+	assert.EqualError(t, err, "failed to get backfill checkpoint from database: database error")
+}
+
+func TestBackfillCheckpointStore_Save(t *testing.T) {
+	repo, mock := setupBackfillCheckpointSQLMock(t)
+
+	doneUntil := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	checkpoint, err := model.NewBackfillCheckpoint("02911", backfillFrom, backfillTo, doneUntil)
+	require.NoError(t, err)
+
+	mock.ExpectExec(`INSERT INTO backfill_checkpoints (.+) ON CONFLICT \(buoy_id, range_from, range_to\) DO UPDATE`).
+		WithArgs("02911", backfillFrom, backfillTo, doneUntil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO backfill_checkpoints`).WillReturnError(errors.New("insert error"))
+
+	require.NoError(t, repo.Save(context.Background(), checkpoint))
+	assert.EqualError(t, repo.Save(context.Background(), checkpoint), "failed to save backfill checkpoint: insert error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func setupBackfillCheckpointSQLMock(t *testing.T) (repository.BackfillCheckpoint, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return persistence.NewBackfillCheckpoint(db), mock
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/jackc/pgx/v5/pgconn"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+// dbError wraps appmodel.ErrStorageUnavailable into the errors of PostgreSQL that come from a lost or refused
+// connection, the other errors are returned as is.
+func dbError(err error) error {
+	if !dbUnavailable(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", appmodel.ErrStorageUnavailable, err)
+}
+
+func dbUnavailable(err error) bool {
+	var netErr net.Error
+	var connectErr *pgconn.ConnectError
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone),
+		errors.As(err, &netErr), errors.As(err, &connectErr):
+		return true
+	case errors.As(err, &pgErr):
+		// Connection exceptions, insufficient resources, and a server shutting down or starting up.
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") ||
+			strings.HasPrefix(pgErr.Code, "57P")
+	default:
+		return false
+	}
+}
+
+// esTransportError wraps appmodel.ErrStorageUnavailable into the error of an Elasticsearch request that got no
+// response, unless the request was cancelled.
+func esTransportError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", appmodel.ErrStorageUnavailable, err)
+}
+
+// esResponseError reads the error response of an Elasticsearch request, it wraps appmodel.ErrStorageUnavailable when
+// Elasticsearch failed or rejected the request for lack of resources.
+func esResponseError(res *esapi.Response) error {
+	body, _ := io.ReadAll(res.Body)
+	err := fmt.Errorf("%s, body: %s", res.Status(), string(body))
+	if res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", appmodel.ErrStorageUnavailable, err)
+	}
+	return err
+}
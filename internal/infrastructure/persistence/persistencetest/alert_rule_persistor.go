@@ -0,0 +1,30 @@
+package persistencetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type alertRulePersistor struct {
+	t  *testing.T
+	db *sql.DB
+}
+
+func NewAlertRulePersistor(t *testing.T, db *sql.DB) *alertRulePersistor {
+	t.Helper()
+
+	return &alertRulePersistor{
+		t:  t,
+		db: db,
+	}
+}
+
+// Clear also removes the alert events, which are deleted along with their rule.
+func (p *alertRulePersistor) Clear() {
+	p.t.Helper()
+
+	_, err := p.db.Exec("DELETE FROM alert_rules")
+	require.NoError(p.t, err, "failed to clear alert_rules table: %v", err)
+}
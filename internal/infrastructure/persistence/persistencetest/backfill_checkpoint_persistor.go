@@ -0,0 +1,29 @@
+package persistencetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type backfillCheckpointPersistor struct {
+	t  *testing.T
+	db *sql.DB
+}
+
+func NewBackfillCheckpointPersistor(t *testing.T, db *sql.DB) *backfillCheckpointPersistor {
+	t.Helper()
+
+	return &backfillCheckpointPersistor{
+		t:  t,
+		db: db,
+	}
+}
+
+func (p *backfillCheckpointPersistor) Clear() {
+	p.t.Helper()
+
+	_, err := p.db.Exec("DELETE FROM backfill_checkpoints")
+	require.NoError(p.t, err, "failed to clear backfill_checkpoints table: %v", err)
+}
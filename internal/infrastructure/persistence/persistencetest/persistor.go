@@ -9,14 +9,22 @@ import (
 )
 
 type Persistor struct {
-	sessionIDPersistor *sessionIDPersistor
+	sessionIDPersistor          *sessionIDPersistor
+	scrapeRunPersistor          *scrapeRunPersistor
+	backfillCheckpointPersistor *backfillCheckpointPersistor
+	stationPersistor            *stationPersistor
+	alertRulePersistor          *alertRulePersistor
 }
 
 func NewPersistor(t *testing.T, db *sql.DB) *Persistor {
 	t.Helper()
 
 	return &Persistor{
-		sessionIDPersistor: NewSessionIDPersistor(t, db),
+		sessionIDPersistor:          NewSessionIDPersistor(t, db),
+		scrapeRunPersistor:          NewScrapeRunPersistor(t, db),
+		backfillCheckpointPersistor: NewBackfillCheckpointPersistor(t, db),
+		stationPersistor:            NewStationPersistor(t, db),
+		alertRulePersistor:          NewAlertRulePersistor(t, db),
 	}
 }
 
@@ -24,8 +32,28 @@ func (p *Persistor) SessionID() *sessionIDPersistor {
 	return p.sessionIDPersistor
 }
 
+func (p *Persistor) ScrapeRun() *scrapeRunPersistor {
+	return p.scrapeRunPersistor
+}
+
+func (p *Persistor) BackfillCheckpoint() *backfillCheckpointPersistor {
+	return p.backfillCheckpointPersistor
+}
+
+func (p *Persistor) Station() *stationPersistor {
+	return p.stationPersistor
+}
+
+func (p *Persistor) AlertRule() *alertRulePersistor {
+	return p.alertRulePersistor
+}
+
 func (p *Persistor) Clear() {
 	p.sessionIDPersistor.Clear()
+	p.scrapeRunPersistor.Clear()
+	p.backfillCheckpointPersistor.Clear()
+	p.stationPersistor.Clear()
+	p.alertRulePersistor.Clear()
 }
 
 type ESPersistor struct {
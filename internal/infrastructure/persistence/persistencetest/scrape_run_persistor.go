@@ -0,0 +1,29 @@
+package persistencetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scrapeRunPersistor struct {
+	t  *testing.T
+	db *sql.DB
+}
+
+func NewScrapeRunPersistor(t *testing.T, db *sql.DB) *scrapeRunPersistor {
+	t.Helper()
+
+	return &scrapeRunPersistor{
+		t:  t,
+		db: db,
+	}
+}
+
+func (p *scrapeRunPersistor) Clear() {
+	p.t.Helper()
+
+	_, err := p.db.Exec("DELETE FROM scrape_runs")
+	require.NoError(p.t, err, "failed to clear scrape_runs table: %v", err)
+}
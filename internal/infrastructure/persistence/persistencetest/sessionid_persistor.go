@@ -3,6 +3,7 @@ package persistencetest
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tul1/candhis_api/internal/application/model"
@@ -22,16 +23,28 @@ func NewSessionIDPersistor(t *testing.T, db *sql.DB) *sessionIDPersistor {
 	}
 }
 
-func (p *sessionIDPersistor) Add(sessionID *model.CandhisSessionID) {
+func (p *sessionIDPersistor) Add(session model.CandhisSession) {
 	p.t.Helper()
 
-	_, err := p.db.Exec("INSERT INTO candhis_session (id, created_at) VALUES ($1, $2)", sessionID.ID(), sessionID.CreatedAt())
+	_, err := p.db.Exec("INSERT INTO candhis_sessions (session_id, source, created_at, valid_until) VALUES ($1, $2, $3, $4)",
+		session.SessionID().ID(), session.Source(), session.SessionID().CreatedAt(), session.ValidUntil())
 	require.NoError(p.t, err, "failed to insert session ID: %v", err)
 }
 
+// Session returns the last used and invalidation times of a stored session ID.
+func (p *sessionIDPersistor) Session(sessionID model.CandhisSessionID) (lastUsedAt, invalidatedAt *time.Time) {
+	p.t.Helper()
+
+	err := p.db.QueryRow("SELECT last_used_at, invalidated_at FROM candhis_sessions WHERE session_id = $1", sessionID.ID()).
+		Scan(&lastUsedAt, &invalidatedAt)
+	require.NoError(p.t, err, "failed to get session ID: %v", err)
+
+	return lastUsedAt, invalidatedAt
+}
+
 func (p *sessionIDPersistor) Clear() {
 	p.t.Helper()
 
-	_, err := p.db.Exec("DELETE FROM candhis_session")
-	require.NoError(p.t, err, "failed to clear candhis_session table: %v", err)
+	_, err := p.db.Exec("DELETE FROM candhis_sessions")
+	require.NoError(p.t, err, "failed to clear candhis_sessions table: %v", err)
 }
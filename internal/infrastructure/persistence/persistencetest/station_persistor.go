@@ -0,0 +1,29 @@
+package persistencetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stationPersistor struct {
+	t  *testing.T
+	db *sql.DB
+}
+
+func NewStationPersistor(t *testing.T, db *sql.DB) *stationPersistor {
+	t.Helper()
+
+	return &stationPersistor{
+		t:  t,
+		db: db,
+	}
+}
+
+func (p *stationPersistor) Clear() {
+	p.t.Helper()
+
+	_, err := p.db.Exec("DELETE FROM campaigns")
+	require.NoError(p.t, err, "failed to clear campaigns table: %v", err)
+}
@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+)
+
+type scrapeRun struct {
+	dbConn *sql.DB
+}
+
+func NewScrapeRun(dbConn *sql.DB) *scrapeRun {
+	return &scrapeRun{
+		dbConn: dbConn,
+	}
+}
+
+func (r *scrapeRun) Add(ctx context.Context, run model.ScrapeRun) error {
+	counts := run.Counts()
+	_, err := r.dbConn.ExecContext(ctx,
+		`INSERT INTO scrape_runs (scraper, campaign, started_at, finished_at, outcome, error,
+			rows_seen, parsed, rejected, indexed, unchanged, failed, layout_fingerprint)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		string(run.Scraper()), run.Campaign(), run.StartedAt(), run.FinishedAt(), string(run.Outcome()), run.ErrorText(),
+		counts.RowsSeen, counts.Parsed, counts.Rejected, counts.Indexed, counts.Unchanged, counts.Failed,
+		counts.LayoutFingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to insert scrape run: %w", dbError(err))
+	}
+
+	return nil
+}
+
+func (r *scrapeRun) ListRecent(ctx context.Context, limit int) ([]model.ScrapeRun, error) {
+	rows, err := r.dbConn.QueryContext(ctx,
+		`SELECT scraper, campaign, started_at, finished_at, error,
+			rows_seen, parsed, rejected, indexed, unchanged, failed, layout_fingerprint
+		FROM scrape_runs ORDER BY started_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scrape runs from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	runs := make([]model.ScrapeRun, 0)
+	for rows.Next() {
+		run, err := scanScrapeRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list scrape runs from database: %w", dbError(err))
+	}
+
+	return runs, nil
+}
+
+func (r *scrapeRun) LatestByCampaign(ctx context.Context, scraper model.Scraper) (map[string]model.ScrapeRun, error) {
+	rows, err := r.dbConn.QueryContext(ctx,
+		`SELECT DISTINCT ON (campaign) scraper, campaign, started_at, finished_at, error,
+			rows_seen, parsed, rejected, indexed, unchanged, failed, layout_fingerprint
+		FROM scrape_runs WHERE scraper = $1 AND campaign <> '' ORDER BY campaign, started_at DESC`, string(scraper))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest scrape runs from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	runs := make(map[string]model.ScrapeRun)
+	for rows.Next() {
+		run, err := scanScrapeRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs[run.Campaign()] = run
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get latest scrape runs from database: %w", dbError(err))
+	}
+
+	return runs, nil
+}
+
+func (r *scrapeRun) LastRunFinishedAt(
+	ctx context.Context,
+	campaign string,
+	scrapers ...model.Scraper,
+) (time.Time, error) {
+	placeholders := make([]string, 0, len(scrapers))
+	args := []any{campaign}
+	for i, scraper := range scrapers {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+2))
+		args = append(args, string(scraper))
+	}
+
+	var finishedAt time.Time
+	err := r.dbConn.QueryRowContext(ctx,
+		`SELECT finished_at FROM scrape_runs
+		WHERE campaign = $1 AND scraper IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY started_at DESC LIMIT 1`,
+		args...).Scan(&finishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last scrape run from database: %w", dbError(err))
+	}
+
+	return finishedAt.UTC(), nil
+}
+
+func (r *scrapeRun) LastLayoutFingerprint(
+	ctx context.Context,
+	campaign string,
+	scrapers ...model.Scraper,
+) (string, error) {
+	placeholders := make([]string, 0, len(scrapers))
+	args := []any{campaign}
+	for i, scraper := range scrapers {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+2))
+		args = append(args, string(scraper))
+	}
+
+	var layoutFingerprint string
+	err := r.dbConn.QueryRowContext(ctx,
+		`SELECT layout_fingerprint FROM scrape_runs
+		WHERE campaign = $1 AND layout_fingerprint <> '' AND scraper IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY started_at DESC LIMIT 1`,
+		args...).Scan(&layoutFingerprint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get last layout fingerprint from database: %w", dbError(err))
+	}
+
+	return layoutFingerprint, nil
+}
+
+func scanScrapeRun(rows *sql.Rows) (model.ScrapeRun, error) {
+	var scraper, campaign, errorText string
+	var startedAt, finishedAt time.Time
+	var counts model.ScrapeRunCounts
+
+	err := rows.Scan(&scraper, &campaign, &startedAt, &finishedAt, &errorText,
+		&counts.RowsSeen, &counts.Parsed, &counts.Rejected, &counts.Indexed, &counts.Unchanged, &counts.Failed,
+		&counts.LayoutFingerprint)
+	if err != nil {
+		return model.ScrapeRun{}, fmt.Errorf("failed to scan scrape run: %w", dbError(err))
+	}
+
+	run, err := model.NewScrapeRun(model.Scraper(scraper), campaign, startedAt.UTC(), finishedAt.UTC(), errorText, counts)
+	if err != nil {
+		return model.ScrapeRun{}, fmt.Errorf("failed to create scrape run: %w", err)
+	}
+
+	return run, nil
+}
@@ -0,0 +1,239 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+func TestScrapeRunStore_Add_Success(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(2 * time.Second)
+	run, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt, finishedAt, "",
+		model.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1, LayoutFingerprint: "6c1b2f0e9d8a7b3c"})
+	require.NoError(t, err)
+
+	mock.ExpectExec(`INSERT INTO scrape_runs`).
+		WithArgs("campaigns", "02911", startedAt, finishedAt, "success", "", 3, 2, 1, 1, 1, 0, "6c1b2f0e9d8a7b3c").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.Add(context.Background(), run)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScrapeRunStore_Add_DatabaseError(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	now := time.Now().UTC()
+	run, err := model.NewScrapeRun(model.ScraperSessionID, "", now, now, "", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+
+	insertErr := errors.New("insert error")
+	mock.ExpectExec(`INSERT INTO scrape_runs`).WillReturnError(insertErr)
+
+	err = repo.Add(context.Background(), run)
+	assert.ErrorIs(t, err, insertErr)
+	assert.NotErrorIs(t, err, model.ErrStorageUnavailable)
+}
+
+func TestScrapeRunStore_Add_StorageUnavailable(t *testing.T) {
+	testCases := map[string]struct {
+		dbErr       error
+		unavailable bool
+	}{
+		"connection refused":  {dbErr: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, unavailable: true},
+		"admin shutdown":      {dbErr: &pgconn.PgError{Code: "57P01"}, unavailable: true},
+		"too many clients":    {dbErr: &pgconn.PgError{Code: "53300"}, unavailable: true},
+		"unique violation":    {dbErr: &pgconn.PgError{Code: "23505"}, unavailable: false},
+		"cancelled operation": {dbErr: context.Canceled, unavailable: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			repo, mock := setupScrapeRunSQLMock(t)
+
+			now := time.Now().UTC()
+			run, err := model.NewScrapeRun(model.ScraperSessionID, "", now, now, "", model.ScrapeRunCounts{})
+			require.NoError(t, err)
+
+			mock.ExpectExec(`INSERT INTO scrape_runs`).WillReturnError(tc.dbErr)
+
+			err = repo.Add(context.Background(), run)
+			assert.ErrorIs(t, err, tc.dbErr)
+			assert.Equal(t, tc.unavailable, errors.Is(err, model.ErrStorageUnavailable))
+		})
+	}
+}
+
+func TestScrapeRunStore_ListRecent_Success(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(2 * time.Second)
+
+	mock.ExpectQuery(`SELECT scraper, campaign, started_at, finished_at, error, (.+) FROM scrape_runs ORDER BY started_at DESC LIMIT \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"scraper", "campaign", "started_at", "finished_at", "error",
+			"rows_seen", "parsed", "rejected", "indexed", "unchanged", "failed", "layout_fingerprint",
+		}).
+			AddRow("campaigns", "02911", startedAt, finishedAt, "", 3, 2, 1, 1, 1, 0, "6c1b2f0e9d8a7b3c").
+			AddRow("sessionid", "", startedAt, finishedAt, "chrome down", 0, 0, 0, 0, 0, 0, ""))
+
+	runs, err := repo.ListRecent(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	assert.Equal(t, model.ScraperCampaigns, runs[0].Scraper())
+	assert.Equal(t, "02911", runs[0].Campaign())
+	assert.Equal(t, startedAt, runs[0].StartedAt())
+	assert.Equal(t, finishedAt, runs[0].FinishedAt())
+	assert.Equal(t, model.ScrapeOutcomeSuccess, runs[0].Outcome())
+	assert.Equal(t, model.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1,
+		LayoutFingerprint: "6c1b2f0e9d8a7b3c"}, runs[0].Counts())
+
+	assert.Equal(t, model.ScraperSessionID, runs[1].Scraper())
+	assert.Equal(t, model.ScrapeOutcomeFailure, runs[1].Outcome())
+	assert.Equal(t, "chrome down", runs[1].ErrorText())
+}
+
+func TestScrapeRunStore_ListRecent_DatabaseError(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	dbErr := errors.New("database error")
+	mock.ExpectQuery(`SELECT (.+) FROM scrape_runs`).WillReturnError(dbErr)
+
+	_, err := repo.ListRecent(context.Background(), 10)
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestScrapeRunStore_LatestByCampaign_Success(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(2 * time.Second)
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(campaign\) (.+) FROM scrape_runs WHERE scraper = \$1 AND campaign <> '' ` +
+		`ORDER BY campaign, started_at DESC`).
+		WithArgs("campaigns").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"scraper", "campaign", "started_at", "finished_at", "error",
+			"rows_seen", "parsed", "rejected", "indexed", "unchanged", "failed", "layout_fingerprint",
+		}).
+			AddRow("campaigns", "02911", startedAt, finishedAt, "", 3, 2, 1, 1, 1, 0, "6c1b2f0e9d8a7b3c").
+			AddRow("campaigns", "05602", startedAt, finishedAt, "error web", 0, 0, 0, 0, 0, 0, ""))
+
+	runs, err := repo.LatestByCampaign(context.Background(), model.ScraperCampaigns)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	assert.Equal(t, finishedAt, runs["02911"].FinishedAt())
+	assert.Equal(t, model.ScrapeOutcomeSuccess, runs["02911"].Outcome())
+	assert.Equal(t, model.ScrapeOutcomeFailure, runs["05602"].Outcome())
+}
+
+func TestScrapeRunStore_LatestByCampaign_DatabaseError(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	dbErr := errors.New("database error")
+	mock.ExpectQuery(`SELECT DISTINCT ON \(campaign\) (.+) FROM scrape_runs`).WillReturnError(dbErr)
+
+	_, err := repo.LatestByCampaign(context.Background(), model.ScraperCampaigns)
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestScrapeRunStore_LastRunFinishedAt_Success(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	finishedAt := time.Date(2024, 9, 17, 9, 0, 2, 0, time.UTC)
+	mock.ExpectQuery(`SELECT finished_at FROM scrape_runs WHERE campaign = \$1 AND scraper IN \(\$2, \$3\) `+
+		`ORDER BY started_at DESC LIMIT 1`).
+		WithArgs("02911", "campaigns", "backfill").
+		WillReturnRows(sqlmock.NewRows([]string{"finished_at"}).AddRow(finishedAt))
+
+	lastFinishedAt, err := repo.LastRunFinishedAt(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.Equal(t, finishedAt, lastFinishedAt)
+}
+
+func TestScrapeRunStore_LastRunFinishedAt_NoRun(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	mock.ExpectQuery(`SELECT finished_at FROM scrape_runs`).
+		WithArgs("02911", "campaigns").
+		WillReturnRows(sqlmock.NewRows([]string{"finished_at"}))
+
+	lastFinishedAt, err := repo.LastRunFinishedAt(context.Background(), "02911", model.ScraperCampaigns)
+	require.NoError(t, err)
+	assert.True(t, lastFinishedAt.IsZero())
+}
+
+func TestScrapeRunStore_LastRunFinishedAt_DatabaseError(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	dbErr := errors.New("database error")
+	mock.ExpectQuery(`SELECT finished_at FROM scrape_runs`).WillReturnError(dbErr)
+
+	_, err := repo.LastRunFinishedAt(context.Background(), "02911", model.ScraperCampaigns)
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestScrapeRunStore_LastLayoutFingerprint_Success(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	mock.ExpectQuery(`SELECT layout_fingerprint FROM scrape_runs WHERE campaign = \$1 AND layout_fingerprint <> '' `+
+		`AND scraper IN \(\$2, \$3\) ORDER BY started_at DESC LIMIT 1`).
+		WithArgs("02911", "campaigns", "backfill").
+		WillReturnRows(sqlmock.NewRows([]string{"layout_fingerprint"}).AddRow("6c1b2f0e9d8a7b3c"))
+
+	layoutFingerprint, err := repo.LastLayoutFingerprint(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.Equal(t, "6c1b2f0e9d8a7b3c", layoutFingerprint)
+}
+
+func TestScrapeRunStore_LastLayoutFingerprint_NoRun(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	mock.ExpectQuery(`SELECT layout_fingerprint FROM scrape_runs`).
+		WithArgs("02911", "campaigns").
+		WillReturnRows(sqlmock.NewRows([]string{"layout_fingerprint"}))
+
+	layoutFingerprint, err := repo.LastLayoutFingerprint(context.Background(), "02911", model.ScraperCampaigns)
+	require.NoError(t, err)
+	assert.Empty(t, layoutFingerprint)
+}
+
+func TestScrapeRunStore_LastLayoutFingerprint_DatabaseError(t *testing.T) {
+	repo, mock := setupScrapeRunSQLMock(t)
+
+	dbErr := errors.New("database error")
+	mock.ExpectQuery(`SELECT layout_fingerprint FROM scrape_runs`).WillReturnError(dbErr)
+
+	_, err := repo.LastLayoutFingerprint(context.Background(), "02911", model.ScraperCampaigns)
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func setupScrapeRunSQLMock(t *testing.T) (repository.ScrapeRun, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return persistence.NewScrapeRun(db), mock
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/tul1/candhis_api/internal/application/model"
-	"github.com/tul1/candhis_api/internal/pkg/db"
 )
 
 type sessionID struct {
@@ -22,7 +21,14 @@ func NewSessionID(dbConn *sql.DB) *sessionID {
 }
 
 func (r *sessionID) Get(ctx context.Context) (*model.CandhisSessionID, error) {
-	row := r.dbConn.QueryRowContext(ctx, `SELECT id, created_at FROM candhis_session`)
+	row := r.dbConn.QueryRowContext(ctx,
+		`UPDATE candhis_sessions SET last_used_at = $1
+		WHERE id = (
+			SELECT id FROM candhis_sessions WHERE invalidated_at IS NULL AND valid_until > $1
+			ORDER BY created_at DESC, id DESC LIMIT 1
+		)
+		RETURNING session_id, created_at`,
+		time.Now().UTC())
 
 	var id string
 	var createdAt time.Time
@@ -30,9 +36,9 @@ func (r *sessionID) Get(ctx context.Context) (*model.CandhisSessionID, error) {
 	err := row.Scan(&id, &createdAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("no session ID found in database")
+			return nil, model.ErrNoValidCandhisSession
 		}
-		return nil, fmt.Errorf("failed to get session ID from database: %w", err)
+		return nil, fmt.Errorf("failed to get session ID from database: %w", dbError(err))
 	}
 
 	c := createdAt.UTC()
@@ -44,23 +50,24 @@ func (r *sessionID) Get(ctx context.Context) (*model.CandhisSessionID, error) {
 	return &candhisSessionID, nil
 }
 
-func (r *sessionID) Update(ctx context.Context, sessionID model.CandhisSessionID) error {
-	return db.Transaction(ctx, r.dbConn, func(tx *sql.Tx) error {
-		query := `UPDATE candhis_session SET id = $1, created_at = $2`
-		result, err := tx.ExecContext(ctx, query, sessionID.ID(), sessionID.CreatedAt())
-		if err != nil {
-			return fmt.Errorf("failed to update session ID: %w", err)
-		}
+func (r *sessionID) Add(ctx context.Context, session model.CandhisSession) error {
+	_, err := r.dbConn.ExecContext(ctx,
+		`INSERT INTO candhis_sessions (session_id, source, created_at, valid_until) VALUES ($1, $2, $3, $4)`,
+		session.SessionID().ID(), session.Source(), session.SessionID().CreatedAt(), session.ValidUntil())
+	if err != nil {
+		return fmt.Errorf("failed to add session ID: %w", dbError(err))
+	}
 
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("failed to check affected rows: %w", err)
-		}
+	return nil
+}
 
-		if rowsAffected == 0 {
-			return errors.New("no session ID found to update")
-		}
+func (r *sessionID) Invalidate(ctx context.Context, sessionID model.CandhisSessionID) error {
+	_, err := r.dbConn.ExecContext(ctx,
+		`UPDATE candhis_sessions SET invalidated_at = $1 WHERE session_id = $2 AND invalidated_at IS NULL`,
+		time.Now().UTC(), sessionID.ID())
+	if err != nil {
+		return fmt.Errorf("failed to invalidate session ID: %w", dbError(err))
+	}
 
-		return nil
-	})
+	return nil
 }
@@ -10,15 +10,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/model/modeltest"
 	"github.com/tul1/candhis_api/internal/application/repository"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
 )
 
+const getSessionIDQuery = `UPDATE candhis_sessions SET last_used_at = \$1 WHERE id = \(
+			SELECT id FROM candhis_sessions WHERE invalidated_at IS NULL AND valid_until > \$1`
+
 func TestSessionIDStore_Get_DatabaseError(t *testing.T) {
 	repo, mock := setupSessionIDSQLMock(t)
 
-	mock.ExpectQuery(`SELECT id, created_at FROM candhis_session`).
+	mock.ExpectQuery(getSessionIDQuery).
+		WithArgs(sqlmock.AnyArg()).
 		WillReturnError(errors.New("database error"))
 
 	_, err := repo.Get(context.Background())
@@ -28,11 +33,12 @@ func TestSessionIDStore_Get_DatabaseError(t *testing.T) {
 func TestSessionIDStore_Get_NotFound(t *testing.T) {
 	repo, mock := setupSessionIDSQLMock(t)
 
-	mock.ExpectQuery(`SELECT id, created_at FROM candhis_session`).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectQuery(getSessionIDQuery).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"session_id", "created_at"}))
 
 	_, err := repo.Get(context.Background())
-	assert.EqualError(t, err, "no session ID found in database")
+	assert.ErrorIs(t, err, model.ErrNoValidCandhisSession)
 }
 
 func TestSessionIDStore_Get_Success(t *testing.T) {
@@ -41,8 +47,9 @@ func TestSessionIDStore_Get_Success(t *testing.T) {
 	expectedID := "some-session-id"
 	expectedCreatedAt := time.Now().UTC().Truncate(time.Microsecond)
 
-	mock.ExpectQuery(`SELECT id, created_at FROM candhis_session`).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
+	mock.ExpectQuery(getSessionIDQuery).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"session_id", "created_at"}).
 			AddRow(expectedID, expectedCreatedAt))
 
 	sessionID, err := repo.Get(context.Background())
@@ -50,52 +57,63 @@ func TestSessionIDStore_Get_Success(t *testing.T) {
 
 	assert.Equal(t, "some-session-id", sessionID.ID())
 	assert.Equal(t, expectedCreatedAt, sessionID.CreatedAt())
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestSessionIDStore_Update_DatabaseError(t *testing.T) {
+func TestSessionIDStore_Add_DatabaseError(t *testing.T) {
 	repo, mock := setupSessionIDSQLMock(t)
 
-	sessionID := modeltest.MustCreateCandhisSessionID(t, "some-session-id")
+	session := modeltest.MustCreateCandhisSession(t, modeltest.MustCreateCandhisSessionID(t, "some-session-id"),
+		model.CandhisSessionSourceScraper)
 
-	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE candhis_session SET id = \$1, created_at = \$2`).
-		WithArgs(sessionID.ID(), sessionID.CreatedAt()).
-		WillReturnError(errors.New("update error"))
-	mock.ExpectRollback()
+	mock.ExpectExec(`INSERT INTO candhis_sessions \(session_id, source, created_at, valid_until\)`).
+		WithArgs(session.SessionID().ID(), session.Source(), session.SessionID().CreatedAt(), session.ValidUntil()).
+		WillReturnError(errors.New("insert error"))
 
-	err := repo.Update(context.Background(), sessionID)
-	assert.EqualError(t, err, "failed to update session ID: update error")
+	err := repo.Add(context.Background(), session)
+	assert.EqualError(t, err, "failed to add session ID: insert error")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestSessionIDStore_Update_NoRowsAffected(t *testing.T) {
+func TestSessionIDStore_Add_Success(t *testing.T) {
+	repo, mock := setupSessionIDSQLMock(t)
+
+	session := modeltest.MustCreateCandhisSession(t, modeltest.MustCreateCandhisSessionID(t, "some-session-id"),
+		model.CandhisSessionSourceBootstrap)
+
+	mock.ExpectExec(`INSERT INTO candhis_sessions \(session_id, source, created_at, valid_until\)`).
+		WithArgs(session.SessionID().ID(), session.Source(), session.SessionID().CreatedAt(), session.ValidUntil()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.Add(context.Background(), session)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionIDStore_Invalidate_DatabaseError(t *testing.T) {
 	repo, mock := setupSessionIDSQLMock(t)
 
 	sessionID := modeltest.MustCreateCandhisSessionID(t, "some-session-id")
 
-	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE candhis_session SET id = \$1, created_at = \$2`).
-		WithArgs(sessionID.ID(), sessionID.CreatedAt()).
-		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectRollback()
+	mock.ExpectExec(`UPDATE candhis_sessions SET invalidated_at = \$1 WHERE session_id = \$2 AND invalidated_at IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sessionID.ID()).
+		WillReturnError(errors.New("update error"))
 
-	err := repo.Update(context.Background(), sessionID)
-	assert.EqualError(t, err, "no session ID found to update")
+	err := repo.Invalidate(context.Background(), sessionID)
+	assert.EqualError(t, err, "failed to invalidate session ID: update error")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestSessionIDStore_Update_Success(t *testing.T) {
+func TestSessionIDStore_Invalidate_Success(t *testing.T) {
 	repo, mock := setupSessionIDSQLMock(t)
 
 	sessionID := modeltest.MustCreateCandhisSessionID(t, "some-session-id")
 
-	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE candhis_session SET id = \$1, created_at = \$2`).
-		WithArgs(sessionID.ID(), sessionID.CreatedAt()).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	mock.ExpectExec(`UPDATE candhis_sessions SET invalidated_at = \$1 WHERE session_id = \$2 AND invalidated_at IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sessionID.ID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := repo.Update(context.Background(), sessionID)
+	err := repo.Invalidate(context.Background(), sessionID)
 	require.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+const (
+	SpectrumIndexTemplateName = "candhis-spectra"
+	// SpectrumIndexTemplateVersion must be bumped whenever spectrumMappingProperties changes.
+	SpectrumIndexTemplateVersion = 1
+)
+
+// spectrumMappingProperties are the explicit mappings of the spectrum fields, they match the JSON of model.Spectrum.
+// The bins are only read back with their spectrum, they are not searchable.
+var spectrumMappingProperties = map[string]any{
+	"timestamp":      map[string]any{"type": "date"},
+	"frequency":      map[string]any{"type": "float", "index": false},
+	"energy_density": map[string]any{"type": "float", "index": false},
+	"direction":      map[string]any{"type": "float", "index": false},
+	"spread":         map[string]any{"type": "float", "index": false},
+}
+
+// SpectrumIndexBootstrapper installs the index template of the spectra indices of every campaign.
+type SpectrumIndexBootstrapper struct {
+	client   *elasticsearch.Client
+	shards   int
+	replicas int
+}
+
+func NewSpectrumIndexBootstrapper(client *elasticsearch.Client, shards, replicas int) *SpectrumIndexBootstrapper {
+	return &SpectrumIndexBootstrapper{
+		client:   client,
+		shards:   shards,
+		replicas: replicas,
+	}
+}
+
+// Bootstrap installs the index template before the first spectrum of a campaign creates its index. The template
+// covers the spectra indices by their name suffix, it is rewritten at every start.
+func (b *SpectrumIndexBootstrapper) Bootstrap(ctx context.Context) error {
+	template := map[string]any{
+		"index_patterns": []string{appmodel.SpectraIndexName("*")},
+		"version":        SpectrumIndexTemplateVersion,
+		"priority":       100,
+		"template": map[string]any{
+			"settings": map[string]any{
+				"number_of_shards":   b.shards,
+				"number_of_replicas": b.replicas,
+			},
+			"mappings": map[string]any{
+				"dynamic":    false,
+				"properties": spectrumMappingProperties,
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index template to JSON: %v", err)
+	}
+
+	req := esapi.IndicesPutIndexTemplateRequest{
+		Name: SpectrumIndexTemplateName,
+		Body: bytes.NewReader(body),
+	}
+	return doRequest(ctx, b.client, req, "error putting index template")
+}
+
+type Spectrum struct {
+	client *elasticsearch.Client
+}
+
+func NewSpectrum(client *elasticsearch.Client) *Spectrum {
+	return &Spectrum{
+		client: client,
+	}
+}
+
+// AddBatch upserts the spectra with a single bulk request, spectra already stored with the same bins are reported as
+// unchanged. A spectrum has no optional field to clear from the stored one.
+func (s *Spectrum) AddBatch(
+	ctx context.Context,
+	spectra []model.Spectrum,
+	indexName string,
+) (appmodel.BatchResult, error) {
+	return bulkUpsert(ctx, s.client, indexName, spectra, model.Spectrum.Timestamp, nil)
+}
+
+// Nearest scores the spectra within maxDistance of timestamp by their distance to it, the closest one has the best
+// score. Between two spectra as close, the latest one is returned.
+func (s *Spectrum) Nearest(
+	ctx context.Context,
+	indexName string,
+	timestamp time.Time,
+	maxDistance time.Duration,
+) (*model.Spectrum, error) {
+	if indexName == "" {
+		return nil, fmt.Errorf("indexName cannot be empty")
+	}
+
+	from, to := timestamp.Add(-maxDistance), timestamp.Add(maxDistance)
+	body, err := json.Marshal(map[string]any{
+		"size": 1,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{timestampRangeQuery(&from, &to)},
+				"should": []any{map[string]any{
+					"distance_feature": map[string]any{
+						"field":  "timestamp",
+						"origin": timestamp.UTC().Format(time.RFC3339),
+						"pivot":  "30m",
+					},
+				}},
+			},
+		},
+		"sort": []any{"_score", map[string]any{"timestamp": map[string]any{"order": "desc"}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search body to JSON: %v", err)
+	}
+
+	ignoreUnavailable := true
+	req := esapi.SearchRequest{
+		Index:             []string{indexName},
+		Body:              bytes.NewReader(body),
+		IgnoreUnavailable: &ignoreUnavailable,
+	}
+
+	res, err := req.Do(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("error searching documents: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error searching documents: %w", esResponseError(res))
+	}
+
+	var searchResponse struct {
+		Hits struct {
+			Hits []struct {
+				Source model.Spectrum `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %v", err)
+	}
+	if len(searchResponse.Hits.Hits) == 0 {
+		return nil, nil
+	}
+
+	return &searchResponse.Hits.Hits[0].Source, nil
+}
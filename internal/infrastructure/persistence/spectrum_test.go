@@ -0,0 +1,178 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+const spectrumSource = `{"timestamp": "2024-09-17T09:00:00Z", "frequency": [0.04, 0.05], "energy_density": [0.012, 0.35],
+	"direction": [285, 280], "spread": [32, 25]}`
+
+func testSpectrum(t *testing.T, timestamp time.Time) model.Spectrum {
+	t.Helper()
+
+	return modeltest.MustCreateSpectrum(t, timestamp,
+		model.SpectrumBin{Frequency: 0.04, EnergyDensity: 0.012, Direction: 285, Spread: 32},
+		model.SpectrumBin{Frequency: 0.05, EnergyDensity: 0.35, Direction: 280, Spread: 25},
+	)
+}
+
+func TestSpectrumIndexBootstrapper_InstallsTemplate(t *testing.T) {
+	var templateBody []byte
+	mockClient, _ := elasticsearch.NewClient(elasticsearch.Config{
+		Transport: &MockTransport{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "PUT /_index_template/candhis-spectra", req.Method+" "+req.URL.Path)
+			templateBody, _ = io.ReadAll(req.Body)
+			return MockResponse(200, `{"acknowledged": true}`), nil
+		}},
+	})
+
+	err := persistence.NewSpectrumIndexBootstrapper(mockClient, 1, 0).Bootstrap(context.Background())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"index_patterns": ["*-spectra"],
+		"version": 1,
+		"priority": 100,
+		"template": {
+			"settings": {"number_of_shards": 1, "number_of_replicas": 0},
+			"mappings": {
+				"dynamic": false,
+				"properties": {
+					"timestamp": {"type": "date"},
+					"frequency": {"type": "float", "index": false},
+					"energy_density": {"type": "float", "index": false},
+					"direction": {"type": "float", "index": false},
+					"spread": {"type": "float", "index": false}
+				}
+			}
+		}
+	}`, string(templateBody))
+}
+
+func TestSpectrum_AddBatch(t *testing.T) {
+	var bulkBody []byte
+	spectrumStore := setupMockSpectrum(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/les-pierres-noires-spectra/_bulk", req.URL.Path)
+		bulkBody, _ = io.ReadAll(req.Body)
+		return MockResponse(200, `{"errors": true, "items": [
+			{"update": {"status": 201, "result": "created"}},
+			{"update": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse"}}}
+		]}`), nil
+	})
+
+	spectra := []model.Spectrum{
+		testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)),
+		testSpectrum(t, time.Date(2024, 9, 17, 9, 30, 0, 0, time.UTC)),
+	}
+
+	result, err := spectrumStore.AddBatch(context.Background(), spectra, "les-pierres-noires-spectra")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{
+		Indexed: 1,
+		Failures: []appmodel.IndexFailure{{
+			Timestamp: spectra[1].Timestamp(),
+			Reason:    "mapper_parsing_exception: failed to parse",
+		}},
+	}, result)
+
+	lines := strings.Split(strings.TrimSpace(string(bulkBody)), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"update": {"_id": "2024-09-17T09:00:00Z"}}`, lines[0])
+	assert.JSONEq(t, `{"doc": `+spectrumSource+`, "doc_as_upsert": true}`, lines[1])
+}
+
+func TestSpectrum_Nearest(t *testing.T) {
+	spectrumStore := setupMockSpectrum(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/les-pierres-noires-spectra/_search", req.URL.Path)
+		assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+		body, _ := io.ReadAll(req.Body)
+		assert.JSONEq(t, `{
+			"size": 1,
+			"query": {"bool": {
+				"filter": [{"range": {"timestamp": {"gte": "2024-09-17T08:10:00Z", "lte": "2024-09-17T10:10:00Z"}}}],
+				"should": [{"distance_feature": {"field": "timestamp", "origin": "2024-09-17T09:10:00Z", "pivot": "30m"}}]
+			}},
+			"sort": ["_score", {"timestamp": {"order": "desc"}}]
+		}`, string(body))
+		return MockResponse(200, `{"hits": {"hits": [{"_source": `+spectrumSource+`}]}}`), nil
+	})
+
+	spectrum, err := spectrumStore.Nearest(context.Background(), "les-pierres-noires-spectra",
+		time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC), time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, spectrum)
+	assert.Equal(t, testSpectrum(t, time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)), *spectrum)
+}
+
+func TestSpectrum_NearestNone(t *testing.T) {
+	spectrumStore := setupMockSpectrum(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, `{"hits": {"hits": []}}`), nil
+	})
+
+	spectrum, err := spectrumStore.Nearest(context.Background(), "les-pierres-noires-spectra",
+		time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC), time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, spectrum)
+}
+
+func TestSpectrum_NearestErrors(t *testing.T) {
+	testCases := map[string]struct {
+		indexName   string
+		mockHandler func(req *http.Request) (*http.Response, error)
+		errMsg      string
+	}{
+		"empty index name": {
+			mockHandler: func(req *http.Request) (*http.Response, error) {
+				t.Fatal("no request expected")
+				return nil, nil
+			},
+			errMsg: "indexName cannot be empty",
+		},
+		"transport error": {
+			indexName: "les-pierres-noires-spectra",
+			mockHandler: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+			errMsg: "error searching documents: storage unavailable: connection refused",
+		},
+		"bad request": {
+			indexName: "les-pierres-noires-spectra",
+			mockHandler: func(req *http.Request) (*http.Response, error) {
+				return MockResponse(400, `{"error": "parsing_exception"}`), nil
+			},
+			errMsg: `error searching documents: 400 Bad Request, body: {"error": "parsing_exception"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			spectrumStore := setupMockSpectrum(tc.mockHandler)
+
+			_, err := spectrumStore.Nearest(context.Background(), tc.indexName,
+				time.Date(2024, 9, 17, 9, 10, 0, 0, time.UTC), time.Hour)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
+
+func setupMockSpectrum(mockHandler func(req *http.Request) (*http.Response, error)) *persistence.Spectrum {
+	mockClient, _ := elasticsearch.NewClient(elasticsearch.Config{
+		Transport: &MockTransport{RoundTripFunc: mockHandler},
+	})
+
+	return persistence.NewSpectrum(mockClient)
+}
@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+)
+
+type station struct {
+	dbConn *sql.DB
+}
+
+func NewStation(dbConn *sql.DB) *station {
+	return &station{
+		dbConn: dbConn,
+	}
+}
+
+func (r *station) UpsertBatch(ctx context.Context, stations []model.Station) error {
+	now := time.Now().UTC()
+	return db.Transaction(ctx, r.dbConn, func(tx *sql.Tx) error {
+		for _, s := range stations {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO campaigns (candhis_id, buoy_id, name, index_name, latitude, longitude, depth, operator, active,
+					first_seen_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+				ON CONFLICT (candhis_id) DO UPDATE SET buoy_id = $2, name = $3, index_name = $4, latitude = $5,
+					longitude = $6, depth = $7, operator = $8, active = $9, updated_at = $10`,
+				s.CandhisID(), s.BuoyID(), s.Name(), s.IndexName(), s.Latitude(), s.Longitude(), s.Depth(), s.Operator(),
+				s.Active(), now)
+			if err != nil {
+				return fmt.Errorf("failed to upsert station %s: %w", s.BuoyID(), dbError(err))
+			}
+		}
+		return nil
+	})
+}
+
+func (r *station) List(ctx context.Context) ([]model.Station, error) {
+	rows, err := r.dbConn.QueryContext(ctx,
+		`SELECT candhis_id, name, latitude, longitude, depth, operator, active FROM campaigns ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stations from database: %w", dbError(err))
+	}
+	defer rows.Close()
+
+	stations := make([]model.Station, 0)
+	for rows.Next() {
+		var candhisID, name, operator string
+		var latitude, longitude float64
+		var depth sql.NullFloat64
+		var active bool
+
+		err := rows.Scan(&candhisID, &name, &latitude, &longitude, &depth, &operator, &active)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan station: %w", dbError(err))
+		}
+
+		s, err := newStation(candhisID, name, latitude, longitude, depth, operator, active)
+		if err != nil {
+			return nil, err
+		}
+		stations = append(stations, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stations from database: %w", dbError(err))
+	}
+
+	return stations, nil
+}
+
+func (r *station) Get(ctx context.Context, indexName string) (*model.Station, error) {
+	row := r.dbConn.QueryRowContext(ctx,
+		`SELECT candhis_id, name, latitude, longitude, depth, operator, active FROM campaigns WHERE index_name = $1
+		ORDER BY updated_at DESC LIMIT 1`, indexName)
+
+	var candhisID, name, operator string
+	var latitude, longitude float64
+	var depth sql.NullFloat64
+	var active bool
+
+	err := row.Scan(&candhisID, &name, &latitude, &longitude, &depth, &operator, &active)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get station from database: %w", dbError(err))
+	}
+
+	s, err := newStation(candhisID, name, latitude, longitude, depth, operator, active)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func newStation(
+	candhisID, name string,
+	latitude, longitude float64,
+	depth sql.NullFloat64,
+	operator string,
+	active bool,
+) (model.Station, error) {
+	var depthPtr *float64
+	if depth.Valid {
+		depthPtr = &depth.Float64
+	}
+
+	s, err := model.NewStation(candhisID, name, latitude, longitude, depthPtr, operator, active)
+	if err != nil {
+		return model.Station{}, fmt.Errorf("failed to create station: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,126 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+func TestStationStore_UpsertBatch_Success(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	depth := 60.0
+	stations := []model.Station{
+		modeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, &depth, "Cerema", true),
+		modeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "", false),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaigns (.+) ON CONFLICT \(candhis_id\) DO UPDATE`).
+		WithArgs("Y2FtcD0wMjkxMQ==", "02911", "Les Pierres Noires", "les-pierres-noires", 48.291, -4.968, &depth, "Cerema", true, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO campaigns`).
+		WithArgs("Y2FtcD0wNTYwMg==", "05602", "Belle Ile", "belle-ile", 47.285, -3.285, nil, "", false, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpsertBatch(context.Background(), stations))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStationStore_UpsertBatch_DatabaseError(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	stations := []model.Station{
+		modeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, nil, "Cerema", true),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaigns`).WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+
+	err := repo.UpsertBatch(context.Background(), stations)
+	assert.EqualError(t, err, "failed to upsert station 02911: insert error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStationStore_List_Success(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	mock.ExpectQuery(`SELECT candhis_id, name, latitude, longitude, depth, operator, active FROM campaigns ORDER BY name`).
+		WillReturnRows(sqlmock.NewRows([]string{"candhis_id", "name", "latitude", "longitude", "depth", "operator", "active"}).
+			AddRow("Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "", false).
+			AddRow("Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, 60.0, "Cerema", true))
+
+	stations, err := repo.List(context.Background())
+	require.NoError(t, err)
+
+	depth := 60.0
+	assert.Equal(t, []model.Station{
+		modeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "", false),
+		modeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, &depth, "Cerema", true),
+	}, stations)
+}
+
+func TestStationStore_List_DatabaseError(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM campaigns`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.List(context.Background())
+	assert.EqualError(t, err, "failed to list stations from database: database error")
+}
+
+func TestStationStore_Get_Success(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	mock.ExpectQuery(`SELECT candhis_id, name, latitude, longitude, depth, operator, active FROM campaigns WHERE index_name = \$1`).
+		WithArgs("les-pierres-noires").
+		WillReturnRows(sqlmock.NewRows([]string{"candhis_id", "name", "latitude", "longitude", "depth", "operator", "active"}).
+			AddRow("Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, 60.0, "Cerema", true))
+
+	station, err := repo.Get(context.Background(), "les-pierres-noires")
+	require.NoError(t, err)
+
+	depth := 60.0
+	expected := modeltest.MustCreateStation(t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, &depth, "Cerema", true)
+	assert.Equal(t, &expected, station)
+}
+
+func TestStationStore_Get_NotFound(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM campaigns WHERE index_name = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"candhis_id", "name", "latitude", "longitude", "depth", "operator", "active"}))
+
+	station, err := repo.Get(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, station)
+}
+
+func TestStationStore_Get_DatabaseError(t *testing.T) {
+	repo, mock := setupStationSQLMock(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM campaigns`).WillReturnError(errors.New("database error"))
+
+	_, err := repo.Get(context.Background(), "les-pierres-noires")
+	assert.EqualError(t, err, "failed to get station from database: database error")
+}
+
+func setupStationSQLMock(t *testing.T) (repository.Station, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return persistence.NewStation(db), mock
+}
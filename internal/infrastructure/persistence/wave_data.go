@@ -5,13 +5,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/domain/model"
 )
 
+// waveDataFields are the JSON fields of the observations, the optional ones included.
+var waveDataFields = slices.Collect(maps.Keys(waveDataMappingProperties))
+
 type WaveData struct {
 	client *elasticsearch.Client
 }
@@ -22,33 +28,196 @@ func NewWaveData(client *elasticsearch.Client) *WaveData {
 	}
 }
 
-func (w *WaveData) Add(ctx context.Context, waveData model.WaveData, indexName string) error {
+// AddBatch upserts the observations with a single bulk request. Upserts let Elasticsearch detect the observations
+// that are already stored with the same values and report them as noop instead of rewriting them. The optional
+// measurements and quality control flags an observation lacks are cleared from the stored document.
+func (w *WaveData) AddBatch(
+	ctx context.Context,
+	waveDataList []model.WaveData,
+	indexName string,
+) (appmodel.BatchResult, error) {
+	return bulkUpsert(ctx, w.client, indexName, waveDataList, model.WaveData.Timestamp, waveDataFields)
+}
+
+// bulkUpsert upserts documents identified by their timestamp with a single bulk request. An upsert merges the
+// document into the stored one, so each of fields missing from a document is sent as null to replace the whole stored
+// document. Stored documents with the same values, nulls included, are still reported as noop.
+func bulkUpsert[T any](
+	ctx context.Context,
+	client *elasticsearch.Client,
+	indexName string,
+	docs []T,
+	timestamp func(T) time.Time,
+	fields []string,
+) (appmodel.BatchResult, error) {
 	if indexName == "" {
-		return fmt.Errorf("indexName cannot be empty")
+		return appmodel.BatchResult{}, fmt.Errorf("indexName cannot be empty")
+	}
+	if len(docs) == 0 {
+		return appmodel.BatchResult{}, nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]any{"update": map[string]any{"_id": timestampDocumentID(timestamp(doc))}}
+		if err := encoder.Encode(action); err != nil {
+			return appmodel.BatchResult{}, fmt.Errorf("failed to marshal bulk action to JSON: %v", err)
+		}
+		source, err := fullDocument(doc, fields)
+		if err != nil {
+			return appmodel.BatchResult{}, err
+		}
+		if err := encoder.Encode(map[string]any{"doc": source, "doc_as_upsert": true}); err != nil {
+			return appmodel.BatchResult{}, fmt.Errorf("failed to marshal document to JSON: %v", err)
+		}
+	}
+
+	req := esapi.BulkRequest{
+		Index:   indexName,
+		Body:    &body,
+		Refresh: "true",
+	}
+
+	res, err := req.Do(ctx, client)
+	if err != nil {
+		return appmodel.BatchResult{}, fmt.Errorf("error bulk indexing documents: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return appmodel.BatchResult{}, fmt.Errorf("error bulk indexing documents: %w", esResponseError(res))
+	}
+
+	var bulkResponse struct {
+		Items []struct {
+			Update struct {
+				Result string `json:"result"`
+				Error  *struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				} `json:"error"`
+			} `json:"update"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&bulkResponse); err != nil {
+		return appmodel.BatchResult{}, fmt.Errorf("failed to decode bulk response: %v", err)
+	}
+
+	// Bulk items are returned in the order of the request actions.
+	var result appmodel.BatchResult
+	for i, item := range bulkResponse.Items {
+		switch {
+		case item.Update.Error != nil && i < len(docs):
+			result.Failures = append(result.Failures, appmodel.IndexFailure{
+				Timestamp: timestamp(docs[i]),
+				Reason:    fmt.Sprintf("%s: %s", item.Update.Error.Type, item.Update.Error.Reason),
+			})
+		case item.Update.Result == "noop":
+			result.Unchanged++
+		default:
+			result.Indexed++
+		}
 	}
 
-	dataJSON, err := json.Marshal(waveData)
+	return result, nil
+}
+
+// fullDocument returns the JSON fields of doc, with a null value for each of fields that doc omits.
+func fullDocument(doc any, fields []string) (map[string]json.RawMessage, error) {
+	dataJSON, err := json.Marshal(doc)
 	if err != nil {
-		return fmt.Errorf("failed to marshal wave data to JSON: %v", err)
+		return nil, fmt.Errorf("failed to marshal document to JSON: %v", err)
 	}
 
-	req := esapi.IndexRequest{
-		Index:      indexName,
-		DocumentID: waveData.Timestamp().Format("2006-01-02T15:04:05Z07:00"),
-		Body:       bytes.NewReader(dataJSON),
-		Refresh:    "true",
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(dataJSON, &source); err != nil {
+		return nil, fmt.Errorf("failed to marshal document to JSON: %v", err)
+	}
+	for _, field := range fields {
+		if _, ok := source[field]; !ok {
+			source[field] = json.RawMessage("null")
+		}
+	}
+
+	return source, nil
+}
+
+func (w *WaveData) List(ctx context.Context, indexName string, query appmodel.WaveDataQuery) (appmodel.WaveDataPage, error) {
+	if indexName == "" {
+		return appmodel.WaveDataPage{}, fmt.Errorf("indexName cannot be empty")
+	}
+
+	body, err := json.Marshal(buildWaveDataSearchBody(query))
+	if err != nil {
+		return appmodel.WaveDataPage{}, fmt.Errorf("failed to marshal search body to JSON: %v", err)
+	}
+
+	ignoreUnavailable := true
+	req := esapi.SearchRequest{
+		Index:             []string{indexName},
+		Body:              bytes.NewReader(body),
+		IgnoreUnavailable: &ignoreUnavailable,
 	}
 
 	res, err := req.Do(ctx, w.client)
 	if err != nil {
-		return fmt.Errorf("error indexing document: %v", err)
+		return appmodel.WaveDataPage{}, fmt.Errorf("error searching documents: %w", esTransportError(err))
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		body, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("error indexing document: %s, body: %s", res.Status(), string(body))
+		return appmodel.WaveDataPage{}, fmt.Errorf("error searching documents: %w", esResponseError(res))
 	}
 
-	return nil
+	var searchResponse struct {
+		Hits struct {
+			Hits []struct {
+				Source model.WaveData `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+		return appmodel.WaveDataPage{}, fmt.Errorf("failed to decode search response: %v", err)
+	}
+
+	waveDataList := make([]model.WaveData, 0, len(searchResponse.Hits.Hits))
+	for _, hit := range searchResponse.Hits.Hits {
+		waveDataList = append(waveDataList, hit.Source)
+	}
+
+	return appmodel.NewWaveDataPage(waveDataList, query), nil
+}
+
+func buildWaveDataSearchBody(query appmodel.WaveDataQuery) map[string]any {
+	body := map[string]any{
+		"size":  query.Limit(),
+		"query": timestampRangeQuery(query.From(), query.To()),
+		"sort":  []any{map[string]any{"timestamp": map[string]any{"order": string(query.Sort())}}},
+	}
+	if query.After() != nil {
+		body["search_after"] = []any{query.After().UnixMilli()}
+	}
+
+	return body
+}
+
+func timestampRangeQuery(from, to *time.Time) map[string]any {
+	timestampRange := map[string]any{}
+	if from != nil {
+		timestampRange["gte"] = from.UTC().Format(time.RFC3339)
+	}
+	if to != nil {
+		timestampRange["lte"] = to.UTC().Format(time.RFC3339)
+	}
+
+	if len(timestampRange) == 0 {
+		return map[string]any{"match_all": map[string]any{}}
+	}
+	return map[string]any{"range": map[string]any{"timestamp": timestampRange}}
+}
+
+// timestampDocumentID identifies an observation by its timestamp so that scraping the same row twice overwrites it.
+func timestampDocumentID(timestamp time.Time) string {
+	return timestamp.Format(time.RFC3339)
 }
@@ -0,0 +1,169 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+const (
+	exportPageSize = 1000
+	// exportKeepAlive only has to cover the time between two pages, it is extended by every search.
+	exportKeepAlive = "1m"
+)
+
+// Export reads the observations page by page from a point in time of the index, so that memory stays bounded and
+// the export is a consistent snapshot even while the scrapers keep indexing. The first page also counts the
+// observations of the snapshot for the writer.
+func (w *WaveData) Export(
+	ctx context.Context,
+	indexName string,
+	query appmodel.WaveDataExportQuery,
+	writer appmodel.WaveDataExportWriter,
+) error {
+	if indexName == "" {
+		return fmt.Errorf("indexName cannot be empty")
+	}
+
+	pitID, err := w.openPointInTime(ctx, indexName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// The point in time expires by itself after exportKeepAlive when it cannot be closed.
+		_ = w.closePointInTime(context.WithoutCancel(ctx), pitID)
+	}()
+
+	var searchAfter []any
+	for first := true; ; first = false {
+		page, err := w.searchExportPage(ctx, pitID, query, searchAfter, first)
+		if err != nil {
+			return err
+		}
+		// Elasticsearch may return a new point in time ID with each page.
+		if page.PitID != "" {
+			pitID = page.PitID
+		}
+
+		if first {
+			if err := writer.Begin(page.Hits.Total.Value); err != nil {
+				return fmt.Errorf("failed to begin export: %w", err)
+			}
+		}
+
+		for _, hit := range page.Hits.Hits {
+			if err := writer.Write(hit.Source); err != nil {
+				return fmt.Errorf("failed to write exported wave data: %w", err)
+			}
+		}
+
+		if len(page.Hits.Hits) < exportPageSize {
+			break
+		}
+		searchAfter = page.Hits.Hits[len(page.Hits.Hits)-1].Sort
+	}
+
+	if err := writer.End(); err != nil {
+		return fmt.Errorf("failed to end export: %w", err)
+	}
+
+	return nil
+}
+
+func (w *WaveData) openPointInTime(ctx context.Context, indexName string) (string, error) {
+	ignoreUnavailable := true
+	req := esapi.OpenPointInTimeRequest{
+		Index:             []string{indexName},
+		KeepAlive:         exportKeepAlive,
+		IgnoreUnavailable: &ignoreUnavailable,
+	}
+
+	res, err := req.Do(ctx, w.client)
+	if err != nil {
+		return "", fmt.Errorf("error opening point in time: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("error opening point in time: %w", esResponseError(res))
+	}
+
+	var openResponse struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&openResponse); err != nil {
+		return "", fmt.Errorf("failed to decode point in time response: %v", err)
+	}
+
+	return openResponse.ID, nil
+}
+
+func (w *WaveData) closePointInTime(ctx context.Context, pitID string) error {
+	body, err := json.Marshal(map[string]any{"id": pitID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal point in time to JSON: %v", err)
+	}
+
+	req := esapi.ClosePointInTimeRequest{Body: bytes.NewReader(body)}
+	return doRequest(ctx, w.client, req, "error closing point in time")
+}
+
+type exportPage struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source model.WaveData `json:"_source"`
+			Sort   []any          `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (w *WaveData) searchExportPage(
+	ctx context.Context,
+	pitID string,
+	query appmodel.WaveDataExportQuery,
+	searchAfter []any,
+	trackTotalHits bool,
+) (exportPage, error) {
+	searchBody := map[string]any{
+		"size":             exportPageSize,
+		"query":            timestampRangeQuery(query.From(), query.To()),
+		"sort":             []any{map[string]any{"timestamp": map[string]any{"order": "asc"}}},
+		"pit":              map[string]any{"id": pitID, "keep_alive": exportKeepAlive},
+		"track_total_hits": trackTotalHits,
+	}
+	if searchAfter != nil {
+		searchBody["search_after"] = searchAfter
+	}
+
+	body, err := json.Marshal(searchBody)
+	if err != nil {
+		return exportPage{}, fmt.Errorf("failed to marshal search body to JSON: %v", err)
+	}
+
+	req := esapi.SearchRequest{Body: bytes.NewReader(body)}
+	res, err := req.Do(ctx, w.client)
+	if err != nil {
+		return exportPage{}, fmt.Errorf("error searching documents: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return exportPage{}, fmt.Errorf("error searching documents: %w", esResponseError(res))
+	}
+
+	var page exportPage
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return exportPage{}, fmt.Errorf("failed to decode search response: %v", err)
+	}
+
+	return page, nil
+}
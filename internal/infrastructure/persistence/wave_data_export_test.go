@@ -0,0 +1,152 @@
+package persistence_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+type recordingExportWriter struct {
+	count    int
+	waveData []model.WaveData
+	ended    bool
+	writeErr error
+}
+
+func (w *recordingExportWriter) Begin(count int) error {
+	w.count = count
+	return nil
+}
+
+func (w *recordingExportWriter) Write(waveData model.WaveData) error {
+	w.waveData = append(w.waveData, waveData)
+	return w.writeErr
+}
+
+func (w *recordingExportWriter) End() error {
+	w.ended = true
+	return nil
+}
+
+func TestExport_Success(t *testing.T) {
+	// Two pages are needed: a full page of 1000 observations then a page of a single one.
+	hits := func(start, count int) string {
+		var docs []string
+		for i := start; i < start+count; i++ {
+			timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * 30 * time.Minute)
+			docs = append(docs, fmt.Sprintf(`{"_source": {"timestamp": %q, "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+				"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15}, "sort": [%d, %d]}`,
+				timestamp.Format(time.RFC3339), timestamp.UnixMilli(), i))
+		}
+		return strings.Join(docs, ",")
+	}
+
+	var searchBodies []map[string]any
+	var closedPit string
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /test-index/_pit":
+			assert.Equal(t, "1m", req.URL.Query().Get("keep_alive"))
+			assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+			return MockResponse(200, `{"id": "pit-1"}`), nil
+		case "POST /_search":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			searchBodies = append(searchBodies, body)
+			if len(searchBodies) == 1 {
+				return MockResponse(200, `{"pit_id": "pit-2", "hits": {"total": {"value": 1001}, "hits": [`+hits(0, 1000)+`]}}`), nil
+			}
+			return MockResponse(200, `{"pit_id": "pit-2", "hits": {"total": {"value": 0}, "hits": [`+hits(1000, 1)+`]}}`), nil
+		case "DELETE /_pit":
+			body, _ := io.ReadAll(req.Body)
+			closedPit = string(body)
+			return MockResponse(200, `{"succeeded": true}`), nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		return MockResponse(500, `{}`), nil
+	})
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query, err := appmodel.NewWaveDataExportQuery(&from, nil)
+	require.NoError(t, err)
+
+	writer := &recordingExportWriter{}
+	err = waveDataStore.Export(context.Background(), "test-index", query, writer)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1001, writer.count)
+	assert.Len(t, writer.waveData, 1001)
+	assert.True(t, writer.ended)
+	assert.JSONEq(t, `{"id": "pit-2"}`, closedPit)
+
+	require.Len(t, searchBodies, 2)
+	assert.Equal(t, map[string]any{"id": "pit-1", "keep_alive": "1m"}, searchBodies[0]["pit"])
+	assert.Equal(t, true, searchBodies[0]["track_total_hits"])
+	assert.Nil(t, searchBodies[0]["search_after"])
+	assert.Equal(t, map[string]any{"range": map[string]any{"timestamp": map[string]any{"gte": "2024-01-01T00:00:00Z"}}},
+		searchBodies[0]["query"])
+	assert.Equal(t, map[string]any{"id": "pit-2", "keep_alive": "1m"}, searchBodies[1]["pit"])
+	assert.Equal(t, false, searchBodies[1]["track_total_hits"])
+	lastOfFirstPage := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(999 * 30 * time.Minute)
+	assert.Equal(t, []any{float64(lastOfFirstPage.UnixMilli()), float64(999)}, searchBodies[1]["search_after"])
+}
+
+func TestExport_WriterError(t *testing.T) {
+	closed := false
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /test-index/_pit":
+			return MockResponse(200, `{"id": "pit-1"}`), nil
+		case "DELETE /_pit":
+			closed = true
+			return MockResponse(200, `{"succeeded": true}`), nil
+		}
+		return MockResponse(200, `{"hits": {"total": {"value": 1}, "hits": [{"_source": {"timestamp": "2024-01-01T00:00:00Z",
+			"h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7, "peak_direction": 8, "peak_directional_spread": 32, "temperature": 15}}]}}`), nil
+	})
+
+	query, err := appmodel.NewWaveDataExportQuery(nil, nil)
+	require.NoError(t, err)
+
+	writeErr := errors.New("broken pipe")
+	writer := &recordingExportWriter{writeErr: writeErr}
+	err = waveDataStore.Export(context.Background(), "test-index", query, writer)
+	assert.ErrorIs(t, err, writeErr)
+	assert.False(t, writer.ended)
+	assert.True(t, closed, "the point in time must be closed")
+}
+
+func TestExport_OpenPointInTimeError(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(500, `{"error": "internal server error"}`), nil
+	})
+
+	query, err := appmodel.NewWaveDataExportQuery(nil, nil)
+	require.NoError(t, err)
+
+	err = waveDataStore.Export(context.Background(), "test-index", query, &recordingExportWriter{})
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+}
+
+func TestExport_EmptyIndexName(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, `{}`), nil
+	})
+
+	query, err := appmodel.NewWaveDataExportQuery(nil, nil)
+	require.NoError(t, err)
+
+	err = waveDataStore.Export(context.Background(), "", query, &recordingExportWriter{})
+	assert.EqualError(t, err, "indexName cannot be empty")
+}
@@ -0,0 +1,310 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+const (
+	WaveDataIndexTemplateName = "candhis-wave-data"
+	WaveDataILMPolicyName     = "candhis-wave-data"
+	// WaveDataIndexTemplateVersion must be bumped whenever waveDataMappingProperties changes.
+	WaveDataIndexTemplateVersion = 3
+)
+
+// ErrIndexMappingDrift is returned when the live mapping of a wave data index differs from the index template. The
+// storage cannot take the observations until the index is reindexed, so it is classified as unavailable.
+var ErrIndexMappingDrift = fmt.Errorf("%w: index mapping drifted from the index template", appmodel.ErrStorageUnavailable)
+
+// waveDataMappingProperties are the explicit mappings of the observation fields, they match the JSON of
+// model.WaveData.
+var waveDataMappingProperties = map[string]string{
+	"timestamp":               "date",
+	"h1_3":                    "float",
+	"hmax":                    "float",
+	"th1_3":                   "float",
+	"peak_direction":          "short",
+	"peak_directional_spread": "short",
+	"temperature":             "float",
+	"tp":                      "float",
+	"tz":                      "float",
+	"mean_direction":          "float",
+	"hm0":                     "float",
+	"wind_speed":              "float",
+	"wind_direction":          "float",
+	"qc_flag":                 "byte",
+	"qc_gross_range":          "byte",
+	"qc_consistency":          "byte",
+	"qc_spike":                "byte",
+	"qc_flat_line":            "byte",
+}
+
+// waveDataAddedMappingFields are the fields added to the mapping after the first template version. Unlike a change
+// of type, new fields can be added to the indices the template already covers.
+var waveDataAddedMappingFields = []string{
+	"qc_flag", "qc_gross_range", "qc_consistency", "qc_spike", "qc_flat_line",
+	"tp", "tz", "mean_direction", "hm0", "wind_speed", "wind_direction",
+}
+
+// WaveDataIndexBootstrapper installs the ILM policy and the versioned index template of the wave data indices, and
+// checks that the mappings of the existing indices match the template.
+type WaveDataIndexBootstrapper struct {
+	client   *elasticsearch.Client
+	shards   int
+	replicas int
+}
+
+func NewWaveDataIndexBootstrapper(client *elasticsearch.Client, shards, replicas int) *WaveDataIndexBootstrapper {
+	return &WaveDataIndexBootstrapper{
+		client:   client,
+		shards:   shards,
+		replicas: replicas,
+	}
+}
+
+// Bootstrap makes the template cover indexNames on top of the indices it already covers, so that binaries knowing
+// different campaigns do not remove each other's indices from it. The template is only rewritten when it is older
+// than this binary or misses one of indexNames, and a newer template is an error. Indices created before the template
+// keep their dynamic mapping, Bootstrap then fails with ErrIndexMappingDrift until they are reindexed.
+func (b *WaveDataIndexBootstrapper) Bootstrap(ctx context.Context, indexNames []string) error {
+	if err := b.putILMPolicy(ctx); err != nil {
+		return err
+	}
+
+	installed, err := b.getIndexTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	var indexPatterns []string
+	if installed != nil {
+		if installed.Version > WaveDataIndexTemplateVersion {
+			return fmt.Errorf("index template %s version %d is newer than the version %d of this binary",
+				WaveDataIndexTemplateName, installed.Version, WaveDataIndexTemplateVersion)
+		}
+		indexPatterns = installed.IndexPatterns
+	}
+
+	upToDate := installed != nil && installed.Version == WaveDataIndexTemplateVersion
+	for _, indexName := range indexNames {
+		if !slices.Contains(indexPatterns, indexName) {
+			indexPatterns = append(indexPatterns, indexName)
+			upToDate = false
+		}
+	}
+
+	// Without any index to cover, e.g. for the API started before the scrapers, there is no template to install.
+	if len(indexPatterns) == 0 {
+		return nil
+	}
+
+	if !upToDate {
+		if err := b.putIndexTemplate(ctx, indexPatterns); err != nil {
+			return err
+		}
+		if err := b.putAddedMappings(ctx, indexPatterns); err != nil {
+			return err
+		}
+	}
+
+	return b.checkMappings(ctx, indexPatterns)
+}
+
+func (b *WaveDataIndexBootstrapper) putILMPolicy(ctx context.Context) error {
+	// Campaign observations are archives, they are never deleted, older indices only lose recovery priority.
+	policy := map[string]any{
+		"policy": map[string]any{
+			"_meta": map[string]any{"version": WaveDataIndexTemplateVersion},
+			"phases": map[string]any{
+				"hot": map[string]any{
+					"actions": map[string]any{"set_priority": map[string]any{"priority": 100}},
+				},
+				"warm": map[string]any{
+					"min_age": "30d",
+					"actions": map[string]any{"set_priority": map[string]any{"priority": 50}},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ILM policy to JSON: %v", err)
+	}
+
+	req := esapi.ILMPutLifecycleRequest{
+		Policy: WaveDataILMPolicyName,
+		Body:   bytes.NewReader(body),
+	}
+	return doRequest(ctx, b.client, req, "error putting ILM policy")
+}
+
+type installedIndexTemplate struct {
+	IndexPatterns []string `json:"index_patterns"`
+	Version       int      `json:"version"`
+}
+
+// getIndexTemplate returns nil when the template is not installed.
+func (b *WaveDataIndexBootstrapper) getIndexTemplate(ctx context.Context) (*installedIndexTemplate, error) {
+	req := esapi.IndicesGetIndexTemplateRequest{Name: WaveDataIndexTemplateName}
+	res, err := req.Do(ctx, b.client)
+	if err != nil {
+		return nil, fmt.Errorf("error getting index template: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting index template: %w", esResponseError(res))
+	}
+
+	var getResponse struct {
+		IndexTemplates []struct {
+			IndexTemplate installedIndexTemplate `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&getResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode index template response: %v", err)
+	}
+	if len(getResponse.IndexTemplates) == 0 {
+		return nil, nil
+	}
+
+	return &getResponse.IndexTemplates[0].IndexTemplate, nil
+}
+
+func (b *WaveDataIndexBootstrapper) putIndexTemplate(ctx context.Context, indexPatterns []string) error {
+	properties := map[string]any{}
+	for field, fieldType := range waveDataMappingProperties {
+		properties[field] = map[string]any{"type": fieldType}
+	}
+
+	template := map[string]any{
+		"index_patterns": indexPatterns,
+		"version":        WaveDataIndexTemplateVersion,
+		"priority":       100,
+		"template": map[string]any{
+			"settings": map[string]any{
+				"number_of_shards":     b.shards,
+				"number_of_replicas":   b.replicas,
+				"index.lifecycle.name": WaveDataILMPolicyName,
+			},
+			"mappings": map[string]any{
+				// Text fields added later are mapped as keywords rather than analysed text.
+				"dynamic_templates": []any{
+					map[string]any{"strings_as_keywords": map[string]any{
+						"match_mapping_type": "string",
+						"mapping":            map[string]any{"type": "keyword"},
+					}},
+				},
+				"properties": properties,
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index template to JSON: %v", err)
+	}
+
+	req := esapi.IndicesPutIndexTemplateRequest{
+		Name: WaveDataIndexTemplateName,
+		Body: bytes.NewReader(body),
+	}
+	return doRequest(ctx, b.client, req, "error putting index template")
+}
+
+// putAddedMappings adds the fields of the newer template versions to the existing indices.
+func (b *WaveDataIndexBootstrapper) putAddedMappings(ctx context.Context, indexPatterns []string) error {
+	properties := map[string]any{}
+	for _, field := range waveDataAddedMappingFields {
+		properties[field] = map[string]any{"type": waveDataMappingProperties[field]}
+	}
+	body, err := json.Marshal(map[string]any{"properties": properties})
+	if err != nil {
+		return fmt.Errorf("failed to marshal index mappings to JSON: %v", err)
+	}
+
+	ignoreUnavailable := true
+	allowNoIndices := true
+	req := esapi.IndicesPutMappingRequest{
+		Index:             indexPatterns,
+		Body:              bytes.NewReader(body),
+		IgnoreUnavailable: &ignoreUnavailable,
+		AllowNoIndices:    &allowNoIndices,
+	}
+	return doRequest(ctx, b.client, req, "error putting index mappings")
+}
+
+func (b *WaveDataIndexBootstrapper) checkMappings(ctx context.Context, indexPatterns []string) error {
+	ignoreUnavailable := true
+	allowNoIndices := true
+	req := esapi.IndicesGetMappingRequest{
+		Index:             indexPatterns,
+		IgnoreUnavailable: &ignoreUnavailable,
+		AllowNoIndices:    &allowNoIndices,
+	}
+	res, err := req.Do(ctx, b.client)
+	if err != nil {
+		return fmt.Errorf("error getting index mappings: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("error getting index mappings: %w", esResponseError(res))
+	}
+
+	var mappings map[string]struct {
+		Mappings struct {
+			Properties map[string]struct {
+				Type string `json:"type"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&mappings); err != nil {
+		return fmt.Errorf("failed to decode index mappings response: %v", err)
+	}
+
+	var drifts []string
+	for indexName, mapping := range mappings {
+		for field, expectedType := range waveDataMappingProperties {
+			liveType := mapping.Mappings.Properties[field].Type
+			if liveType == "" {
+				liveType = "missing"
+			}
+			if liveType != expectedType {
+				drifts = append(drifts, fmt.Sprintf("%s.%s is %s instead of %s", indexName, field, liveType, expectedType))
+			}
+		}
+	}
+	if len(drifts) > 0 {
+		sort.Strings(drifts)
+		return fmt.Errorf("%w, reindex the indices: %s", ErrIndexMappingDrift, strings.Join(drifts, ", "))
+	}
+
+	return nil
+}
+
+func doRequest(ctx context.Context, client *elasticsearch.Client, req esapi.Request, errPrefix string) error {
+	res, err := req.Do(ctx, client)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%s: %w", errPrefix, esResponseError(res))
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package persistence_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+const templateMappings = `{
+	"les-pierres-noires": {"mappings": {"properties": {
+		"timestamp": {"type": "date"}, "h1_3": {"type": "float"}, "hmax": {"type": "float"}, "th1_3": {"type": "float"},
+		"peak_direction": {"type": "short"}, "peak_directional_spread": {"type": "short"}, "temperature": {"type": "float"},
+		"qc_flag": {"type": "byte"}, "qc_gross_range": {"type": "byte"}, "qc_consistency": {"type": "byte"},
+		"qc_spike": {"type": "byte"}, "qc_flat_line": {"type": "byte"}, "tp": {"type": "float"}, "tz": {"type": "float"},
+		"mean_direction": {"type": "float"}, "hm0": {"type": "float"}, "wind_speed": {"type": "float"},
+		"wind_direction": {"type": "float"}
+	}}}
+}`
+
+func TestWaveDataIndexBootstrapper_InstallsTemplate(t *testing.T) {
+	var templateBody []byte
+	var requests []string
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.Method + " " + req.URL.Path {
+		case "PUT /_ilm/policy/candhis-wave-data":
+			return MockResponse(200, `{"acknowledged": true}`), nil
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(404, `{"error": "index template matching [candhis-wave-data] not found"}`), nil
+		case "PUT /_index_template/candhis-wave-data":
+			templateBody, _ = io.ReadAll(req.Body)
+			return MockResponse(200, `{"acknowledged": true}`), nil
+		case "PUT /les-pierres-noires/_mapping":
+			assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+			return MockResponse(200, `{"acknowledged": true}`), nil
+		case "GET /les-pierres-noires/_mapping":
+			assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+			return MockResponse(200, templateMappings), nil
+		}
+		return MockResponse(500, `{}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), []string{"les-pierres-noires"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"PUT /_ilm/policy/candhis-wave-data",
+		"GET /_index_template/candhis-wave-data",
+		"PUT /_index_template/candhis-wave-data",
+		"PUT /les-pierres-noires/_mapping",
+		"GET /les-pierres-noires/_mapping",
+	}, requests)
+	assert.JSONEq(t, `{
+		"index_patterns": ["les-pierres-noires"],
+		"version": 3,
+		"priority": 100,
+		"template": {
+			"settings": {"number_of_shards": 1, "number_of_replicas": 0, "index.lifecycle.name": "candhis-wave-data"},
+			"mappings": {
+				"dynamic_templates": [
+					{"strings_as_keywords": {"match_mapping_type": "string", "mapping": {"type": "keyword"}}}
+				],
+				"properties": {
+					"timestamp": {"type": "date"},
+					"h1_3": {"type": "float"},
+					"hmax": {"type": "float"},
+					"th1_3": {"type": "float"},
+					"peak_direction": {"type": "short"},
+					"peak_directional_spread": {"type": "short"},
+					"temperature": {"type": "float"},
+					"tp": {"type": "float"},
+					"tz": {"type": "float"},
+					"mean_direction": {"type": "float"},
+					"hm0": {"type": "float"},
+					"wind_speed": {"type": "float"},
+					"wind_direction": {"type": "float"},
+					"qc_flag": {"type": "byte"},
+					"qc_gross_range": {"type": "byte"},
+					"qc_consistency": {"type": "byte"},
+					"qc_spike": {"type": "byte"},
+					"qc_flat_line": {"type": "byte"}
+				}
+			}
+		}
+	}`, string(templateBody))
+}
+
+func TestWaveDataIndexBootstrapper_KeepsInstalledIndexPatterns(t *testing.T) {
+	var templateBody []byte
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(200, `{"index_templates": [{"name": "candhis-wave-data",
+				"index_template": {"index_patterns": ["other-campaign"], "version": 3}}]}`), nil
+		case "PUT /_index_template/candhis-wave-data":
+			templateBody, _ = io.ReadAll(req.Body)
+		case "GET /other-campaign,les-pierres-noires/_mapping":
+			return MockResponse(200, `{}`), nil
+		}
+		return MockResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), []string{"les-pierres-noires"})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(templateBody), `"index_patterns":["other-campaign","les-pierres-noires"]`)
+}
+
+func TestWaveDataIndexBootstrapper_UpToDateTemplate(t *testing.T) {
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(200, `{"index_templates": [{"name": "candhis-wave-data",
+				"index_template": {"index_patterns": ["les-pierres-noires"], "version": 3}}]}`), nil
+		case "PUT /_index_template/candhis-wave-data", "PUT /les-pierres-noires/_mapping":
+			t.Error("an up to date template must not be rewritten")
+		case "GET /les-pierres-noires/_mapping":
+			return MockResponse(200, templateMappings), nil
+		}
+		return MockResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	// The API knows no campaign, it checks the indices covered by the installed template.
+	err := bootstrapper.Bootstrap(context.Background(), nil)
+	require.NoError(t, err)
+}
+
+func TestWaveDataIndexBootstrapper_AddsNewFieldsToExistingIndices(t *testing.T) {
+	var mappingBody []byte
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(200, `{"index_templates": [{"name": "candhis-wave-data",
+				"index_template": {"index_patterns": ["les-pierres-noires"], "version": 1}}]}`), nil
+		case "PUT /les-pierres-noires/_mapping":
+			mappingBody, _ = io.ReadAll(req.Body)
+		case "GET /les-pierres-noires/_mapping":
+			return MockResponse(200, templateMappings), nil
+		}
+		return MockResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"properties": {
+		"qc_flag": {"type": "byte"},
+		"qc_gross_range": {"type": "byte"},
+		"qc_consistency": {"type": "byte"},
+		"qc_spike": {"type": "byte"},
+		"qc_flat_line": {"type": "byte"},
+		"tp": {"type": "float"},
+		"tz": {"type": "float"},
+		"mean_direction": {"type": "float"},
+		"hm0": {"type": "float"},
+		"wind_speed": {"type": "float"},
+		"wind_direction": {"type": "float"}
+	}}`, string(mappingBody))
+}
+
+func TestWaveDataIndexBootstrapper_NoIndex(t *testing.T) {
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "PUT /_ilm/policy/candhis-wave-data":
+			return MockResponse(200, `{"acknowledged": true}`), nil
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(404, `{}`), nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+		return MockResponse(500, `{}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), nil)
+	require.NoError(t, err)
+}
+
+func TestWaveDataIndexBootstrapper_MappingDrift(t *testing.T) {
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /_index_template/candhis-wave-data":
+			return MockResponse(404, `{}`), nil
+		case "GET /les-pierres-noires/_mapping":
+			return MockResponse(200, `{"les-pierres-noires": {"mappings": {"properties": {
+				"timestamp": {"type": "date"}, "h1_3": {"type": "float"}, "hmax": {"type": "float"},
+				"th1_3": {"type": "float"}, "peak_direction": {"type": "long"}, "temperature": {"type": "float"},
+				"qc_flag": {"type": "byte"}, "qc_gross_range": {"type": "byte"}, "qc_consistency": {"type": "byte"},
+				"qc_spike": {"type": "byte"}, "qc_flat_line": {"type": "byte"}, "tp": {"type": "float"},
+				"tz": {"type": "float"}, "mean_direction": {"type": "float"}, "hm0": {"type": "float"},
+				"wind_speed": {"type": "float"}, "wind_direction": {"type": "float"}
+			}}}}`), nil
+		}
+		return MockResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), []string{"les-pierres-noires"})
+	require.ErrorIs(t, err, persistence.ErrIndexMappingDrift)
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+	assert.ErrorContains(t, err, "les-pierres-noires.peak_direction is long instead of short, "+
+		"les-pierres-noires.peak_directional_spread is missing instead of short")
+}
+
+func TestWaveDataIndexBootstrapper_NewerTemplate(t *testing.T) {
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		if req.Method+" "+req.URL.Path == "GET /_index_template/candhis-wave-data" {
+			return MockResponse(200, `{"index_templates": [{"name": "candhis-wave-data",
+				"index_template": {"index_patterns": ["les-pierres-noires"], "version": 4}}]}`), nil
+		}
+		return MockResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), []string{"les-pierres-noires"})
+	assert.EqualError(t, err, "index template candhis-wave-data version 4 is newer than the version 3 of this binary")
+}
+
+func TestWaveDataIndexBootstrapper_ILMPolicyError(t *testing.T) {
+	bootstrapper := setupMockBootstrapper(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(500, `{"error": "internal server error"}`), nil
+	})
+
+	err := bootstrapper.Bootstrap(context.Background(), []string{"les-pierres-noires"})
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+}
+
+func setupMockBootstrapper(mockHandler func(req *http.Request) (*http.Response, error)) *persistence.WaveDataIndexBootstrapper {
+	mockClient, _ := elasticsearch.NewClient(elasticsearch.Config{
+		Transport: &MockTransport{RoundTripFunc: mockHandler},
+	})
+
+	return persistence.NewWaveDataIndexBootstrapper(mockClient, 1, 0)
+}
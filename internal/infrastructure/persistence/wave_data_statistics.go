@@ -0,0 +1,234 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+const (
+	statisticsAggregation       = "statistics"
+	peakDirectionSinAggregation = "peak_direction_sin"
+	peakDirectionCosAggregation = "peak_direction_cos"
+)
+
+// Statistics runs a date histogram over the observations of the index. The circular mean of the peak direction is
+// computed from the sums of the sines and cosines of the directions, since averaging angles is meaningless around
+// north. Percentiles are approximated by Elasticsearch.
+func (w *WaveData) Statistics(
+	ctx context.Context,
+	indexName string,
+	query appmodel.WaveDataStatisticsQuery,
+) ([]appmodel.WaveDataStatisticsBucket, error) {
+	if indexName == "" {
+		return nil, fmt.Errorf("indexName cannot be empty")
+	}
+
+	body, err := json.Marshal(buildWaveDataStatisticsBody(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search body to JSON: %v", err)
+	}
+
+	ignoreUnavailable := true
+	req := esapi.SearchRequest{
+		Index:             []string{indexName},
+		Body:              bytes.NewReader(body),
+		IgnoreUnavailable: &ignoreUnavailable,
+	}
+
+	res, err := req.Do(ctx, w.client)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating documents: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error aggregating documents: %w", esResponseError(res))
+	}
+
+	var searchResponse struct {
+		Aggregations struct {
+			Statistics struct {
+				Buckets []map[string]json.RawMessage `json:"buckets"`
+			} `json:"statistics"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %v", err)
+	}
+
+	buckets := make([]appmodel.WaveDataStatisticsBucket, 0, len(searchResponse.Aggregations.Statistics.Buckets))
+	for _, rawBucket := range searchResponse.Aggregations.Statistics.Buckets {
+		bucket, err := parseWaveDataStatisticsBucket(rawBucket, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode statistics bucket: %v", err)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+func buildWaveDataStatisticsBody(query appmodel.WaveDataStatisticsQuery) map[string]any {
+	histogram := map[string]any{
+		"field":             "timestamp",
+		"calendar_interval": string(query.Interval()),
+		"time_zone":         "UTC",
+		"min_doc_count":     0,
+	}
+	// Empty buckets are returned within the requested range so that the time series has no hole.
+	if query.From() != nil && query.To() != nil {
+		histogram["extended_bounds"] = map[string]any{
+			"min": query.From().UnixMilli(),
+			"max": query.To().UnixMilli(),
+		}
+	}
+
+	aggs := map[string]any{
+		peakDirectionSinAggregation: peakDirectionSumAggregation("sin"),
+		peakDirectionCosAggregation: peakDirectionSumAggregation("cos"),
+	}
+
+	var stats bool
+	var percents []float64
+	for _, metric := range query.Metrics() {
+		if percent, ok := metric.Percentile(); ok {
+			percents = append(percents, percent)
+		} else {
+			stats = true
+		}
+	}
+
+	for _, field := range query.Fields() {
+		if stats {
+			aggs[statsAggregationName(field)] = map[string]any{"stats": map[string]any{"field": string(field)}}
+		}
+		if len(percents) > 0 {
+			aggs[percentilesAggregationName(field)] = map[string]any{
+				"percentiles": map[string]any{"field": string(field), "percents": percents, "keyed": false},
+			}
+		}
+	}
+
+	return map[string]any{
+		"size":  0,
+		"query": timestampRangeQuery(query.From(), query.To()),
+		"aggs": map[string]any{
+			statisticsAggregation: map[string]any{"date_histogram": histogram, "aggs": aggs},
+		},
+	}
+}
+
+func peakDirectionSumAggregation(function string) map[string]any {
+	return map[string]any{
+		"sum": map[string]any{
+			"script": map[string]any{
+				"source": fmt.Sprintf("Math.%s(Math.toRadians(doc['peak_direction'].value))", function),
+			},
+		},
+	}
+}
+
+func parseWaveDataStatisticsBucket(
+	rawBucket map[string]json.RawMessage,
+	query appmodel.WaveDataStatisticsQuery,
+) (appmodel.WaveDataStatisticsBucket, error) {
+	var key int64
+	if err := json.Unmarshal(rawBucket["key"], &key); err != nil {
+		return appmodel.WaveDataStatisticsBucket{}, err
+	}
+	var docCount int
+	if err := json.Unmarshal(rawBucket["doc_count"], &docCount); err != nil {
+		return appmodel.WaveDataStatisticsBucket{}, err
+	}
+
+	bucket := appmodel.WaveDataStatisticsBucket{
+		Start:  time.UnixMilli(key).UTC(),
+		Count:  docCount,
+		Values: map[appmodel.StatisticsField]map[appmodel.StatisticsMetric]float64{},
+	}
+	if docCount == 0 {
+		return bucket, nil
+	}
+
+	for _, field := range query.Fields() {
+		var stats struct {
+			Min *float64 `json:"min"`
+			Max *float64 `json:"max"`
+			Avg *float64 `json:"avg"`
+		}
+		if err := unmarshalAggregation(rawBucket, statsAggregationName(field), &stats); err != nil {
+			return appmodel.WaveDataStatisticsBucket{}, err
+		}
+		var percentiles struct {
+			Values []struct {
+				Key   float64  `json:"key"`
+				Value *float64 `json:"value"`
+			} `json:"values"`
+		}
+		if err := unmarshalAggregation(rawBucket, percentilesAggregationName(field), &percentiles); err != nil {
+			return appmodel.WaveDataStatisticsBucket{}, err
+		}
+
+		values := map[appmodel.StatisticsMetric]float64{}
+		for _, metric := range query.Metrics() {
+			var value *float64
+			switch metric {
+			case appmodel.StatisticsMetricMin:
+				value = stats.Min
+			case appmodel.StatisticsMetricMax:
+				value = stats.Max
+			case appmodel.StatisticsMetricAvg:
+				value = stats.Avg
+			default:
+				percent, _ := metric.Percentile()
+				for _, percentile := range percentiles.Values {
+					if percentile.Key == percent {
+						value = percentile.Value
+					}
+				}
+			}
+			if value != nil {
+				values[metric] = *value
+			}
+		}
+		if len(values) > 0 {
+			bucket.Values[field] = values
+		}
+	}
+
+	var sumSin, sumCos struct {
+		Value float64 `json:"value"`
+	}
+	if err := unmarshalAggregation(rawBucket, peakDirectionSinAggregation, &sumSin); err != nil {
+		return appmodel.WaveDataStatisticsBucket{}, err
+	}
+	if err := unmarshalAggregation(rawBucket, peakDirectionCosAggregation, &sumCos); err != nil {
+		return appmodel.WaveDataStatisticsBucket{}, err
+	}
+	bucket.PeakDirectionMean = appmodel.CircularMeanDirection(sumSin.Value, sumCos.Value)
+
+	return bucket, nil
+}
+
+// unmarshalAggregation leaves value untouched when the aggregation was not requested.
+func unmarshalAggregation(rawBucket map[string]json.RawMessage, name string, value any) error {
+	raw, ok := rawBucket[name]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, value)
+}
+
+func statsAggregationName(field appmodel.StatisticsField) string {
+	return string(field) + "_stats"
+}
+
+func percentilesAggregationName(field appmodel.StatisticsField) string {
+	return string(field) + "_percentiles"
+}
@@ -0,0 +1,133 @@
+package persistence_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+)
+
+const statisticsResponse = `{
+	"hits": {"hits": []},
+	"aggregations": {
+		"statistics": {
+			"buckets": [
+				{
+					"key_as_string": "2024-09-17T00:00:00.000Z", "key": 1726531200000, "doc_count": 2,
+					"hmax_stats": {"count": 2, "min": 0.9, "max": 1.1, "avg": 1.0, "sum": 2.0},
+					"hmax_percentiles": {"values": [{"key": 90.0, "value": 1.08}]},
+					"peak_direction_sin": {"value": 0.0},
+					"peak_direction_cos": {"value": 1.9}
+				},
+				{
+					"key_as_string": "2024-09-18T00:00:00.000Z", "key": 1726617600000, "doc_count": 0,
+					"hmax_stats": {"count": 0, "min": null, "max": null, "avg": null, "sum": 0.0},
+					"hmax_percentiles": {"values": [{"key": 90.0, "value": null}]},
+					"peak_direction_sin": {"value": 0.0},
+					"peak_direction_cos": {"value": 0.0}
+				}
+			]
+		}
+	}
+}`
+
+func TestStatistics_Success(t *testing.T) {
+	var searchBody []byte
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/test-index/_search", req.URL.Path)
+		assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+		searchBody, _ = io.ReadAll(req.Body)
+		return MockResponse(200, statisticsResponse), nil
+	})
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 18, 23, 59, 59, 0, time.UTC)
+	query, err := appmodel.NewWaveDataStatisticsQuery(&from, &to, appmodel.StatisticsIntervalDay,
+		[]appmodel.StatisticsField{appmodel.StatisticsFieldMaxHeight},
+		[]appmodel.StatisticsMetric{appmodel.StatisticsMetricMax, appmodel.StatisticsMetricP90})
+	require.NoError(t, err)
+
+	buckets, err := waveDataStore.Statistics(context.Background(), "test-index", query)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"size": 0,
+		"query": {"range": {"timestamp": {"gte": "2024-09-17T00:00:00Z", "lte": "2024-09-18T23:59:59Z"}}},
+		"aggs": {
+			"statistics": {
+				"date_histogram": {
+					"field": "timestamp",
+					"calendar_interval": "day",
+					"time_zone": "UTC",
+					"min_doc_count": 0,
+					"extended_bounds": {"min": 1726531200000, "max": 1726703999000}
+				},
+				"aggs": {
+					"hmax_stats": {"stats": {"field": "hmax"}},
+					"hmax_percentiles": {"percentiles": {"field": "hmax", "percents": [90], "keyed": false}},
+					"peak_direction_sin": {"sum": {"script": {"source": "Math.sin(Math.toRadians(doc['peak_direction'].value))"}}},
+					"peak_direction_cos": {"sum": {"script": {"source": "Math.cos(Math.toRadians(doc['peak_direction'].value))"}}}
+				}
+			}
+		}
+	}`, string(searchBody))
+
+	peakDirectionMean := 0.0
+	expected := []appmodel.WaveDataStatisticsBucket{
+		{
+			Start: from,
+			Count: 2,
+			Values: map[appmodel.StatisticsField]map[appmodel.StatisticsMetric]float64{
+				appmodel.StatisticsFieldMaxHeight: {appmodel.StatisticsMetricMax: 1.1, appmodel.StatisticsMetricP90: 1.08},
+			},
+			PeakDirectionMean: &peakDirectionMean,
+		},
+		{
+			Start:  time.Date(2024, 9, 18, 0, 0, 0, 0, time.UTC),
+			Values: map[appmodel.StatisticsField]map[appmodel.StatisticsMetric]float64{},
+		},
+	}
+	assert.Equal(t, expected, buckets)
+}
+
+func TestStatistics_MissingIndex(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, `{"hits": {"hits": []}}`), nil
+	})
+
+	query, err := appmodel.NewWaveDataStatisticsQuery(nil, nil, appmodel.StatisticsIntervalMonth, nil, nil)
+	require.NoError(t, err)
+
+	buckets, err := waveDataStore.Statistics(context.Background(), "unknown-index", query)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+}
+
+func TestStatistics_Error(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(500, `{"error": "internal server error"}`), nil
+	})
+
+	query, err := appmodel.NewWaveDataStatisticsQuery(nil, nil, appmodel.StatisticsIntervalMonth, nil, nil)
+	require.NoError(t, err)
+
+	_, err = waveDataStore.Statistics(context.Background(), "test-index", query)
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+}
+
+func TestStatistics_EmptyIndexName(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, statisticsResponse), nil
+	})
+
+	query, err := appmodel.NewWaveDataStatisticsQuery(nil, nil, appmodel.StatisticsIntervalMonth, nil, nil)
+	require.NoError(t, err)
+
+	_, err = waveDataStore.Statistics(context.Background(), "", query)
+	assert.EqualError(t, err, "indexName cannot be empty")
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
+)
+
+const summariesAggregation = "by_index"
+
+// Summaries describes the observations of all the indices in a single search, grouping them by index.
+func (w *WaveData) Summaries(ctx context.Context, indexNames []string) (map[string]appmodel.WaveDataSummary, error) {
+	summaries := make(map[string]appmodel.WaveDataSummary)
+	if len(indexNames) == 0 {
+		return summaries, nil
+	}
+
+	body, err := json.Marshal(buildWaveDataSummariesBody(len(indexNames)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search body to JSON: %v", err)
+	}
+
+	ignoreUnavailable := true
+	allowNoIndices := true
+	req := esapi.SearchRequest{
+		Index:             indexNames,
+		Body:              bytes.NewReader(body),
+		IgnoreUnavailable: &ignoreUnavailable,
+		AllowNoIndices:    &allowNoIndices,
+	}
+
+	res, err := req.Do(ctx, w.client)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating documents: %w", esTransportError(err))
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error aggregating documents: %w", esResponseError(res))
+	}
+
+	var searchResponse struct {
+		Aggregations struct {
+			ByIndex struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int    `json:"doc_count"`
+					First    struct {
+						Value float64 `json:"value"`
+					} `json:"first"`
+					Last struct {
+						Value float64 `json:"value"`
+					} `json:"last"`
+					Latest struct {
+						Hits struct {
+							Hits []struct {
+								Source model.WaveData `json:"_source"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"latest"`
+				} `json:"buckets"`
+			} `json:"by_index"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %v", err)
+	}
+
+	for _, bucket := range searchResponse.Aggregations.ByIndex.Buckets {
+		if bucket.DocCount == 0 || len(bucket.Latest.Hits.Hits) == 0 {
+			continue
+		}
+		summaries[bucket.Key] = appmodel.WaveDataSummary{
+			Count:  bucket.DocCount,
+			First:  time.UnixMilli(int64(bucket.First.Value)).UTC(),
+			Last:   time.UnixMilli(int64(bucket.Last.Value)).UTC(),
+			Latest: bucket.Latest.Hits.Hits[0].Source,
+		}
+	}
+
+	return summaries, nil
+}
+
+func buildWaveDataSummariesBody(indicesCount int) map[string]any {
+	return map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			summariesAggregation: map[string]any{
+				"terms": map[string]any{"field": "_index", "size": indicesCount},
+				"aggs": map[string]any{
+					"first": map[string]any{"min": map[string]any{"field": "timestamp"}},
+					"last":  map[string]any{"max": map[string]any{"field": "timestamp"}},
+					"latest": map[string]any{
+						"top_hits": map[string]any{
+							"size": 1,
+							"sort": []map[string]any{{"timestamp": map[string]any{"order": "desc"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package persistence_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+)
+
+const summariesResponse = `{
+	"hits": {"hits": []},
+	"aggregations": {
+		"by_index": {
+			"buckets": [
+				{
+					"key": "les-pierres-noires", "doc_count": 2,
+					"first": {"value": 1726561800000, "value_as_string": "2024-09-17T08:30:00.000Z"},
+					"last": {"value": 1726563600000, "value_as_string": "2024-09-17T09:00:00.000Z"},
+					"latest": {"hits": {"hits": [{"_index": "les-pierres-noires", "_source": {
+						"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+						"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15
+					}}]}}
+				}
+			]
+		}
+	}
+}`
+
+func TestSummaries_Success(t *testing.T) {
+	var searchBody []byte
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/les-pierres-noires,belle-ile/_search", req.URL.Path)
+		assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+		assert.Equal(t, "true", req.URL.Query().Get("allow_no_indices"))
+		searchBody, _ = io.ReadAll(req.Body)
+		return MockResponse(200, summariesResponse), nil
+	})
+
+	summaries, err := waveDataStore.Summaries(context.Background(), []string{"les-pierres-noires", "belle-ile"})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"size": 0,
+		"aggs": {
+			"by_index": {
+				"terms": {"field": "_index", "size": 2},
+				"aggs": {
+					"first": {"min": {"field": "timestamp"}},
+					"last": {"max": {"field": "timestamp"}},
+					"latest": {"top_hits": {"size": 1, "sort": [{"timestamp": {"order": "desc"}}]}}
+				}
+			}
+		}
+	}`, string(searchBody))
+
+	assert.Equal(t, map[string]appmodel.WaveDataSummary{
+		"les-pierres-noires": {
+			Count:  2,
+			First:  time.Date(2024, 9, 17, 8, 30, 0, 0, time.UTC),
+			Last:   time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC),
+			Latest: modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		},
+	}, summaries)
+}
+
+func TestSummaries_NoIndices(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request expected")
+		return nil, nil
+	})
+
+	summaries, err := waveDataStore.Summaries(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, summaries)
+}
+
+func TestSummaries_Failure(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(500, `{"error": "internal"}`), nil
+	})
+
+	_, err := waveDataStore.Summaries(context.Background(), []string{"les-pierres-noires"})
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+}
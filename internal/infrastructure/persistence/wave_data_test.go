@@ -5,44 +5,173 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/domain/model"
 	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
 )
 
-func TestAdd_Success(t *testing.T) {
+func TestAddBatch_Success(t *testing.T) {
+	var bulkBody []byte
 	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
-		return MockResponse(201, `{"result": "created"}`), nil
+		assert.Equal(t, "/test-index/_bulk", req.URL.Path)
+		assert.Equal(t, "true", req.URL.Query().Get("refresh"))
+		bulkBody, _ = io.ReadAll(req.Body)
+		return MockResponse(200, `{"errors": false, "items": [
+			{"update": {"status": 201, "result": "created"}},
+			{"update": {"status": 200, "result": "noop"}}
+		]}`), nil
 	})
 
-	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	result, err := waveDataStore.AddBatch(context.Background(), wavesData, "test-index")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{Indexed: 1, Unchanged: 1}, result)
+
+	lines := strings.Split(strings.TrimSpace(string(bulkBody)), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"update": {"_id": "2024-09-17T09:00:00Z"}}`, lines[0])
+	// The fields the observation lacks are sent as null to clear them from the stored observation.
+	assert.JSONEq(t, `{"doc": {"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+		"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15,
+		"tp": null, "tz": null, "mean_direction": null, "hm0": null, "wind_speed": null, "wind_direction": null,
+		"qc_flag": null, "qc_gross_range": null, "qc_consistency": null, "qc_spike": null, "qc_flat_line": null},
+		"doc_as_upsert": true}`, lines[1])
+	assert.JSONEq(t, `{"update": {"_id": "2024-09-17T08:30:00Z"}}`, lines[2])
+}
+
+func TestAddBatch_PartialFailure(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, `{"errors": true, "items": [
+			{"update": {"status": 200, "result": "updated"}},
+			{"update": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse field [hmax]"}}}
+		]}`), nil
+	})
+
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+
+	result, err := waveDataStore.AddBatch(context.Background(), wavesData, "test-index")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{
+		Indexed: 1,
+		Failures: []appmodel.IndexFailure{{
+			Timestamp: wavesData[1].Timestamp(),
+			Reason:    "mapper_parsing_exception: failed to parse field [hmax]",
+		}},
+	}, result)
+}
+
+func TestAddBatch_Error(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(500, `{"error": "internal server error"}`), nil
+	})
+
+	wavesData := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+	}
+
+	_, err := waveDataStore.AddBatch(context.Background(), wavesData, "test-index")
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
+}
+
+func TestAddBatch_EmptyBatch(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request expected for an empty batch")
+		return nil, nil
+	})
+
+	result, err := waveDataStore.AddBatch(context.Background(), nil, "test-index")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{}, result)
+}
+
+func TestAddBatch_EmptyIndexName(t *testing.T) {
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		return MockResponse(200, `{"errors": false, "items": []}`), nil
+	})
+
+	_, err := waveDataStore.AddBatch(context.Background(), nil, "")
+	assert.EqualError(t, err, "indexName cannot be empty")
+}
 
-	err := waveDataStore.Add(context.Background(), waveData, "test-index")
-	assert.NoError(t, err)
+const searchResponse = `{
+	"hits": {
+		"hits": [
+			{"_source": {"timestamp": "2024-09-17T09:00:00Z", "h1_3": 0.6, "hmax": 1.1, "th1_3": 4.7,
+				"peak_direction": 8, "peak_directional_spread": 32, "temperature": 15}},
+			{"_source": {"timestamp": "2024-09-17T08:30:00Z", "h1_3": 0.5, "hmax": 0.9, "th1_3": 4.8,
+				"peak_direction": 4, "peak_directional_spread": 47, "temperature": 15}}
+		]
+	}
+}`
+
+func TestList_Success(t *testing.T) {
+	var searchBody []byte
+	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/test-index/_search", req.URL.Path)
+		assert.Equal(t, "true", req.URL.Query().Get("ignore_unavailable"))
+		searchBody, _ = io.ReadAll(req.Body)
+		return MockResponse(200, searchResponse), nil
+	})
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 9, 17, 10, 0, 0, 0, time.UTC)
+	query, err := appmodel.NewWaveDataQuery(&from, nil, appmodel.EncodeWaveDataCursor(after), 2, appmodel.SortOrderDesc)
+	require.NoError(t, err)
+
+	page, err := waveDataStore.List(context.Background(), "test-index", query)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"size": 2,
+		"query": {"range": {"timestamp": {"gte": "2024-09-17T00:00:00Z"}}},
+		"sort": [{"timestamp": {"order": "desc"}}],
+		"search_after": [1726567200000]
+	}`, string(searchBody))
+
+	expected := []model.WaveData{
+		modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15"),
+		modeltest.MustCreateWaveData(t, "17/09/2024", "08:30", "0.5", "0.9", "4.8", "4", "47", "15"),
+	}
+	assert.Equal(t, expected, page.WaveData)
+	assert.Equal(t, appmodel.EncodeWaveDataCursor(expected[1].Timestamp()), page.NextCursor)
 }
 
-func TestAdd_Error(t *testing.T) {
+func TestList_Error(t *testing.T) {
 	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
 		return MockResponse(500, `{"error": "internal server error"}`), nil
 	})
 
-	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	query, err := appmodel.NewWaveDataQuery(nil, nil, "", 0, "")
+	require.NoError(t, err)
 
-	err := waveDataStore.Add(context.Background(), waveData, "test-index")
-	assert.EqualError(t, err, `error indexing document: 500 Internal Server Error, body: {"error": "internal server error"}`)
+	_, err = waveDataStore.List(context.Background(), "test-index", query)
+	assert.ErrorIs(t, err, appmodel.ErrStorageUnavailable)
 }
 
-func TestAdd_EmptyIndexName(t *testing.T) {
+func TestList_EmptyIndexName(t *testing.T) {
 	waveDataStore := setupMockWaveData(func(req *http.Request) (*http.Response, error) {
-		return MockResponse(201, `{"result": "created"}`), nil
+		return MockResponse(200, searchResponse), nil
 	})
 
-	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "15")
+	query, err := appmodel.NewWaveDataQuery(nil, nil, "", 0, "")
+	require.NoError(t, err)
 
-	err := waveDataStore.Add(context.Background(), waveData, "")
+	_, err = waveDataStore.List(context.Background(), "", query)
 	assert.EqualError(t, err, "indexName cannot be empty")
 }
 
@@ -3,35 +3,115 @@ package chrome
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
 )
 
+// Options tune the runs of the scraper, zero fields take the value of DefaultOptions.
+type Options struct {
+	// NavigationTimeout bounds the connection to Chrome and the page load, ActionTimeout the action run on the page.
+	NavigationTimeout time.Duration
+	ActionTimeout     time.Duration
+	// A failed run is tried up to Attempts times, waiting Backoff before the first retry and twice longer before each
+	// next one.
+	Attempts int
+	Backoff  time.Duration
+	// PoolSize is the number of browser contexts kept open between runs.
+	PoolSize int
+	// DebugDir receives a screenshot and the HTML of the page of each failed run, nothing is saved when empty.
+	DebugDir string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		NavigationTimeout: 30 * time.Second,
+		ActionTimeout:     10 * time.Second,
+		Attempts:          3,
+		Backoff:           2 * time.Second,
+		PoolSize:          2,
+	}
+}
+
+// WithDefaults returns the options with their zero fields set to the value of DefaultOptions.
+func (o Options) WithDefaults() Options {
+	defaults := DefaultOptions()
+	if o.NavigationTimeout <= 0 {
+		o.NavigationTimeout = defaults.NavigationTimeout
+	}
+	if o.ActionTimeout <= 0 {
+		o.ActionTimeout = defaults.ActionTimeout
+	}
+	if o.Attempts <= 0 {
+		o.Attempts = defaults.Attempts
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = defaults.Backoff
+	}
+	if o.PoolSize <= 0 {
+		o.PoolSize = defaults.PoolSize
+	}
+	return o
+}
+
+// browserContext is an isolated browser context of the Chrome connection, with its own cookies, and its tab.
+type browserContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
 type chromedpScraper struct {
+	client    *http.Client
+	chromeURL string
+	options   Options
+
+	mu sync.Mutex
+	// chromodpWS is the debugger URL of the browser, empty once a failure requires to resolve it again, e.g. after
+	// Chrome restarted with a new browser id.
 	chromodpWS string
+	browser    *browserContext
+	pool       []browserContext
 }
 
-func NewChromedpScraper(client *http.Client, chromeURL string) (*chromedpScraper, error) {
+func NewChromedpScraper(client *http.Client, chromeURL string, options Options) (*chromedpScraper, error) {
 	if !strings.HasPrefix(chromeURL, "http://") && !strings.HasPrefix(chromeURL, "https://") {
 		chromeURL = "http://" + chromeURL
 	}
 
-	chromeID, err := getChromeID(client, chromeURL)
+	cs := &chromedpScraper{
+		client:    client,
+		chromeURL: chromeURL,
+		options:   options.WithDefaults(),
+	}
+
+	chromodpWS, err := cs.resolveWS()
 	if err != nil {
 		return nil, err
 	}
+	cs.chromodpWS = chromodpWS
+
+	return cs, nil
+}
+
+func (cs *chromedpScraper) resolveWS() (string, error) {
+	chromeID, err := getChromeID(cs.client, cs.chromeURL)
+	if err != nil {
+		return "", err
+	}
 
-	chromeURL = strings.TrimPrefix(chromeURL, "http://")
-	chromeURL = strings.TrimPrefix(chromeURL, "https://")
+	host := strings.TrimPrefix(cs.chromeURL, "http://")
+	host = strings.TrimPrefix(host, "https://")
 
-	return &chromedpScraper{
-		chromodpWS: fmt.Sprintf("ws://%s/devtools/browser/%s", chromeURL, chromeID),
-	}, nil
+	return fmt.Sprintf("ws://%s/devtools/browser/%s", host, chromeID), nil
 }
 
 const webSocketDebuggerURLFieldSplitedParts = 6
@@ -73,16 +153,215 @@ func getChromeID(client *http.Client, chromeURL string) (string, error) {
 	return parts[len(parts)-1], nil
 }
 
+// Run navigates to targetWeb in a browser context without cookies, then runs actionFunc on the page. A failed run is
+// retried, and the connection to Chrome is opened again before the retry.
 func (cs *chromedpScraper) Run(ctx context.Context, targetWeb string, actionFunc func(context.Context) error) error {
-	ctx, cancel := chromedp.NewRemoteAllocator(ctx, cs.chromodpWS)
+	backoff := cs.options.Backoff
+	for attempt := 1; ; attempt++ {
+		err := cs.run(ctx, targetWeb, actionFunc)
+		if err == nil {
+			return nil
+		}
+		if attempt == cs.options.Attempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, retry cancelled: %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (cs *chromedpScraper) run(ctx context.Context, targetWeb string, actionFunc func(context.Context) error) error {
+	bc, err := cs.acquire(ctx)
+	if err != nil {
+		cs.reset()
+		return err
+	}
+
+	err = runOnPage(ctx, bc.ctx, cs.options, targetWeb, actionFunc)
+	if err == nil {
+		cs.release(bc)
+		return nil
+	}
+
+	if cs.options.DebugDir != "" {
+		err = saveDebugFiles(bc.ctx, cs.options, err)
+	}
+	bc.cancel()
+	// The failure may come from a Chrome that restarted, its connection and browser contexts are not reused.
+	cs.reset()
+
+	return err
+}
+
+func runOnPage(
+	ctx, pageCtx context.Context,
+	options Options,
+	targetWeb string,
+	actionFunc func(context.Context) error,
+) error {
+	navigationCtx, cancel := withTimeout(ctx, pageCtx, options.NavigationTimeout)
 	defer cancel()
 
-	ctx, cancel = chromedp.NewContext(ctx)
+	err := chromedp.Run(navigationCtx, network.ClearBrowserCookies(), chromedp.Navigate(targetWeb))
+	if err != nil {
+		return fmt.Errorf("failed to navigate to %s: %w", targetWeb, err)
+	}
+
+	actionCtx, cancel := withTimeout(ctx, pageCtx, options.ActionTimeout)
+	defer cancel()
+
+	err = chromedp.Run(actionCtx, chromedp.ActionFunc(actionFunc))
+	if err != nil {
+		return fmt.Errorf("failed to run action on %s: %w", targetWeb, err)
+	}
+
+	return nil
+}
+
+// withTimeout derives a context of the chromedp context pageCtx, also cancelled with ctx, which the chromedp
+// contexts do not derive from as they outlive a run.
+func withTimeout(ctx, pageCtx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	timeoutCtx, cancel := context.WithTimeout(pageCtx, timeout)
+	stop := context.AfterFunc(ctx, cancel)
+
+	return timeoutCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// acquire returns a browser context of the pool, or opens a new one, connecting to Chrome first when needed.
+func (cs *chromedpScraper) acquire(ctx context.Context) (browserContext, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if n := len(cs.pool); n > 0 {
+		bc := cs.pool[n-1]
+		cs.pool = cs.pool[:n-1]
+		return bc, nil
+	}
+
+	if cs.browser == nil {
+		if cs.chromodpWS == "" {
+			chromodpWS, err := cs.resolveWS()
+			if err != nil {
+				return browserContext{}, err
+			}
+			cs.chromodpWS = chromodpWS
+		}
+
+		allocatorCtx, cancelAllocator := chromedp.NewRemoteAllocator(context.Background(), cs.chromodpWS)
+		browserCtx, cancelBrowser := chromedp.NewContext(allocatorCtx)
+		browser := &browserContext{ctx: browserCtx, cancel: func() {
+			cancelBrowser()
+			cancelAllocator()
+		}}
+
+		err := runFirst(ctx, *browser, cs.options.NavigationTimeout)
+		if err != nil {
+			return browserContext{}, fmt.Errorf("failed to connect to chrome: %w", err)
+		}
+		cs.browser = browser
+	}
+
+	tabCtx, cancelTab := chromedp.NewContext(cs.browser.ctx, chromedp.WithNewBrowserContext())
+	bc := browserContext{ctx: tabCtx, cancel: cancelTab}
+
+	err := runFirst(ctx, bc, cs.options.NavigationTimeout)
+	if err != nil {
+		return browserContext{}, fmt.Errorf("failed to open browser context: %w", err)
+	}
+
+	return bc, nil
+}
+
+// runFirst runs the first run of a chromedp context, which connects to the browser or opens the tab. The connection
+// and the tab live as long as the context of that run, so it cannot be given a timeout: the chromedp context is
+// cancelled instead when the run takes longer than timeout or ctx is done.
+func runFirst(ctx context.Context, bc browserContext, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() { done <- chromedp.Run(bc.ctx) }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-timer.C:
+		err = fmt.Errorf("timed out after %s", timeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		bc.cancel()
+	}
+	return err
+}
+
+// release puts a browser context back into the pool, or closes it when the pool is full.
+func (cs *chromedpScraper) release(bc browserContext) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	// The browser context is closed with its connection when another run reset it.
+	if bc.ctx.Err() != nil || cs.browser == nil || len(cs.pool) >= cs.options.PoolSize {
+		bc.cancel()
+		return
+	}
+	cs.pool = append(cs.pool, bc)
+}
+
+// reset closes the pooled browser contexts and the connection to Chrome, whose browser id is resolved again on the
+// next run.
+func (cs *chromedpScraper) reset() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	for _, bc := range cs.pool {
+		bc.cancel()
+	}
+	cs.pool = nil
+	if cs.browser != nil {
+		cs.browser.cancel()
+		cs.browser = nil
+	}
+	cs.chromodpWS = ""
+}
+
+// saveDebugFiles saves a screenshot and the HTML of the page of a failed run, and tells where in the run error.
+func saveDebugFiles(pageCtx context.Context, options Options, runErr error) error {
+	ctx, cancel := context.WithTimeout(pageCtx, options.ActionTimeout)
 	defer cancel()
 
-	return chromedp.Run(ctx,
-		network.Enable(),
-		chromedp.Navigate(targetWeb),
-		chromedp.ActionFunc(actionFunc),
+	var screenshot []byte
+	var html string
+	err := chromedp.Run(ctx,
+		chromedp.CaptureScreenshot(&screenshot),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
 	)
+	if err != nil {
+		return errors.Join(runErr, fmt.Errorf("failed to capture debug files: %w", err))
+	}
+
+	err = os.MkdirAll(options.DebugDir, 0o755)
+	if err != nil {
+		return errors.Join(runErr, fmt.Errorf("failed to create debug directory: %w", err))
+	}
+
+	prefix := filepath.Join(options.DebugDir, "chrome-"+time.Now().UTC().Format("20060102T150405.000000000"))
+	err = errors.Join(
+		os.WriteFile(prefix+".png", screenshot, 0o644),
+		os.WriteFile(prefix+".html", []byte(html), 0o644),
+	)
+	if err != nil {
+		return errors.Join(runErr, fmt.Errorf("failed to write debug files: %w", err))
+	}
+
+	return fmt.Errorf("%w, page saved to %s.png and %s.html", runErr, prefix, prefix)
 }
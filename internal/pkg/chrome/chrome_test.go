@@ -1,10 +1,12 @@
 package chrome_test
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,13 +20,23 @@ func mockHTTPResponse(statusCode int, body string) *http.Response {
 	}
 }
 
+func TestOptions_WithDefaults(t *testing.T) {
+	assert.Equal(t, chrome.DefaultOptions(), chrome.Options{}.WithDefaults())
+
+	options := chrome.Options{
+		NavigationTimeout: time.Second, ActionTimeout: time.Second, Attempts: 1, Backoff: time.Millisecond, PoolSize: 1,
+		DebugDir: "/tmp/chrome",
+	}
+	assert.Equal(t, options, options.WithDefaults())
+}
+
 func TestNewChromedpScraper_Success(t *testing.T) {
 	mockHandler := func(req *http.Request) *http.Response {
 		return mockHTTPResponse(200, `{"webSocketDebuggerUrl": "ws://localhost:9222/devtools/browser/abc123"}`)
 	}
 	client := setupMockHTTPClient(mockHandler)
 
-	scraper, err := chrome.NewChromedpScraper(client, "fake.url")
+	scraper, err := chrome.NewChromedpScraper(client, "fake.url", chrome.Options{})
 	require.NoError(t, err)
 	assert.NotNil(t, scraper)
 }
@@ -51,12 +63,50 @@ func TestNewChromedpScraper_Failures(t *testing.T) {
 			}
 			client := setupMockHTTPClient(mockHandler)
 
-			_, err := chrome.NewChromedpScraper(client, "fake.url")
+			_, err := chrome.NewChromedpScraper(client, "fake.url", chrome.Options{})
 			require.EqualError(t, err, tc.expectedErr)
 		})
 	}
 }
 
+func TestChromedpScraper_Run_RetriesAndResolvesBrowserIDAgain(t *testing.T) {
+	versionRequests := 0
+	client := setupMockHTTPClient(func(req *http.Request) *http.Response {
+		versionRequests++
+		assert.Equal(t, "http://127.0.0.1:1/json/version", req.URL.String())
+		return mockHTTPResponse(200, `{"webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/browser/abc123"}`)
+	})
+
+	// Nothing listens on the port, as when Chrome is restarting.
+	scraper, err := chrome.NewChromedpScraper(client, "127.0.0.1:1",
+		chrome.Options{Attempts: 3, Backoff: time.Millisecond, NavigationTimeout: time.Second})
+	require.NoError(t, err)
+
+	err = scraper.Run(context.Background(), "https://example.com", func(context.Context) error {
+		t.Error("action run without Chrome")
+		return nil
+	})
+	assert.ErrorContains(t, err, "failed after 3 attempts: failed to connect to chrome")
+	// Once when created, then before each retry.
+	assert.Equal(t, 3, versionRequests)
+}
+
+func TestChromedpScraper_Run_RetryCancelled(t *testing.T) {
+	client := setupMockHTTPClient(func(req *http.Request) *http.Response {
+		return mockHTTPResponse(200, `{"webSocketDebuggerUrl": "ws://127.0.0.1:1/devtools/browser/abc123"}`)
+	})
+
+	scraper, err := chrome.NewChromedpScraper(client, "127.0.0.1:1",
+		chrome.Options{Attempts: 3, Backoff: time.Hour, NavigationTimeout: time.Second})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err = scraper.Run(ctx, "https://example.com", func(context.Context) error { return nil })
+	assert.ErrorContains(t, err, "retry cancelled: context deadline exceeded")
+}
+
 type mockRoundTripper struct {
 	mockHandler func(req *http.Request) *http.Response
 }
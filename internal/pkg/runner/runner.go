@@ -0,0 +1,103 @@
+// Package runner runs the scraping jobs shared by the one-shot scraper and the scheduler, and logs their reports.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/service"
+)
+
+// Campaigns returns a job scraping and storing the wave data of the campaigns, then evaluating the alert rules. The
+// job fails when a campaign or the alert evaluation failed.
+func Campaigns(
+	log logrus.FieldLogger,
+	campaignsScraper service.CandhisCampaignsScraper,
+	alertEvaluator service.AlertEvaluator,
+) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		results, err := campaignsScraper.FetchAndStoreWaveData(ctx)
+		for _, result := range results {
+			logCampaign := log.WithFields(logrus.Fields{
+				"campaign": result.Campaign.Name(),
+				"buoy_id":  result.Campaign.BuoyID(),
+				"index":    result.Campaign.IndexName(),
+			})
+			if result.Report.LayoutChanged {
+				logCampaign.Warnf("Layout of campaign table changed, fingerprint %s", result.Report.LayoutFingerprint)
+			}
+			for _, rejected := range result.Report.Rejected {
+				logCampaign.Warnf("Rejected row %d of campaign table: %s", rejected.Row, rejected.Reason)
+			}
+			logCampaign = logCampaign.WithFields(IngestionReportFields(result.Report))
+			if result.Err != nil {
+				logCampaign.Errorf("Failed scraping campaign: %v", result.Err)
+				continue
+			}
+			logCampaign.Info("Scraped campaign successfully")
+		}
+		log.WithFields(IngestionReportFields(service.TotalIngestionReport(results))).Info("Ingestion report")
+
+		// Alert rules are evaluated even when some campaigns failed, the others may have new observations
+		events, alertErr := alertEvaluator.Evaluate(ctx)
+		for _, event := range events {
+			log.WithFields(alertEventFields(event)).Info("Alert state changed")
+		}
+		if alertErr != nil {
+			alertErr = fmt.Errorf("failed to evaluate alert rules: %w", alertErr)
+		}
+
+		return errors.Join(err, alertErr)
+	}
+}
+
+// Spectra returns a job downloading and storing the spectra of the campaigns. The job fails when a campaign failed.
+func Spectra(log logrus.FieldLogger, spectraScraper service.CandhisSpectraScraper) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		results, err := spectraScraper.FetchAndStoreSpectra(ctx)
+		for _, result := range results {
+			logCampaign := log.WithFields(logrus.Fields{
+				"campaign": result.Campaign.Name(),
+				"buoy_id":  result.Campaign.BuoyID(),
+				"index":    appmodel.SpectraIndexName(result.Campaign.IndexName()),
+			})
+			for _, rejected := range result.Report.Rejected {
+				logCampaign.Warnf("Rejected row %d of spectral file: %s", rejected.Row, rejected.Reason)
+			}
+			logCampaign = logCampaign.WithFields(IngestionReportFields(result.Report))
+			if result.Err != nil {
+				logCampaign.Errorf("Failed downloading spectra of campaign: %v", result.Err)
+				continue
+			}
+			logCampaign.Info("Downloaded spectra of campaign successfully")
+		}
+		log.WithFields(IngestionReportFields(service.TotalIngestionReport(results))).Info("Spectra ingestion report")
+
+		return err
+	}
+}
+
+func IngestionReportFields(report appmodel.IngestionReport) logrus.Fields {
+	return logrus.Fields{
+		"rows_seen": report.RowsSeen,
+		"parsed":    report.Parsed,
+		"rejected":  len(report.Rejected),
+		"indexed":   report.Indexed,
+		"unchanged": report.Unchanged,
+		"failed":    report.Failed,
+
+		"layout_fingerprint": report.LayoutFingerprint,
+	}
+}
+
+func alertEventFields(event appmodel.AlertEvent) logrus.Fields {
+	return logrus.Fields{
+		"rule_id":           event.RuleID(),
+		"state":             event.State(),
+		"value":             event.Value(),
+		"delivery_attempts": event.DeliveryAttempts(),
+	}
+}
@@ -0,0 +1,134 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/service"
+	"github.com/tul1/candhis_api/internal/pkg/runner"
+)
+
+type campaignsScraperFunc func(ctx context.Context) ([]service.CampaignScrapeResult, error)
+
+func (f campaignsScraperFunc) FetchAndStoreWaveData(ctx context.Context) ([]service.CampaignScrapeResult, error) {
+	return f(ctx)
+}
+
+type spectraScraperFunc func(ctx context.Context) ([]service.CampaignScrapeResult, error)
+
+func (f spectraScraperFunc) FetchAndStoreSpectra(ctx context.Context) ([]service.CampaignScrapeResult, error) {
+	return f(ctx)
+}
+
+type alertEvaluatorFunc func(ctx context.Context) ([]appmodel.AlertEvent, error)
+
+func (f alertEvaluatorFunc) Evaluate(ctx context.Context) ([]appmodel.AlertEvent, error) {
+	return f(ctx)
+}
+
+func TestCampaigns_Success(t *testing.T) {
+	log, hook := logrustest.NewNullLogger()
+	campaign := modeltest.MustCreateCampaign(t, "02911", "Les Pierres Noires", "https://candhis.cerema.fr/", "les-pierres-noires", true)
+	observedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	event := modeltest.MustCreateAlertEvent(t, 1, appmodel.AlertStateFiring, 4.2, observedAt, observedAt, observedAt, 1, "")
+
+	job := runner.Campaigns(log.WithField("job", "campaigns"),
+		campaignsScraperFunc(func(context.Context) ([]service.CampaignScrapeResult, error) {
+			return []service.CampaignScrapeResult{{
+				Campaign: campaign,
+				Report:   appmodel.IngestionReport{RowsSeen: 2, Parsed: 2, Indexed: 2},
+			}}, nil
+		}),
+		alertEvaluatorFunc(func(context.Context) ([]appmodel.AlertEvent, error) {
+			return []appmodel.AlertEvent{event}, nil
+		}),
+	)
+
+	require.NoError(t, job(context.Background()))
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "Scraped campaign successfully", entries[0].Message)
+	assert.Equal(t, "02911", entries[0].Data["buoy_id"])
+	assert.Equal(t, 2, entries[0].Data["indexed"])
+	assert.Equal(t, "Ingestion report", entries[1].Message)
+	assert.Equal(t, "Alert state changed", entries[2].Message)
+	assert.Equal(t, int64(1), entries[2].Data["rule_id"])
+	for _, entry := range entries {
+		assert.Equal(t, "campaigns", entry.Data["job"])
+	}
+}
+
+func TestCampaigns_Failures(t *testing.T) {
+	errScrape := errors.New("error candhis")
+	errAlert := errors.New("error webhook")
+
+	testCases := map[string]struct {
+		scrapeErr   error
+		alertErr    error
+		expectedErr []error
+	}{
+		"scraping failed": {
+			scrapeErr:   errScrape,
+			expectedErr: []error{errScrape},
+		},
+		"alert evaluation failed": {
+			alertErr:    errAlert,
+			expectedErr: []error{errAlert},
+		},
+		"both failed": {
+			scrapeErr:   errScrape,
+			alertErr:    errAlert,
+			expectedErr: []error{errScrape, errAlert},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			log, _ := logrustest.NewNullLogger()
+			evaluated := false
+
+			job := runner.Campaigns(log,
+				campaignsScraperFunc(func(context.Context) ([]service.CampaignScrapeResult, error) {
+					return nil, tc.scrapeErr
+				}),
+				alertEvaluatorFunc(func(context.Context) ([]appmodel.AlertEvent, error) {
+					evaluated = true
+					return nil, tc.alertErr
+				}),
+			)
+
+			err := job(context.Background())
+			for _, expected := range tc.expectedErr {
+				assert.ErrorIs(t, err, expected)
+			}
+			assert.True(t, evaluated, "alert rules must be evaluated even when scraping failed")
+		})
+	}
+}
+
+func TestSpectra_Failure(t *testing.T) {
+	log, hook := logrustest.NewNullLogger()
+	campaign := modeltest.MustCreateCampaign(t, "02911", "Les Pierres Noires", "https://candhis.cerema.fr/", "les-pierres-noires", true)
+	errDownload := errors.New("error candhis")
+
+	job := runner.Spectra(log, spectraScraperFunc(func(context.Context) ([]service.CampaignScrapeResult, error) {
+		return []service.CampaignScrapeResult{{Campaign: campaign, Err: errDownload}}, errDownload
+	}))
+
+	assert.ErrorIs(t, job(context.Background()), errDownload)
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, logrus.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "les-pierres-noires-spectra", entries[0].Data["index"])
+	assert.Equal(t, "Spectra ingestion report", entries[1].Message)
+}
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+)
+
+// Job is a task run on a cron schedule. Its start is delayed by a random duration up to Jitter, and a run is skipped
+// while the previous one of the same job is still running.
+type Job struct {
+	Name     string
+	Schedule string
+	Jitter   time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type JobStatus struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Running        bool       `json:"running"`
+	NextRunAt      time.Time  `json:"next_run_at"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	Runs           int        `json:"runs"`
+	Failures       int        `json:"failures"`
+	Skipped        int        `json:"skipped"`
+}
+
+type scheduledJob struct {
+	job     Job
+	entryID cron.EntryID
+	running sync.Mutex
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+type Scheduler struct {
+	log  *logrus.Logger
+	cron *cron.Cron
+	jobs []*scheduledJob
+	// ctx is the context of the job runs, it is cancelled once Stop stops waiting for them.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// stopping is cancelled as soon as Stop is called, the runs still waiting for their jitter then do not start.
+	stopping context.Context
+	stop     context.CancelFunc
+}
+
+func New(log *logrus.Logger, jobs []Job) (*Scheduler, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopping, stop := context.WithCancel(ctx)
+	s := &Scheduler{
+		log:      log,
+		cron:     cron.New(cron.WithLocation(time.UTC)),
+		ctx:      ctx,
+		cancel:   cancel,
+		stopping: stopping,
+		stop:     stop,
+	}
+
+	for _, job := range jobs {
+		if job.Jitter < 0 {
+			cancel()
+			return nil, fmt.Errorf("invalid jitter of job %s: must not be negative", job.Name)
+		}
+
+		sj := &scheduledJob{job: job, status: JobStatus{Name: job.Name, Schedule: job.Schedule}}
+		entryID, err := s.cron.AddFunc(job.Schedule, func() { s.runJob(sj) })
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("invalid schedule of job %s: %w", job.Name, err)
+		}
+		sj.entryID = entryID
+		s.jobs = append(s.jobs, sj)
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling new runs, drops the runs waiting for their jitter and waits for the running ones to finish.
+// When ctx is done first, the running jobs are cancelled and ctx error is returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stop()
+	defer s.cancel()
+
+	select {
+	case <-s.cron.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("running jobs did not finish in time: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) Statuses() []JobStatus {
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, sj := range s.jobs {
+		sj.mu.Lock()
+		status := sj.status
+		sj.mu.Unlock()
+
+		status.NextRunAt = s.cron.Entry(sj.entryID).Next
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (s *Scheduler) runJob(sj *scheduledJob) {
+	log := s.log.WithField("job", sj.job.Name)
+
+	if !sj.running.TryLock() {
+		sj.mu.Lock()
+		sj.status.Skipped++
+		sj.mu.Unlock()
+		log.Warn("Skipping job run, previous run is still running")
+		return
+	}
+	defer sj.running.Unlock()
+
+	if sj.job.Jitter > 0 {
+		select {
+		case <-time.After(rand.N(sj.job.Jitter)):
+		case <-s.stopping.Done():
+			log.Info("Dropping job run waiting for its jitter, scheduler is stopping")
+			return
+		}
+	}
+
+	startedAt := time.Now().UTC()
+	sj.mu.Lock()
+	sj.status.Running = true
+	sj.status.LastStartedAt = &startedAt
+	sj.mu.Unlock()
+
+	log.Info("Job started")
+	err := sj.job.Run(s.ctx)
+	finishedAt := time.Now().UTC()
+
+	sj.mu.Lock()
+	sj.status.Running = false
+	sj.status.LastFinishedAt = &finishedAt
+	sj.status.Runs++
+	sj.status.LastError = ""
+	if err != nil {
+		sj.status.Failures++
+		sj.status.LastError = err.Error()
+	}
+	sj.mu.Unlock()
+
+	if err != nil {
+		log.Errorf("Job failed: %v", err)
+		return
+	}
+	log.Info("Job finished successfully")
+}
@@ -0,0 +1,144 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/pkg/scheduler"
+)
+
+func TestNew_Failure(t *testing.T) {
+	testCases := map[string]struct {
+		job    scheduler.Job
+		errMsg string
+	}{
+		"invalid schedule": {
+			job:    scheduler.Job{Name: "campaigns", Schedule: "every half hour"},
+			errMsg: "invalid schedule of job campaigns: expected exactly 5 fields, found 3: [every half hour]",
+		},
+		"negative jitter": {
+			job:    scheduler.Job{Name: "campaigns", Schedule: "*/30 * * * *", Jitter: -time.Second},
+			errMsg: "invalid jitter of job campaigns: must not be negative",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, err := scheduler.New(logrus.New(), []scheduler.Job{tc.job})
+			assert.EqualError(t, err, tc.errMsg)
+			assert.Nil(t, s)
+		})
+	}
+}
+
+func TestScheduler_RunsJobs(t *testing.T) {
+	runs := make(chan struct{}, 10)
+	s, err := scheduler.New(logrus.New(), []scheduler.Job{
+		{Name: "ok", Schedule: "@every 1s", Run: func(context.Context) error {
+			runs <- struct{}{}
+			return nil
+		}},
+		{Name: "failing", Schedule: "@every 1s", Run: func(context.Context) error {
+			return errors.New("error candhis")
+		}},
+	})
+	require.NoError(t, err)
+
+	statuses := s.Statuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "ok", statuses[0].Name)
+	assert.Equal(t, "@every 1s", statuses[0].Schedule)
+	assert.Zero(t, statuses[0].Runs)
+	assert.Nil(t, statuses[0].LastStartedAt)
+
+	s.Start()
+	<-runs
+	require.NoError(t, s.Stop(context.Background()))
+
+	statuses = s.Statuses()
+	assert.GreaterOrEqual(t, statuses[0].Runs, 1)
+	assert.Zero(t, statuses[0].Failures)
+	assert.NotNil(t, statuses[0].LastFinishedAt)
+	assert.False(t, statuses[0].Running)
+
+	assert.Equal(t, statuses[1].Runs, statuses[1].Failures)
+	if statuses[1].Runs > 0 {
+		assert.Equal(t, "error candhis", statuses[1].LastError)
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	s, err := scheduler.New(logrus.New(), []scheduler.Job{
+		{Name: "slow", Schedule: "@every 1s", Run: func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}},
+	})
+	require.NoError(t, err)
+
+	s.Start()
+	<-started
+	assert.Eventually(t, func() bool { return s.Statuses()[0].Skipped > 0 }, 3*time.Second, 50*time.Millisecond)
+	assert.True(t, s.Statuses()[0].Running)
+
+	close(release)
+	require.NoError(t, s.Stop(context.Background()))
+	assert.Len(t, started, 0, "a skipped run must not start the job")
+}
+
+func TestScheduler_StopCancelsRunningJobsAfterTimeout(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	s, err := scheduler.New(logrus.New(), []scheduler.Job{
+		{Name: "stuck", Schedule: "@every 1s", Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		}},
+	})
+	require.NoError(t, err)
+
+	s.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = s.Stop(ctx)
+	assert.EqualError(t, err, "running jobs did not finish in time: context deadline exceeded")
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("running job was not cancelled")
+	}
+}
+
+func TestScheduler_StopDropsRunsWaitingForJitter(t *testing.T) {
+	started := make(chan struct{}, 10)
+	s, err := scheduler.New(logrus.New(), []scheduler.Job{
+		{Name: "jittered", Schedule: "@every 1s", Jitter: time.Hour, Run: func(context.Context) error {
+			started <- struct{}{}
+			return nil
+		}},
+	})
+	require.NoError(t, err)
+
+	s.Start()
+	// Leave time for a run to be triggered and wait for its jitter.
+	time.Sleep(1500 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+	assert.Len(t, started, 0, "a run waiting for its jitter must not start once stopped")
+	assert.Zero(t, s.Statuses()[0].Runs)
+}
@@ -1,9 +1,10 @@
 // Package openapi provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.0.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package openapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,15 +12,638 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AlertEventState.
+const (
+	Firing   AlertEventState = "firing"
+	Resolved AlertEventState = "resolved"
+)
+
+// Defines values for AlertRuleComparator.
+const (
+	AlertRuleComparatorGt  AlertRuleComparator = "gt"
+	AlertRuleComparatorGte AlertRuleComparator = "gte"
+	AlertRuleComparatorLt  AlertRuleComparator = "lt"
+	AlertRuleComparatorLte AlertRuleComparator = "lte"
+)
+
+// Defines values for AlertRuleMetric.
+const (
+	AlertRuleMetricH13         AlertRuleMetric = "h1_3"
+	AlertRuleMetricH13Rise     AlertRuleMetric = "h1_3_rise"
+	AlertRuleMetricHmax        AlertRuleMetric = "hmax"
+	AlertRuleMetricHmaxRise    AlertRuleMetric = "hmax_rise"
+	AlertRuleMetricTemperature AlertRuleMetric = "temperature"
+	AlertRuleMetricTh13        AlertRuleMetric = "th1_3"
+)
+
+// Defines values for AlertRuleInputComparator.
+const (
+	AlertRuleInputComparatorGt  AlertRuleInputComparator = "gt"
+	AlertRuleInputComparatorGte AlertRuleInputComparator = "gte"
+	AlertRuleInputComparatorLt  AlertRuleInputComparator = "lt"
+	AlertRuleInputComparatorLte AlertRuleInputComparator = "lte"
+)
+
+// Defines values for AlertRuleInputMetric.
+const (
+	AlertRuleInputMetricH13         AlertRuleInputMetric = "h1_3"
+	AlertRuleInputMetricH13Rise     AlertRuleInputMetric = "h1_3_rise"
+	AlertRuleInputMetricHmax        AlertRuleInputMetric = "hmax"
+	AlertRuleInputMetricHmaxRise    AlertRuleInputMetric = "hmax_rise"
+	AlertRuleInputMetricTemperature AlertRuleInputMetric = "temperature"
+	AlertRuleInputMetricTh13        AlertRuleInputMetric = "th1_3"
+)
+
+// Defines values for CampaignFeatureType.
+const (
+	Feature CampaignFeatureType = "Feature"
+)
+
+// Defines values for CampaignFeatureCollectionType.
+const (
+	FeatureCollection CampaignFeatureCollectionType = "FeatureCollection"
+)
+
+// Defines values for LastScrapeOutcome.
+const (
+	LastScrapeOutcomeFailure LastScrapeOutcome = "failure"
+	LastScrapeOutcomeSuccess LastScrapeOutcome = "success"
+)
+
+// Defines values for PointGeometryType.
+const (
+	Point PointGeometryType = "Point"
+)
+
+// Defines values for QCFlag.
+const (
+	QCFlagFail         QCFlag = 4
+	QCFlagNotEvaluated QCFlag = 2
+	QCFlagPass         QCFlag = 1
+	QCFlagSuspect      QCFlag = 3
+)
+
+// Defines values for ScrapeRunOutcome.
+const (
+	ScrapeRunOutcomeFailure ScrapeRunOutcome = "failure"
+	ScrapeRunOutcomeSuccess ScrapeRunOutcome = "success"
+)
+
+// Defines values for ScrapeRunScraper.
+const (
+	Backfill  ScrapeRunScraper = "backfill"
+	Campaigns ScrapeRunScraper = "campaigns"
+	Catalogue ScrapeRunScraper = "catalogue"
+	Sessionid ScrapeRunScraper = "sessionid"
+	Spectra   ScrapeRunScraper = "spectra"
+)
+
+// Defines values for WaveDataStatisticsInterval.
+const (
+	WaveDataStatisticsIntervalDay   WaveDataStatisticsInterval = "day"
+	WaveDataStatisticsIntervalHour  WaveDataStatisticsInterval = "hour"
+	WaveDataStatisticsIntervalMonth WaveDataStatisticsInterval = "month"
+	WaveDataStatisticsIntervalWeek  WaveDataStatisticsInterval = "week"
+)
+
+// Defines values for ExportCampaignObservationsParamsFormat.
+const (
+	Csv    ExportCampaignObservationsParamsFormat = "csv"
+	Ndjson ExportCampaignObservationsParamsFormat = "ndjson"
+	Netcdf ExportCampaignObservationsParamsFormat = "netcdf"
+)
+
+// Defines values for ListCampaignObservationsParamsSort.
+const (
+	Asc  ListCampaignObservationsParamsSort = "asc"
+	Desc ListCampaignObservationsParamsSort = "desc"
+)
+
+// Defines values for GetCampaignStatisticsParamsInterval.
+const (
+	GetCampaignStatisticsParamsIntervalDay   GetCampaignStatisticsParamsInterval = "day"
+	GetCampaignStatisticsParamsIntervalHour  GetCampaignStatisticsParamsInterval = "hour"
+	GetCampaignStatisticsParamsIntervalMonth GetCampaignStatisticsParamsInterval = "month"
+	GetCampaignStatisticsParamsIntervalWeek  GetCampaignStatisticsParamsInterval = "week"
+)
+
+// Defines values for GetCampaignStatisticsParamsFields.
+const (
+	H13         GetCampaignStatisticsParamsFields = "h1_3"
+	Hmax        GetCampaignStatisticsParamsFields = "hmax"
+	Temperature GetCampaignStatisticsParamsFields = "temperature"
+	Th13        GetCampaignStatisticsParamsFields = "th1_3"
+)
+
+// Defines values for GetCampaignStatisticsParamsMetrics.
+const (
+	Avg GetCampaignStatisticsParamsMetrics = "avg"
+	Max GetCampaignStatisticsParamsMetrics = "max"
+	Min GetCampaignStatisticsParamsMetrics = "min"
+	P50 GetCampaignStatisticsParamsMetrics = "p50"
+	P90 GetCampaignStatisticsParamsMetrics = "p90"
+	P99 GetCampaignStatisticsParamsMetrics = "p99"
+)
+
+// AlertEvent defines model for AlertEvent.
+type AlertEvent struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// DeliveryAttempts Number of webhook requests sent
+	DeliveryAttempts int `json:"delivery_attempts"`
+
+	// DeliveryError Error of the last webhook request, absent when it was delivered
+	DeliveryError *string `json:"delivery_error,omitempty"`
+
+	// FiredAt Time the alert fired, resolved events keep the time of the firing they resolve
+	FiredAt time.Time `json:"fired_at"`
+
+	// ObservedAt Time of the most recent evaluated observation
+	ObservedAt time.Time       `json:"observed_at"`
+	State      AlertEventState `json:"state"`
+
+	// Value Metric value of the evaluated observations
+	Value float64 `json:"value"`
+}
+
+// AlertEventState defines model for AlertEvent.State.
+type AlertEventState string
+
+// AlertEventsList defines model for AlertEventsList.
+type AlertEventsList struct {
+	Events []AlertEvent `json:"events"`
+}
+
+// AlertRule defines model for AlertRule.
+type AlertRule struct {
+	// Campaign Campaign name, as used for its Elasticsearch index
+	Campaign   string              `json:"campaign"`
+	Comparator AlertRuleComparator `json:"comparator"`
+
+	// CooldownSeconds Minimum time between two firings of the rule
+	CooldownSeconds int   `json:"cooldown_seconds"`
+	Enabled         bool  `json:"enabled"`
+	Id              int64 `json:"id"`
+
+	// Metric Observed field of the most recent observation, or its change over the window for the _rise metrics
+	Metric     AlertRuleMetric `json:"metric"`
+	Name       string          `json:"name"`
+	Threshold  float64         `json:"threshold"`
+	WebhookUrl string          `json:"webhook_url"`
+
+	// WindowSeconds Only observations of this last period are evaluated, 0 evaluates the most recent one
+	WindowSeconds int `json:"window_seconds"`
+}
+
+// AlertRuleComparator defines model for AlertRule.Comparator.
+type AlertRuleComparator string
+
+// AlertRuleMetric Observed field of the most recent observation, or its change over the window for the _rise metrics
+type AlertRuleMetric string
+
+// AlertRuleInput defines model for AlertRuleInput.
+type AlertRuleInput struct {
+	// Campaign Campaign name, as used for its Elasticsearch index
+	Campaign   string                   `json:"campaign"`
+	Comparator AlertRuleInputComparator `json:"comparator"`
+
+	// CooldownSeconds Minimum time between two firings of the rule
+	CooldownSeconds *int  `json:"cooldown_seconds,omitempty"`
+	Enabled         *bool `json:"enabled,omitempty"`
+
+	// Metric Observed field of the most recent observation, or its change over the window for the _rise metrics
+	Metric    AlertRuleInputMetric `json:"metric"`
+	Name      string               `json:"name"`
+	Threshold float64              `json:"threshold"`
+
+	// WebhookUrl http(s) URL the alerts are posted to, its host must be one of the allowed webhook hosts of the API and cannot be a loopback or link-local address
+	WebhookUrl string `json:"webhook_url"`
+
+	// WindowSeconds Only observations of this last period are evaluated, 0 evaluates the most recent one
+	WindowSeconds *int `json:"window_seconds,omitempty"`
+}
+
+// AlertRuleInputComparator defines model for AlertRuleInput.Comparator.
+type AlertRuleInputComparator string
+
+// AlertRuleInputMetric Observed field of the most recent observation, or its change over the window for the _rise metrics
+type AlertRuleInputMetric string
+
+// AlertRulesList defines model for AlertRulesList.
+type AlertRulesList struct {
+	AlertRules []AlertRule `json:"alert_rules"`
+}
+
+// Campaign defines model for Campaign.
+type Campaign struct {
+	// Active Whether the buoy is still in service
+	Active bool   `json:"active"`
+	BuoyId string `json:"buoy_id"`
+
+	// CandhisId Identifier of the campaign page on the Candhis website
+	CandhisId string    `json:"candhis_id"`
+	Coverage  *Coverage `json:"coverage,omitempty"`
+
+	// Depth Water depth at the buoy in meters, absent when Candhis does not publish it
+	Depth *float64 `json:"depth,omitempty"`
+
+	// Id Campaign name, as used for its Elasticsearch index and in the campaign paths
+	Id                string      `json:"id"`
+	LastScrape        *LastScrape `json:"last_scrape,omitempty"`
+	LatestObservation *WaveData   `json:"latest_observation,omitempty"`
+	Latitude          float64     `json:"latitude"`
+	Longitude         float64     `json:"longitude"`
+	Name              string      `json:"name"`
+	Operator          *string     `json:"operator,omitempty"`
+}
+
+// CampaignFeature defines model for CampaignFeature.
+type CampaignFeature struct {
+	Geometry   PointGeometry       `json:"geometry"`
+	Id         string              `json:"id"`
+	Properties Campaign            `json:"properties"`
+	Type       CampaignFeatureType `json:"type"`
+}
+
+// CampaignFeatureType defines model for CampaignFeature.Type.
+type CampaignFeatureType string
+
+// CampaignFeatureCollection defines model for CampaignFeatureCollection.
+type CampaignFeatureCollection struct {
+	Features []CampaignFeature             `json:"features"`
+	Type     CampaignFeatureCollectionType `json:"type"`
+}
+
+// CampaignFeatureCollectionType defines model for CampaignFeatureCollection.Type.
+type CampaignFeatureCollectionType string
+
+// CampaignsList defines model for CampaignsList.
+type CampaignsList struct {
+	Campaigns []Campaign `json:"campaigns"`
+}
+
+// Coverage defines model for Coverage.
+type Coverage struct {
+	// Count Number of stored observations
+	Count int `json:"count"`
+
+	// From Time of the oldest stored observation
+	From time.Time `json:"from"`
+
+	// To Time of the most recent stored observation
+	To time.Time `json:"to"`
+}
+
+// DailyCoverage defines model for DailyCoverage.
+type DailyCoverage struct {
+	Coverage float64            `json:"coverage"`
+	Day      openapi_types.Date `json:"day"`
+	Expected int                `json:"expected"`
+	Observed int                `json:"observed"`
+}
+
+// FieldStatistics Metrics of an observation field, a metric is absent when it was not requested
+type FieldStatistics struct {
+	Avg *float64 `json:"avg,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	Min *float64 `json:"min,omitempty"`
+	P50 *float64 `json:"p50,omitempty"`
+	P90 *float64 `json:"p90,omitempty"`
+	P99 *float64 `json:"p99,omitempty"`
+}
+
+// GapReport defines model for GapReport.
+type GapReport struct {
+	// Coverage Percentage of the expected observations that are stored
+	Coverage float64         `json:"coverage"`
+	Days     []DailyCoverage `json:"days"`
+
+	// Expected Number of observations expected over the time range
+	Expected int              `json:"expected"`
+	From     time.Time        `json:"from"`
+	Gaps     []ObservationGap `json:"gaps"`
+
+	// Observed Number of expected observations that are stored
+	Observed int       `json:"observed"`
+	To       time.Time `json:"to"`
+}
+
+// LastScrape defines model for LastScrape.
+type LastScrape struct {
+	FinishedAt time.Time         `json:"finished_at"`
+	Outcome    LastScrapeOutcome `json:"outcome"`
+}
+
+// LastScrapeOutcome defines model for LastScrape.Outcome.
+type LastScrapeOutcome string
+
+// LatestObservation defines model for LatestObservation.
+type LatestObservation struct {
+	// AgeSeconds Time elapsed since the observation, in seconds
+	AgeSeconds  int      `json:"age_seconds"`
+	Observation WaveData `json:"observation"`
+
+	// Stale Whether the age of the observation exceeds stale_after_seconds
+	Stale bool `json:"stale"`
+
+	// StaleAfterSeconds Age from which an observation is stale, in seconds
+	StaleAfterSeconds int `json:"stale_after_seconds"`
+}
+
+// ObservationGap defines model for ObservationGap.
+type ObservationGap struct {
+	// From Time of the first missing observation
+	From time.Time `json:"from"`
+
+	// Missing Number of missing observations
+	Missing int `json:"missing"`
+
+	// To End of the gap, excluded, time of the next expected observation
+	To time.Time `json:"to"`
+}
+
+// ObservationsPage defines model for ObservationsPage.
+type ObservationsPage struct {
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor   *string    `json:"next_cursor,omitempty"`
+	Observations []WaveData `json:"observations"`
+}
+
+// PointGeometry defines model for PointGeometry.
+type PointGeometry struct {
+	// Coordinates Longitude and latitude in decimal degrees
+	Coordinates []float64         `json:"coordinates"`
+	Type        PointGeometryType `json:"type"`
+}
+
+// PointGeometryType defines model for PointGeometry.Type.
+type PointGeometryType string
+
 // Pong defines model for Pong.
 type Pong struct {
 	Message string `json:"message"`
 }
 
+// QCFlag IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+type QCFlag int
+
+// QCFlags Quality control flags of the observation, absent for observations stored before quality control. gross_range flags values outside the possible or plausible range of the buoy, consistency values contradicting each other (e.g. hmax below h1_3), spike a sudden change from the previous observation and flat_line heights and period repeated over consecutive observations. flag is the most severe of them.
+type QCFlags struct {
+	// Consistency IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+	Consistency QCFlag `json:"consistency"`
+
+	// Flag IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+	Flag QCFlag `json:"flag"`
+
+	// FlatLine IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+	FlatLine QCFlag `json:"flat_line"`
+
+	// GrossRange IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+	GrossRange QCFlag `json:"gross_range"`
+
+	// Spike IOOS QARTOD flag, 1 pass, 2 not evaluated, 3 suspect, 4 fail
+	Spike QCFlag `json:"spike"`
+}
+
+// ScrapeRun defines model for ScrapeRun.
+type ScrapeRun struct {
+	// Campaign Buoy ID of the scraped campaign, absent when the run is not about a single campaign
+	Campaign *string `json:"campaign,omitempty"`
+
+	// Error Error of a failed run
+	Error      *string          `json:"error,omitempty"`
+	Failed     int              `json:"failed"`
+	FinishedAt time.Time        `json:"finished_at"`
+	Indexed    int              `json:"indexed"`
+	Outcome    ScrapeRunOutcome `json:"outcome"`
+	Parsed     int              `json:"parsed"`
+	Rejected   int              `json:"rejected"`
+	RowsSeen   int              `json:"rows_seen"`
+	Scraper    ScrapeRunScraper `json:"scraper"`
+	StartedAt  time.Time        `json:"started_at"`
+	Unchanged  int              `json:"unchanged"`
+}
+
+// ScrapeRunOutcome defines model for ScrapeRun.Outcome.
+type ScrapeRunOutcome string
+
+// ScrapeRunScraper defines model for ScrapeRun.Scraper.
+type ScrapeRunScraper string
+
+// ScrapeRunsList defines model for ScrapeRunsList.
+type ScrapeRunsList struct {
+	ScrapeRuns []ScrapeRun `json:"scrape_runs"`
+}
+
+// Spectrum defines model for Spectrum.
+type Spectrum struct {
+	// Bins Frequency bins of the spectrum, by increasing frequency
+	Bins      []SpectrumBin `json:"bins"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// SpectrumBin defines model for SpectrumBin.
+type SpectrumBin struct {
+	// Direction Mean direction of wave origin of the band in degrees
+	Direction float64 `json:"direction"`
+
+	// EnergyDensity Energy density of the band in m²/Hz
+	EnergyDensity float64 `json:"energy_density"`
+
+	// Frequency Center of the frequency band in Hz
+	Frequency float64 `json:"frequency"`
+
+	// Spread Directional spread of the band in degrees
+	Spread float64 `json:"spread"`
+}
+
+// WaveData defines model for WaveData.
+type WaveData struct {
+	// H13 Significant wave height in meters
+	H13 float64 `json:"h1_3"`
+
+	// Hm0 Spectral significant wave height in meters, only published by some buoys
+	Hm0 *float64 `json:"hm0,omitempty"`
+
+	// Hmax Height of the largest wave in meters
+	Hmax float64 `json:"hmax"`
+
+	// MeanDirection Mean direction of wave origin over the spectrum in degrees, only published by some buoys
+	MeanDirection *float64 `json:"mean_direction,omitempty"`
+
+	// PeakDirection Direction of wave origin at the peak of the spectrum in degrees
+	PeakDirection int `json:"peak_direction"`
+
+	// PeakDirectionalSpread Directional spread at the peak of the spectrum in degrees
+	PeakDirectionalSpread int `json:"peak_directional_spread"`
+
+	// Qc Quality control flags of the observation, absent for observations stored before quality control. gross_range flags values outside the possible or plausible range of the buoy, consistency values contradicting each other (e.g. hmax below h1_3), spike a sudden change from the previous observation and flat_line heights and period repeated over consecutive observations. flag is the most severe of them.
+	Qc *QCFlags `json:"qc,omitempty"`
+
+	// Temperature Water temperature in degrees Celsius
+	Temperature float64 `json:"temperature"`
+
+	// Th13 Significant period in seconds
+	Th13      float64   `json:"th1_3"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Tp Peak period in seconds, only published by some buoys
+	Tp *float64 `json:"tp,omitempty"`
+
+	// Tz Mean zero up-crossing period in seconds, only published by some buoys
+	Tz *float64 `json:"tz,omitempty"`
+
+	// WindDirection Direction the wind blows from in degrees, only published by some buoys
+	WindDirection *float64 `json:"wind_direction,omitempty"`
+
+	// WindSpeed Wind speed in meters per second, only published by some buoys
+	WindSpeed *float64 `json:"wind_speed,omitempty"`
+}
+
+// WaveDataStatistics defines model for WaveDataStatistics.
+type WaveDataStatistics struct {
+	Buckets  []WaveDataStatisticsBucket `json:"buckets"`
+	Interval WaveDataStatisticsInterval `json:"interval"`
+}
+
+// WaveDataStatisticsInterval defines model for WaveDataStatistics.Interval.
+type WaveDataStatisticsInterval string
+
+// WaveDataStatisticsBucket defines model for WaveDataStatisticsBucket.
+type WaveDataStatisticsBucket struct {
+	// Count Number of observations in the bucket, the statistics are absent when it is zero
+	Count int `json:"count"`
+
+	// H13 Metrics of an observation field, a metric is absent when it was not requested
+	H13 *FieldStatistics `json:"h1_3,omitempty"`
+
+	// Hmax Metrics of an observation field, a metric is absent when it was not requested
+	Hmax *FieldStatistics `json:"hmax,omitempty"`
+
+	// PeakDirectionMean Circular mean of the peak directions in degrees, absent when the directions cancel each other out
+	PeakDirectionMean *float64 `json:"peak_direction_mean,omitempty"`
+
+	// Start Start of the interval of the bucket
+	Start time.Time `json:"start"`
+
+	// Temperature Metrics of an observation field, a metric is absent when it was not requested
+	Temperature *FieldStatistics `json:"temperature,omitempty"`
+
+	// Th13 Metrics of an observation field, a metric is absent when it was not requested
+	Th13 *FieldStatistics `json:"th1_3,omitempty"`
+}
+
+// ErrorResponse defines model for errorResponse.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ListAlertEventsParams defines parameters for ListAlertEvents.
+type ListAlertEventsParams struct {
+	// Limit Maximum number of events to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ExportCampaignObservationsParams defines parameters for ExportCampaignObservations.
+type ExportCampaignObservationsParams struct {
+	// From Only export observations at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only export observations at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format File format of the export
+	Format *ExportCampaignObservationsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportCampaignObservationsParamsFormat defines parameters for ExportCampaignObservations.
+type ExportCampaignObservationsParamsFormat string
+
+// GetCampaignGapsParams defines parameters for GetCampaignGaps.
+type GetCampaignGapsParams struct {
+	// From Start of the analysed time range, 7 days before to by default
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the analysed time range, excluded, now by default. The range must not exceed 366 days
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListCampaignObservationsParams defines parameters for ListCampaignObservations.
+type ListCampaignObservationsParams struct {
+	// From Only return observations at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return observations at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Cursor Cursor returned as next_cursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of observations in the page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Sort Sort order on the observation timestamp
+	Sort *ListCampaignObservationsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// ListCampaignObservationsParamsSort defines parameters for ListCampaignObservations.
+type ListCampaignObservationsParamsSort string
+
+// GetCampaignNearestSpectrumParams defines parameters for GetCampaignNearestSpectrum.
+type GetCampaignNearestSpectrumParams struct {
+	// Timestamp Time the spectrum is looked for at
+	Timestamp time.Time `form:"timestamp" json:"timestamp"`
+
+	// MaxDistanceMinutes Maximum time between the timestamp and the returned spectrum, in minutes
+	MaxDistanceMinutes *int `form:"max_distance_minutes,omitempty" json:"max_distance_minutes,omitempty"`
+}
+
+// GetCampaignStatisticsParams defines parameters for GetCampaignStatistics.
+type GetCampaignStatisticsParams struct {
+	// From Only aggregate observations at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only aggregate observations at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Interval Calendar interval of the buckets, in UTC, weeks start on monday
+	Interval *GetCampaignStatisticsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
+	// Fields Comma separated observation fields to aggregate, all of them by default
+	Fields *[]GetCampaignStatisticsParamsFields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Metrics Comma separated metrics computed for every field, all of them by default
+	Metrics *[]GetCampaignStatisticsParamsMetrics `form:"metrics,omitempty" json:"metrics,omitempty"`
+}
+
+// GetCampaignStatisticsParamsInterval defines parameters for GetCampaignStatistics.
+type GetCampaignStatisticsParamsInterval string
+
+// GetCampaignStatisticsParamsFields defines parameters for GetCampaignStatistics.
+type GetCampaignStatisticsParamsFields string
+
+// GetCampaignStatisticsParamsMetrics defines parameters for GetCampaignStatistics.
+type GetCampaignStatisticsParamsMetrics string
+
+// ListScrapeRunsParams defines parameters for ListScrapeRuns.
+type ListScrapeRunsParams struct {
+	// Limit Maximum number of runs to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreateAlertRuleJSONRequestBody defines body for CreateAlertRule for application/json ContentType.
+type CreateAlertRuleJSONRequestBody = AlertRuleInput
+
+// UpdateAlertRuleJSONRequestBody defines body for UpdateAlertRule for application/json ContentType.
+type UpdateAlertRuleJSONRequestBody = AlertRuleInput
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -93,12 +717,61 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAlertRules request
+	ListAlertRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAlertRuleWithBody request with any body
+	CreateAlertRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAlertRule(ctx context.Context, body CreateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAlertRule request
+	DeleteAlertRule(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAlertRule request
+	GetAlertRule(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateAlertRuleWithBody request with any body
+	UpdateAlertRuleWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateAlertRule(ctx context.Context, id int64, body UpdateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAlertEvents request
+	ListAlertEvents(ctx context.Context, id int64, params *ListAlertEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCampaigns request
+	ListCampaigns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCampaign request
+	GetCampaign(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportCampaignObservations request
+	ExportCampaignObservations(ctx context.Context, campaign string, params *ExportCampaignObservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCampaignGaps request
+	GetCampaignGaps(ctx context.Context, campaign string, params *GetCampaignGapsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCampaignLatestObservation request
+	GetCampaignLatestObservation(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCampaignObservations request
+	ListCampaignObservations(ctx context.Context, campaign string, params *ListCampaignObservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCampaignNearestSpectrum request
+	GetCampaignNearestSpectrum(ctx context.Context, campaign string, params *GetCampaignNearestSpectrumParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCampaignStatistics request
+	GetCampaignStatistics(ctx context.Context, campaign string, params *GetCampaignStatisticsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Ping request
 	Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListScrapeRuns request
+	ListScrapeRuns(ctx context.Context, params *ListScrapeRunsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPingRequest(c.Server)
+func (c *Client) ListAlertRules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAlertRulesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -109,152 +782,3035 @@ func (c *Client) Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http
 	return c.Client.Do(req)
 }
 
-// NewPingRequest generates requests for Ping
-func NewPingRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) CreateAlertRuleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAlertRuleRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/ping")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CreateAlertRule(ctx context.Context, body CreateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAlertRuleRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) DeleteAlertRule(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAlertRuleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) GetAlertRule(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAlertRuleRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
-
-// WithBaseURL overrides the baseURL.
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateAlertRuleWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateAlertRuleRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateAlertRule(ctx context.Context, id int64, body UpdateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateAlertRuleRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAlertEvents(ctx context.Context, id int64, params *ListAlertEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAlertEventsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCampaigns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCampaignsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCampaign(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCampaignRequest(c.Server, campaign)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportCampaignObservations(ctx context.Context, campaign string, params *ExportCampaignObservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportCampaignObservationsRequest(c.Server, campaign, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCampaignGaps(ctx context.Context, campaign string, params *GetCampaignGapsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCampaignGapsRequest(c.Server, campaign, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCampaignLatestObservation(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCampaignLatestObservationRequest(c.Server, campaign)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCampaignObservations(ctx context.Context, campaign string, params *ListCampaignObservationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCampaignObservationsRequest(c.Server, campaign, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCampaignNearestSpectrum(ctx context.Context, campaign string, params *GetCampaignNearestSpectrumParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCampaignNearestSpectrumRequest(c.Server, campaign, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCampaignStatistics(ctx context.Context, campaign string, params *GetCampaignStatisticsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCampaignStatisticsRequest(c.Server, campaign, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Ping(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListScrapeRuns(ctx context.Context, params *ListScrapeRunsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListScrapeRunsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListAlertRulesRequest generates requests for ListAlertRules
+func NewListAlertRulesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateAlertRuleRequest calls the generic CreateAlertRule builder with application/json body
+func NewCreateAlertRuleRequest(server string, body CreateAlertRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAlertRuleRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateAlertRuleRequestWithBody generates requests for CreateAlertRule with any type of body
+func NewCreateAlertRuleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAlertRuleRequest generates requests for DeleteAlertRule
+func NewDeleteAlertRuleRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAlertRuleRequest generates requests for GetAlertRule
+func NewGetAlertRuleRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateAlertRuleRequest calls the generic UpdateAlertRule builder with application/json body
+func NewUpdateAlertRuleRequest(server string, id int64, body UpdateAlertRuleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateAlertRuleRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateAlertRuleRequestWithBody generates requests for UpdateAlertRule with any type of body
+func NewUpdateAlertRuleRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListAlertEventsRequest generates requests for ListAlertEvents
+func NewListAlertEventsRequest(server string, id int64, params *ListAlertEventsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/alert-rules/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCampaignsRequest generates requests for ListCampaigns
+func NewListCampaignsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCampaignRequest generates requests for GetCampaign
+func NewGetCampaignRequest(server string, campaign string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportCampaignObservationsRequest generates requests for ExportCampaignObservations
+func NewExportCampaignObservationsRequest(server string, campaign string, params *ExportCampaignObservationsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCampaignGapsRequest generates requests for GetCampaignGaps
+func NewGetCampaignGapsRequest(server string, campaign string, params *GetCampaignGapsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/gaps", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCampaignLatestObservationRequest generates requests for GetCampaignLatestObservation
+func NewGetCampaignLatestObservationRequest(server string, campaign string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/latest", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCampaignObservationsRequest generates requests for ListCampaignObservations
+func NewListCampaignObservationsRequest(server string, campaign string, params *ListCampaignObservationsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/observations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCampaignNearestSpectrumRequest generates requests for GetCampaignNearestSpectrum
+func NewGetCampaignNearestSpectrumRequest(server string, campaign string, params *GetCampaignNearestSpectrumParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/spectra/nearest", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "timestamp", runtime.ParamLocationQuery, params.Timestamp); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.MaxDistanceMinutes != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_distance_minutes", runtime.ParamLocationQuery, *params.MaxDistanceMinutes); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCampaignStatisticsRequest generates requests for GetCampaignStatistics
+func NewGetCampaignStatisticsRequest(server string, campaign string, params *GetCampaignStatisticsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "campaign", runtime.ParamLocationPath, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/campaigns/%s/statistics", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Interval != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "interval", runtime.ParamLocationQuery, *params.Interval); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Metrics != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "metrics", runtime.ParamLocationQuery, *params.Metrics); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPingRequest generates requests for Ping
+func NewPingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ping")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListScrapeRunsRequest generates requests for ListScrapeRuns
+func NewListScrapeRunsRequest(server string, params *ListScrapeRunsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scrape-runs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
 		newBaseURL, err := url.Parse(baseURL)
 		if err != nil {
 			return err
 		}
-		c.Server = newBaseURL.String()
-		return nil
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListAlertRulesWithResponse request
+	ListAlertRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAlertRulesResponse, error)
+
+	// CreateAlertRuleWithBodyWithResponse request with any body
+	CreateAlertRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAlertRuleResponse, error)
+
+	CreateAlertRuleWithResponse(ctx context.Context, body CreateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAlertRuleResponse, error)
+
+	// DeleteAlertRuleWithResponse request
+	DeleteAlertRuleWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteAlertRuleResponse, error)
+
+	// GetAlertRuleWithResponse request
+	GetAlertRuleWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetAlertRuleResponse, error)
+
+	// UpdateAlertRuleWithBodyWithResponse request with any body
+	UpdateAlertRuleWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateAlertRuleResponse, error)
+
+	UpdateAlertRuleWithResponse(ctx context.Context, id int64, body UpdateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateAlertRuleResponse, error)
+
+	// ListAlertEventsWithResponse request
+	ListAlertEventsWithResponse(ctx context.Context, id int64, params *ListAlertEventsParams, reqEditors ...RequestEditorFn) (*ListAlertEventsResponse, error)
+
+	// ListCampaignsWithResponse request
+	ListCampaignsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCampaignsResponse, error)
+
+	// GetCampaignWithResponse request
+	GetCampaignWithResponse(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*GetCampaignResponse, error)
+
+	// ExportCampaignObservationsWithResponse request
+	ExportCampaignObservationsWithResponse(ctx context.Context, campaign string, params *ExportCampaignObservationsParams, reqEditors ...RequestEditorFn) (*ExportCampaignObservationsResponse, error)
+
+	// GetCampaignGapsWithResponse request
+	GetCampaignGapsWithResponse(ctx context.Context, campaign string, params *GetCampaignGapsParams, reqEditors ...RequestEditorFn) (*GetCampaignGapsResponse, error)
+
+	// GetCampaignLatestObservationWithResponse request
+	GetCampaignLatestObservationWithResponse(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*GetCampaignLatestObservationResponse, error)
+
+	// ListCampaignObservationsWithResponse request
+	ListCampaignObservationsWithResponse(ctx context.Context, campaign string, params *ListCampaignObservationsParams, reqEditors ...RequestEditorFn) (*ListCampaignObservationsResponse, error)
+
+	// GetCampaignNearestSpectrumWithResponse request
+	GetCampaignNearestSpectrumWithResponse(ctx context.Context, campaign string, params *GetCampaignNearestSpectrumParams, reqEditors ...RequestEditorFn) (*GetCampaignNearestSpectrumResponse, error)
+
+	// GetCampaignStatisticsWithResponse request
+	GetCampaignStatisticsWithResponse(ctx context.Context, campaign string, params *GetCampaignStatisticsParams, reqEditors ...RequestEditorFn) (*GetCampaignStatisticsResponse, error)
+
+	// PingWithResponse request
+	PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error)
+
+	// ListScrapeRunsWithResponse request
+	ListScrapeRunsWithResponse(ctx context.Context, params *ListScrapeRunsParams, reqEditors ...RequestEditorFn) (*ListScrapeRunsResponse, error)
+}
+
+type ListAlertRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertRulesList
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAlertRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAlertRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAlertRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *AlertRule
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAlertRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAlertRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAlertRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAlertRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAlertRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAlertRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertRule
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAlertRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAlertRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateAlertRuleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertRule
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateAlertRuleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateAlertRuleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAlertEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AlertEventsList
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAlertEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAlertEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCampaignsResponse struct {
+	Body                  []byte
+	HTTPResponse          *http.Response
+	ApplicationgeoJSON200 *CampaignFeatureCollection
+	JSON200               *CampaignsList
+	JSON500               *ErrorResponse
+	JSON503               *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCampaignsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCampaignsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCampaignResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Campaign
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCampaignResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCampaignResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportCampaignObservationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportCampaignObservationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportCampaignObservationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCampaignGapsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GapReport
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCampaignGapsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCampaignGapsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCampaignLatestObservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LatestObservation
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCampaignLatestObservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCampaignLatestObservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCampaignObservationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ObservationsPage
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCampaignObservationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCampaignObservationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCampaignNearestSpectrumResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Spectrum
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCampaignNearestSpectrumResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCampaignNearestSpectrumResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCampaignStatisticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WaveDataStatistics
+	JSON400      *ErrorResponse
+	JSON404      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCampaignStatisticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCampaignStatisticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pong
+}
+
+// Status returns HTTPResponse.Status
+func (r PingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListScrapeRunsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScrapeRunsList
+	JSON400      *ErrorResponse
+	JSON500      *ErrorResponse
+	JSON503      *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListScrapeRunsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListScrapeRunsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListAlertRulesWithResponse request returning *ListAlertRulesResponse
+func (c *ClientWithResponses) ListAlertRulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAlertRulesResponse, error) {
+	rsp, err := c.ListAlertRules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAlertRulesResponse(rsp)
+}
+
+// CreateAlertRuleWithBodyWithResponse request with arbitrary body returning *CreateAlertRuleResponse
+func (c *ClientWithResponses) CreateAlertRuleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAlertRuleResponse, error) {
+	rsp, err := c.CreateAlertRuleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAlertRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAlertRuleWithResponse(ctx context.Context, body CreateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAlertRuleResponse, error) {
+	rsp, err := c.CreateAlertRule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAlertRuleResponse(rsp)
+}
+
+// DeleteAlertRuleWithResponse request returning *DeleteAlertRuleResponse
+func (c *ClientWithResponses) DeleteAlertRuleWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteAlertRuleResponse, error) {
+	rsp, err := c.DeleteAlertRule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAlertRuleResponse(rsp)
+}
+
+// GetAlertRuleWithResponse request returning *GetAlertRuleResponse
+func (c *ClientWithResponses) GetAlertRuleWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetAlertRuleResponse, error) {
+	rsp, err := c.GetAlertRule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAlertRuleResponse(rsp)
+}
+
+// UpdateAlertRuleWithBodyWithResponse request with arbitrary body returning *UpdateAlertRuleResponse
+func (c *ClientWithResponses) UpdateAlertRuleWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateAlertRuleResponse, error) {
+	rsp, err := c.UpdateAlertRuleWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateAlertRuleResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateAlertRuleWithResponse(ctx context.Context, id int64, body UpdateAlertRuleJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateAlertRuleResponse, error) {
+	rsp, err := c.UpdateAlertRule(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateAlertRuleResponse(rsp)
+}
+
+// ListAlertEventsWithResponse request returning *ListAlertEventsResponse
+func (c *ClientWithResponses) ListAlertEventsWithResponse(ctx context.Context, id int64, params *ListAlertEventsParams, reqEditors ...RequestEditorFn) (*ListAlertEventsResponse, error) {
+	rsp, err := c.ListAlertEvents(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAlertEventsResponse(rsp)
+}
+
+// ListCampaignsWithResponse request returning *ListCampaignsResponse
+func (c *ClientWithResponses) ListCampaignsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCampaignsResponse, error) {
+	rsp, err := c.ListCampaigns(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCampaignsResponse(rsp)
+}
+
+// GetCampaignWithResponse request returning *GetCampaignResponse
+func (c *ClientWithResponses) GetCampaignWithResponse(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*GetCampaignResponse, error) {
+	rsp, err := c.GetCampaign(ctx, campaign, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCampaignResponse(rsp)
+}
+
+// ExportCampaignObservationsWithResponse request returning *ExportCampaignObservationsResponse
+func (c *ClientWithResponses) ExportCampaignObservationsWithResponse(ctx context.Context, campaign string, params *ExportCampaignObservationsParams, reqEditors ...RequestEditorFn) (*ExportCampaignObservationsResponse, error) {
+	rsp, err := c.ExportCampaignObservations(ctx, campaign, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportCampaignObservationsResponse(rsp)
+}
+
+// GetCampaignGapsWithResponse request returning *GetCampaignGapsResponse
+func (c *ClientWithResponses) GetCampaignGapsWithResponse(ctx context.Context, campaign string, params *GetCampaignGapsParams, reqEditors ...RequestEditorFn) (*GetCampaignGapsResponse, error) {
+	rsp, err := c.GetCampaignGaps(ctx, campaign, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCampaignGapsResponse(rsp)
+}
+
+// GetCampaignLatestObservationWithResponse request returning *GetCampaignLatestObservationResponse
+func (c *ClientWithResponses) GetCampaignLatestObservationWithResponse(ctx context.Context, campaign string, reqEditors ...RequestEditorFn) (*GetCampaignLatestObservationResponse, error) {
+	rsp, err := c.GetCampaignLatestObservation(ctx, campaign, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCampaignLatestObservationResponse(rsp)
+}
+
+// ListCampaignObservationsWithResponse request returning *ListCampaignObservationsResponse
+func (c *ClientWithResponses) ListCampaignObservationsWithResponse(ctx context.Context, campaign string, params *ListCampaignObservationsParams, reqEditors ...RequestEditorFn) (*ListCampaignObservationsResponse, error) {
+	rsp, err := c.ListCampaignObservations(ctx, campaign, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCampaignObservationsResponse(rsp)
+}
+
+// GetCampaignNearestSpectrumWithResponse request returning *GetCampaignNearestSpectrumResponse
+func (c *ClientWithResponses) GetCampaignNearestSpectrumWithResponse(ctx context.Context, campaign string, params *GetCampaignNearestSpectrumParams, reqEditors ...RequestEditorFn) (*GetCampaignNearestSpectrumResponse, error) {
+	rsp, err := c.GetCampaignNearestSpectrum(ctx, campaign, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCampaignNearestSpectrumResponse(rsp)
+}
+
+// GetCampaignStatisticsWithResponse request returning *GetCampaignStatisticsResponse
+func (c *ClientWithResponses) GetCampaignStatisticsWithResponse(ctx context.Context, campaign string, params *GetCampaignStatisticsParams, reqEditors ...RequestEditorFn) (*GetCampaignStatisticsResponse, error) {
+	rsp, err := c.GetCampaignStatistics(ctx, campaign, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCampaignStatisticsResponse(rsp)
+}
+
+// PingWithResponse request returning *PingResponse
+func (c *ClientWithResponses) PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error) {
+	rsp, err := c.Ping(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePingResponse(rsp)
+}
+
+// ListScrapeRunsWithResponse request returning *ListScrapeRunsResponse
+func (c *ClientWithResponses) ListScrapeRunsWithResponse(ctx context.Context, params *ListScrapeRunsParams, reqEditors ...RequestEditorFn) (*ListScrapeRunsResponse, error) {
+	rsp, err := c.ListScrapeRuns(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListScrapeRunsResponse(rsp)
+}
+
+// ParseListAlertRulesResponse parses an HTTP response from a ListAlertRulesWithResponse call
+func ParseListAlertRulesResponse(rsp *http.Response) (*ListAlertRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAlertRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertRulesList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAlertRuleResponse parses an HTTP response from a CreateAlertRuleWithResponse call
+func ParseCreateAlertRuleResponse(rsp *http.Response) (*CreateAlertRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAlertRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest AlertRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAlertRuleResponse parses an HTTP response from a DeleteAlertRuleWithResponse call
+func ParseDeleteAlertRuleResponse(rsp *http.Response) (*DeleteAlertRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAlertRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAlertRuleResponse parses an HTTP response from a GetAlertRuleWithResponse call
+func ParseGetAlertRuleResponse(rsp *http.Response) (*GetAlertRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAlertRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateAlertRuleResponse parses an HTTP response from a UpdateAlertRuleWithResponse call
+func ParseUpdateAlertRuleResponse(rsp *http.Response) (*UpdateAlertRuleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateAlertRuleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAlertEventsResponse parses an HTTP response from a ListAlertEventsWithResponse call
+func ParseListAlertEventsResponse(rsp *http.Response) (*ListAlertEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAlertEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AlertEventsList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCampaignsResponse parses an HTTP response from a ListCampaignsWithResponse call
+func ParseListCampaignsResponse(rsp *http.Response) (*ListCampaignsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCampaignsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.Header.Get("Content-Type") == "application/geo+json" && rsp.StatusCode == 200:
+		var dest CampaignFeatureCollection
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationgeoJSON200 = &dest
+
+	case rsp.Header.Get("Content-Type") == "application/json" && rsp.StatusCode == 200:
+		var dest CampaignsList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCampaignResponse parses an HTTP response from a GetCampaignWithResponse call
+func ParseGetCampaignResponse(rsp *http.Response) (*GetCampaignResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCampaignResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Campaign
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportCampaignObservationsResponse parses an HTTP response from a ExportCampaignObservationsWithResponse call
+func ParseExportCampaignObservationsResponse(rsp *http.Response) (*ExportCampaignObservationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportCampaignObservationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCampaignGapsResponse parses an HTTP response from a GetCampaignGapsWithResponse call
+func ParseGetCampaignGapsResponse(rsp *http.Response) (*GetCampaignGapsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCampaignGapsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GapReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCampaignLatestObservationResponse parses an HTTP response from a GetCampaignLatestObservationWithResponse call
+func ParseGetCampaignLatestObservationResponse(rsp *http.Response) (*GetCampaignLatestObservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCampaignLatestObservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LatestObservation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCampaignObservationsResponse parses an HTTP response from a ListCampaignObservationsWithResponse call
+func ParseListCampaignObservationsResponse(rsp *http.Response) (*ListCampaignObservationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCampaignObservationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ObservationsPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCampaignNearestSpectrumResponse parses an HTTP response from a GetCampaignNearestSpectrumWithResponse call
+func ParseGetCampaignNearestSpectrumResponse(rsp *http.Response) (*GetCampaignNearestSpectrumResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCampaignNearestSpectrumResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Spectrum
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCampaignStatisticsResponse parses an HTTP response from a GetCampaignStatisticsWithResponse call
+func ParseGetCampaignStatisticsResponse(rsp *http.Response) (*GetCampaignStatisticsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCampaignStatisticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WaveDataStatistics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePingResponse parses an HTTP response from a PingWithResponse call
+func ParsePingResponse(rsp *http.Response) (*PingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Pong
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListScrapeRunsResponse parses an HTTP response from a ListScrapeRunsWithResponse call
+func ParseListScrapeRunsResponse(rsp *http.Response) (*ListScrapeRunsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListScrapeRunsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScrapeRunsList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /alert-rules)
+	ListAlertRules(c *gin.Context)
+
+	// (POST /alert-rules)
+	CreateAlertRule(c *gin.Context)
+
+	// (DELETE /alert-rules/{id})
+	DeleteAlertRule(c *gin.Context, id int64)
+
+	// (GET /alert-rules/{id})
+	GetAlertRule(c *gin.Context, id int64)
+
+	// (PUT /alert-rules/{id})
+	UpdateAlertRule(c *gin.Context, id int64)
+
+	// (GET /alert-rules/{id}/events)
+	ListAlertEvents(c *gin.Context, id int64, params ListAlertEventsParams)
+
+	// (GET /campaigns)
+	ListCampaigns(c *gin.Context)
+
+	// (GET /campaigns/{campaign})
+	GetCampaign(c *gin.Context, campaign string)
+
+	// (GET /campaigns/{campaign}/export)
+	ExportCampaignObservations(c *gin.Context, campaign string, params ExportCampaignObservationsParams)
+
+	// (GET /campaigns/{campaign}/gaps)
+	GetCampaignGaps(c *gin.Context, campaign string, params GetCampaignGapsParams)
+
+	// (GET /campaigns/{campaign}/latest)
+	GetCampaignLatestObservation(c *gin.Context, campaign string)
+
+	// (GET /campaigns/{campaign}/observations)
+	ListCampaignObservations(c *gin.Context, campaign string, params ListCampaignObservationsParams)
+
+	// (GET /campaigns/{campaign}/spectra/nearest)
+	GetCampaignNearestSpectrum(c *gin.Context, campaign string, params GetCampaignNearestSpectrumParams)
+
+	// (GET /campaigns/{campaign}/statistics)
+	GetCampaignStatistics(c *gin.Context, campaign string, params GetCampaignStatisticsParams)
+
+	// (GET /ping)
+	Ping(c *gin.Context)
+
+	// (GET /scrape-runs)
+	ListScrapeRuns(c *gin.Context, params ListScrapeRunsParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// ListAlertRules operation middleware
+func (siw *ServerInterfaceWrapper) ListAlertRules(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAlertRules(c)
+}
+
+// CreateAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) CreateAlertRule(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateAlertRule(c)
+}
+
+// DeleteAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAlertRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteAlertRule(c, id)
+}
+
+// GetAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) GetAlertRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAlertRule(c, id)
+}
+
+// UpdateAlertRule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAlertRule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PingWithResponse request
-	PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error)
+	siw.Handler.UpdateAlertRule(c, id)
 }
 
-type PingResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Pong
+// ListAlertEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListAlertEvents(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAlertEventsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAlertEvents(c, id, params)
 }
 
-// Status returns HTTPResponse.Status
-func (r PingResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ListCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) ListCampaigns(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
-	return http.StatusText(0)
+
+	siw.Handler.ListCampaigns(c)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PingResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// GetCampaign operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaign(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
 	}
-	return 0
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCampaign(c, campaign)
 }
 
-// PingWithResponse request returning *PingResponse
-func (c *ClientWithResponses) PingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PingResponse, error) {
-	rsp, err := c.Ping(ctx, reqEditors...)
+// ExportCampaignObservations operation middleware
+func (siw *ServerInterfaceWrapper) ExportCampaignObservations(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
 	}
-	return ParsePingResponse(rsp)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportCampaignObservationsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportCampaignObservations(c, campaign, params)
 }
 
-// ParsePingResponse parses an HTTP response from a PingWithResponse call
-func ParsePingResponse(rsp *http.Response) (*PingResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
+// GetCampaignGaps operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignGaps(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return nil, err
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	response := &PingResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignGapsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Pong
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
 		}
-		response.JSON200 = &dest
+	}
+
+	siw.Handler.GetCampaignGaps(c, campaign, params)
+}
 
+// GetCampaignLatestObservation operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignLatestObservation(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	return response, nil
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCampaignLatestObservation(c, campaign)
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
+// ListCampaignObservations operation middleware
+func (siw *ServerInterfaceWrapper) ListCampaignObservations(c *gin.Context) {
 
-	// (GET /ping)
-	Ping(c *gin.Context)
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCampaignObservationsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListCampaignObservations(c, campaign, params)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandler       func(*gin.Context, error, int)
+// GetCampaignNearestSpectrum operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignNearestSpectrum(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignNearestSpectrumParams
+
+	// ------------- Required query parameter "timestamp" -------------
+
+	if paramValue := c.Query("timestamp"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument timestamp is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "timestamp", c.Request.URL.Query(), &params.Timestamp)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timestamp: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "max_distance_minutes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_distance_minutes", c.Request.URL.Query(), &params.MaxDistanceMinutes)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_distance_minutes: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCampaignNearestSpectrum(c, campaign, params)
 }
 
-type MiddlewareFunc func(c *gin.Context)
+// GetCampaignStatistics operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignStatistics(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", c.Param("campaign"), &campaign, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter campaign: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignStatisticsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", c.Request.URL.Query(), &params.Interval)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter interval: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fields: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "metrics" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "metrics", c.Request.URL.Query(), &params.Metrics)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter metrics: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCampaignStatistics(c, campaign, params)
+}
 
 // Ping operation middleware
 func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
@@ -269,6 +3825,32 @@ func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
 	siw.Handler.Ping(c)
 }
 
+// ListScrapeRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListScrapeRuns(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListScrapeRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListScrapeRuns(c, params)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -296,5 +3878,20 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/alert-rules", wrapper.ListAlertRules)
+	router.POST(options.BaseURL+"/alert-rules", wrapper.CreateAlertRule)
+	router.DELETE(options.BaseURL+"/alert-rules/:id", wrapper.DeleteAlertRule)
+	router.GET(options.BaseURL+"/alert-rules/:id", wrapper.GetAlertRule)
+	router.PUT(options.BaseURL+"/alert-rules/:id", wrapper.UpdateAlertRule)
+	router.GET(options.BaseURL+"/alert-rules/:id/events", wrapper.ListAlertEvents)
+	router.GET(options.BaseURL+"/campaigns", wrapper.ListCampaigns)
+	router.GET(options.BaseURL+"/campaigns/:campaign", wrapper.GetCampaign)
+	router.GET(options.BaseURL+"/campaigns/:campaign/export", wrapper.ExportCampaignObservations)
+	router.GET(options.BaseURL+"/campaigns/:campaign/gaps", wrapper.GetCampaignGaps)
+	router.GET(options.BaseURL+"/campaigns/:campaign/latest", wrapper.GetCampaignLatestObservation)
+	router.GET(options.BaseURL+"/campaigns/:campaign/observations", wrapper.ListCampaignObservations)
+	router.GET(options.BaseURL+"/campaigns/:campaign/spectra/nearest", wrapper.GetCampaignNearestSpectrum)
+	router.GET(options.BaseURL+"/campaigns/:campaign/statistics", wrapper.GetCampaignStatistics)
 	router.GET(options.BaseURL+"/ping", wrapper.Ping)
+	router.GET(options.BaseURL+"/scrape-runs", wrapper.ListScrapeRuns)
 }
@@ -0,0 +1,29 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAlertRules(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.ListAlertRulesWithResponse(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	assert.NotNil(t, resp.JSON200.AlertRules)
+}
+
+func TestGetAlertRule_NotFound(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.GetAlertRuleWithResponse(context.Background(), -1)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
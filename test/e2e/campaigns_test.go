@@ -0,0 +1,53 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func TestListCampaigns(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.ListCampaignsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.NotNil(t, resp.JSON200)
+
+	for _, campaign := range resp.JSON200.Campaigns {
+		assert.NotEmpty(t, campaign.Id)
+		assert.NotEmpty(t, campaign.BuoyId)
+	}
+}
+
+func TestListCampaigns_GeoJSON(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.ListCampaignsWithResponse(context.Background(),
+		func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Accept", "application/geo+json")
+			return nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.NotNil(t, resp.ApplicationgeoJSON200)
+
+	assert.Equal(t, openapi.FeatureCollection, resp.ApplicationgeoJSON200.Type)
+	for _, feature := range resp.ApplicationgeoJSON200.Features {
+		assert.Equal(t, feature.Properties.Id, feature.Id)
+		assert.Equal(t, []float64{feature.Properties.Longitude, feature.Properties.Latitude}, feature.Geometry.Coordinates)
+	}
+}
+
+func TestGetCampaign_NotFound(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.GetCampaignWithResponse(context.Background(), "unknown-campaign")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
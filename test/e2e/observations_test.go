@@ -0,0 +1,105 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func TestListCampaignObservations(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	limit := 10
+	resp, err := openAPIClient.ListCampaignObservationsWithResponse(
+		context.Background(), "les-pierres-noires", &openapi.ListCampaignObservationsParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	assert.LessOrEqual(t, len(resp.JSON200.Observations), limit)
+}
+
+func TestListCampaignObservations_InvalidCursor(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	cursor := "!!!"
+	resp, err := openAPIClient.ListCampaignObservationsWithResponse(
+		context.Background(), "les-pierres-noires", &openapi.ListCampaignObservationsParams{Cursor: &cursor})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	assert.Equal(t, "invalid cursor", resp.JSON400.Error)
+}
+
+func TestGetCampaignStatistics(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	interval := openapi.GetCampaignStatisticsParamsIntervalWeek
+	fields := []openapi.GetCampaignStatisticsParamsFields{openapi.Hmax}
+	resp, err := openAPIClient.GetCampaignStatisticsWithResponse(context.Background(), "les-pierres-noires",
+		&openapi.GetCampaignStatisticsParams{Interval: &interval, Fields: &fields})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	assert.Equal(t, openapi.WaveDataStatisticsIntervalWeek, resp.JSON200.Interval)
+	for _, bucket := range resp.JSON200.Buckets {
+		assert.Nil(t, bucket.H13)
+	}
+}
+
+func TestExportCampaignObservations(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	format := openapi.Csv
+	resp, err := openAPIClient.ExportCampaignObservationsWithResponse(
+		context.Background(), "les-pierres-noires", &openapi.ExportCampaignObservationsParams{Format: &format})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	assert.Equal(t, "text/csv; charset=utf-8", resp.HTTPResponse.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(string(resp.Body), "Date,Heure (TU),H1/3 (m)"))
+}
+
+func TestGetCampaignLatestObservation(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.GetCampaignLatestObservationWithResponse(context.Background(), "les-pierres-noires")
+	require.NoError(t, err)
+	if resp.StatusCode() == http.StatusNotFound {
+		t.Skip("no observation scraped for les-pierres-noires")
+	}
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	latest := resp.JSON200
+	assert.Equal(t, latest.AgeSeconds > latest.StaleAfterSeconds, latest.Stale)
+}
+
+func TestGetCampaignLatestObservation_UnknownCampaign(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.GetCampaignLatestObservationWithResponse(context.Background(), "unknown-campaign")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
+
+func TestGetCampaignGaps(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	resp, err := openAPIClient.GetCampaignGapsWithResponse(
+		context.Background(), "les-pierres-noires", &openapi.GetCampaignGapsParams{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	report := resp.JSON200
+	assert.LessOrEqual(t, report.Observed, report.Expected)
+	missing := 0
+	for _, gap := range report.Gaps {
+		missing += gap.Missing
+	}
+	assert.Equal(t, report.Expected-report.Observed, missing)
+}
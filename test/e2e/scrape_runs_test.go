@@ -0,0 +1,32 @@
+package e2e_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/openapi"
+)
+
+func TestListScrapeRuns(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	limit := 5
+	resp, err := openAPIClient.ListScrapeRunsWithResponse(context.Background(), &openapi.ListScrapeRunsParams{Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	assert.LessOrEqual(t, len(resp.JSON200.ScrapeRuns), limit)
+}
+
+func TestListScrapeRuns_InvalidLimit(t *testing.T) {
+	openAPIClient := setupOpenAPIClient(t)
+
+	limit := 0
+	resp, err := openAPIClient.ListScrapeRunsWithResponse(context.Background(), &openapi.ListScrapeRunsParams{Limit: &limit})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
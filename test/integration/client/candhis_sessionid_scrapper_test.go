@@ -24,6 +24,19 @@ func TestGetCandhisSessionID_Success(t *testing.T) {
 	assert.NotEmpty(t, sessionID.ID())
 }
 
+func TestGetCandhisSessionID_HTTP_Success(t *testing.T) {
+	targetWeb := os.Getenv("TARGET_WEB")
+	require.NotEmpty(t, targetWeb, "TARGET_WEB must be set")
+
+	sessionScraper := client.NewCandhisSessionIDHTTPScraper(
+		client.NewCandhisHTTPClient(&http.Client{}, client.DefaultCandhisHTTPOptions()), targetWeb)
+
+	sessionID, err := sessionScraper.GetCandhisSessionID(context.Background())
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, sessionID.ID())
+}
+
 func setupCandhisSessionIDWebScraperClient(t *testing.T) repository.CandhisSessionIDWebScraper {
 	t.Helper()
 
@@ -33,7 +46,7 @@ func setupCandhisSessionIDWebScraperClient(t *testing.T) repository.CandhisSessi
 	targetWeb := os.Getenv("TARGET_WEB")
 	require.NotEmpty(t, targetWeb, "TARGET_WEB must be set")
 
-	chromeScraper, err := chrome.NewChromedpScraper(&http.Client{}, chromeURL)
+	chromeScraper, err := chrome.NewChromedpScraper(&http.Client{}, chromeURL, chrome.DefaultOptions())
 	require.NoError(t, err)
 
 	return client.NewCandhisSessionIDWebScraper(chromeScraper, targetWeb)
@@ -0,0 +1,100 @@
+package persistence_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence/persistencetest"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func TestAlertRuleStore_AddUpdateDelete(t *testing.T) {
+	alertRuleStore, _ := setupAlertTest(t)
+
+	rule := modeltest.MustCreateAlertRule(t, 0, "Big swell", "les-pierres-noires",
+		model.AlertMetricAverageTopThirdWaveHeight, model.AlertComparatorGreaterThanOrEqual, 4, 0, 6*time.Hour,
+		"https://example.com/hook", true)
+	added, err := alertRuleStore.Add(context.Background(), rule)
+	require.NoError(t, err)
+	assert.NotZero(t, added.ID())
+
+	got, err := alertRuleStore.Get(context.Background(), added.ID())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, added, *got)
+
+	updated := modeltest.MustCreateAlertRule(t, added.ID(), "Big swell", "les-pierres-noires", model.AlertMetricMaxHeight,
+		model.AlertComparatorGreaterThan, 6, 0, time.Hour, "https://example.com/hook", false)
+	require.NoError(t, alertRuleStore.Update(context.Background(), updated))
+
+	rules, err := alertRuleStore.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []model.AlertRule{updated}, rules)
+
+	require.NoError(t, alertRuleStore.Delete(context.Background(), added.ID()))
+	assert.ErrorIs(t, alertRuleStore.Delete(context.Background(), added.ID()), model.ErrAlertRuleNotFound)
+
+	got, err = alertRuleStore.Get(context.Background(), added.ID())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestAlertEventStore_LatestByRule(t *testing.T) {
+	alertRuleStore, alertEventStore := setupAlertTest(t)
+
+	rule, err := alertRuleStore.Add(context.Background(), modeltest.MustCreateAlertRule(t, 0, "Big swell",
+		"les-pierres-noires", model.AlertMetricAverageTopThirdWaveHeight, model.AlertComparatorGreaterThanOrEqual, 4, 0, 0,
+		"https://example.com/hook", true))
+	require.NoError(t, err)
+
+	firedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	firing := modeltest.MustCreateAlertEvent(t, rule.ID(), model.AlertStateFiring, 4.2, firedAt, firedAt, firedAt, 1, "")
+	resolved := modeltest.MustCreateAlertEvent(t, rule.ID(), model.AlertStateResolved, 3.1, firedAt.Add(time.Hour),
+		firedAt, firedAt.Add(time.Hour), 3, "unexpected status code 500, url: https://example.com/hook")
+	require.NoError(t, alertEventStore.Add(context.Background(), firing))
+	require.NoError(t, alertEventStore.Add(context.Background(), resolved))
+
+	latest, err := alertEventStore.LatestByRule(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]model.AlertEvent{rule.ID(): resolved}, latest)
+
+	events, err := alertEventStore.ListByRule(context.Background(), rule.ID(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.AlertEvent{resolved, firing}, events)
+}
+
+func setupAlertTest(t *testing.T) (repository.AlertRule, repository.AlertEvent) {
+	t.Helper()
+
+	host := os.Getenv("DATABASE_HOST")
+	require.NotEmpty(t, host)
+
+	port := os.Getenv("DATABASE_PORT")
+	require.NotEmpty(t, port)
+
+	user := os.Getenv("DATABASE_USER")
+	require.NotEmpty(t, user)
+
+	dbName := os.Getenv("DATABASE_NAME")
+	require.NotEmpty(t, dbName)
+
+	password := os.Getenv("DATABASE_PASSWORD")
+	require.NotEmpty(t, password)
+
+	dbConn, err := db.NewDBConnection(user, password, host, port, dbName, db.DefaultDBConnector, logger.NewWithDefaultLogger())
+	require.NoError(t, err, "failed to initialize database connection")
+
+	persistor := persistencetest.NewPersistor(t, dbConn.DB)
+	t.Cleanup(func() { persistor.Clear() })
+
+	return persistence.NewAlertRule(dbConn.DB), persistence.NewAlertEvent(dbConn.DB)
+}
@@ -0,0 +1,69 @@
+package persistence_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence/persistencetest"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func TestBackfillCheckpointStore_SaveAndGet(t *testing.T) {
+	checkpointStore := setupBackfillCheckpointTest(t)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	checkpoint, err := checkpointStore.Get(context.Background(), "02911", from, to)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	for _, doneUntil := range []time.Time{time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), to} {
+		saved, err := model.NewBackfillCheckpoint("02911", from, to, doneUntil)
+		require.NoError(t, err)
+		require.NoError(t, checkpointStore.Save(context.Background(), saved))
+
+		checkpoint, err = checkpointStore.Get(context.Background(), "02911", from, to)
+		require.NoError(t, err)
+		assert.Equal(t, &saved, checkpoint)
+	}
+
+	checkpoint, err = checkpointStore.Get(context.Background(), "05602", from, to)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func setupBackfillCheckpointTest(t *testing.T) repository.BackfillCheckpoint {
+	t.Helper()
+
+	host := os.Getenv("DATABASE_HOST")
+	require.NotEmpty(t, host)
+
+	port := os.Getenv("DATABASE_PORT")
+	require.NotEmpty(t, port)
+
+	user := os.Getenv("DATABASE_USER")
+	require.NotEmpty(t, user)
+
+	dbName := os.Getenv("DATABASE_NAME")
+	require.NotEmpty(t, dbName)
+
+	password := os.Getenv("DATABASE_PASSWORD")
+	require.NotEmpty(t, password)
+
+	dbConn, err := db.NewDBConnection(user, password, host, port, dbName, db.DefaultDBConnector, logger.NewWithDefaultLogger())
+	require.NoError(t, err, "failed to initialize database connection")
+
+	persistor := persistencetest.NewPersistor(t, dbConn.DB)
+	t.Cleanup(func() { persistor.Clear() })
+
+	return persistence.NewBackfillCheckpoint(dbConn.DB)
+}
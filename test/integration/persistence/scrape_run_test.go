@@ -0,0 +1,135 @@
+package persistence_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence/persistencetest"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func TestScrapeRunStore_ListRecent_Empty(t *testing.T) {
+	scrapeRunStore := setupScrapeRunTest(t)
+
+	runs, err := scrapeRunStore.ListRecent(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestScrapeRunStore_AddAndListRecent(t *testing.T) {
+	scrapeRunStore := setupScrapeRunTest(t)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 123456000, time.UTC)
+	sessionIDRun, err := model.NewScrapeRun(model.ScraperSessionID, "", startedAt, startedAt.Add(5*time.Second),
+		"failed to get session ID from web: timeout", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	campaignsRun, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt.Add(time.Minute),
+		startedAt.Add(time.Minute+2*time.Second), "",
+		model.ScrapeRunCounts{RowsSeen: 3, Parsed: 2, Rejected: 1, Indexed: 1, Unchanged: 1})
+	require.NoError(t, err)
+
+	require.NoError(t, scrapeRunStore.Add(context.Background(), sessionIDRun))
+	require.NoError(t, scrapeRunStore.Add(context.Background(), campaignsRun))
+
+	runs, err := scrapeRunStore.ListRecent(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ScrapeRun{campaignsRun, sessionIDRun}, runs)
+
+	runs, err = scrapeRunStore.ListRecent(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ScrapeRun{campaignsRun}, runs)
+}
+
+func TestScrapeRunStore_LastRunFinishedAt(t *testing.T) {
+	scrapeRunStore := setupScrapeRunTest(t)
+
+	lastFinishedAt, err := scrapeRunStore.LastRunFinishedAt(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.True(t, lastFinishedAt.IsZero())
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	campaignsRun, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt, startedAt.Add(2*time.Second), "",
+		model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	spectraRun, err := model.NewScrapeRun(model.ScraperSpectra, "02911", startedAt.Add(time.Minute),
+		startedAt.Add(2*time.Minute), "", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	otherCampaignRun, err := model.NewScrapeRun(model.ScraperCampaigns, "05602", startedAt.Add(time.Minute),
+		startedAt.Add(2*time.Minute), "", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	for _, run := range []model.ScrapeRun{campaignsRun, spectraRun, otherCampaignRun} {
+		require.NoError(t, scrapeRunStore.Add(context.Background(), run))
+	}
+
+	lastFinishedAt, err = scrapeRunStore.LastRunFinishedAt(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.Equal(t, campaignsRun.FinishedAt(), lastFinishedAt)
+}
+
+func TestScrapeRunStore_LastLayoutFingerprint(t *testing.T) {
+	scrapeRunStore := setupScrapeRunTest(t)
+
+	layoutFingerprint, err := scrapeRunStore.LastLayoutFingerprint(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.Empty(t, layoutFingerprint)
+
+	startedAt := time.Date(2024, 9, 17, 9, 0, 0, 0, time.UTC)
+	backfillRun, err := model.NewScrapeRun(model.ScraperBackfill, "02911", startedAt, startedAt.Add(time.Second), "",
+		model.ScrapeRunCounts{LayoutFingerprint: "0f4e5d6c7b8a9123"})
+	require.NoError(t, err)
+	campaignsRun, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt.Add(time.Minute),
+		startedAt.Add(time.Minute+time.Second), "", model.ScrapeRunCounts{LayoutFingerprint: "6c1b2f0e9d8a7b3c"})
+	require.NoError(t, err)
+	failedRun, err := model.NewScrapeRun(model.ScraperCampaigns, "02911", startedAt.Add(2*time.Minute),
+		startedAt.Add(2*time.Minute+time.Second), "candhis unavailable", model.ScrapeRunCounts{})
+	require.NoError(t, err)
+	otherCampaignRun, err := model.NewScrapeRun(model.ScraperCampaigns, "05602", startedAt.Add(3*time.Minute),
+		startedAt.Add(3*time.Minute+time.Second), "", model.ScrapeRunCounts{LayoutFingerprint: "9a8b7c6d5e4f3210"})
+	require.NoError(t, err)
+	for _, run := range []model.ScrapeRun{backfillRun, campaignsRun, failedRun, otherCampaignRun} {
+		require.NoError(t, scrapeRunStore.Add(context.Background(), run))
+	}
+
+	layoutFingerprint, err = scrapeRunStore.LastLayoutFingerprint(context.Background(), "02911",
+		model.ScraperCampaigns, model.ScraperBackfill)
+	require.NoError(t, err)
+	assert.Equal(t, "6c1b2f0e9d8a7b3c", layoutFingerprint)
+}
+
+func setupScrapeRunTest(t *testing.T) repository.ScrapeRun {
+	t.Helper()
+
+	host := os.Getenv("DATABASE_HOST")
+	require.NotEmpty(t, host)
+
+	port := os.Getenv("DATABASE_PORT")
+	require.NotEmpty(t, port)
+
+	user := os.Getenv("DATABASE_USER")
+	require.NotEmpty(t, user)
+
+	dbName := os.Getenv("DATABASE_NAME")
+	require.NotEmpty(t, dbName)
+
+	password := os.Getenv("DATABASE_PASSWORD")
+	require.NotEmpty(t, password)
+
+	dbConn, err := db.NewDBConnection(user, password, host, port, dbName, db.DefaultDBConnector, logger.NewWithDefaultLogger())
+	require.NoError(t, err, "failed to initialize database connection")
+
+	persistor := persistencetest.NewPersistor(t, dbConn.DB)
+	t.Cleanup(func() { persistor.Clear() })
+
+	return persistence.NewScrapeRun(dbConn.DB)
+}
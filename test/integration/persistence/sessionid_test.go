@@ -4,9 +4,11 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/model/modeltest"
 	"github.com/tul1/candhis_api/internal/application/repository"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
@@ -19,47 +21,81 @@ func TestSessionIDStore_Get_NotFound(t *testing.T) {
 	_, sessionIDStore := setupSessionIDTest(t)
 
 	_, err := sessionIDStore.Get(context.Background())
-	require.Error(t, err)
-
-	assert.EqualError(t, err, "no session ID found in database")
+	assert.ErrorIs(t, err, model.ErrNoValidCandhisSession)
 }
 
 func TestSessionIDStore_Get_Success(t *testing.T) {
 	persistor, sessionIDStore := setupSessionIDTest(t)
 
 	sessionID := modeltest.MustCreateCandhisSessionID(t, "some-session-id")
-	persistor.SessionID().Add(&sessionID)
+	persistor.SessionID().Add(modeltest.MustCreateCandhisSession(t, sessionID, model.CandhisSessionSourceScraper))
 
 	retrievedSessionID, err := sessionIDStore.Get(context.Background())
 	require.NoError(t, err)
 
 	assert.Equal(t, "some-session-id", retrievedSessionID.ID())
 	assert.Equal(t, sessionID.CreatedAt(), retrievedSessionID.CreatedAt())
+
+	lastUsedAt, invalidatedAt := persistor.SessionID().Session(sessionID)
+	assert.NotNil(t, lastUsedAt)
+	assert.Nil(t, invalidatedAt)
 }
 
-func TestSessionIDStore_Update_NotExistingCandhisSessionID(t *testing.T) {
-	_, sessionIDStore := setupSessionIDTest(t)
+func TestSessionIDStore_Get_NewestValid(t *testing.T) {
+	persistor, sessionIDStore := setupSessionIDTest(t)
 
-	sessionID := modeltest.MustCreateCandhisSessionID(t, "non-existing-session-id")
-	err := sessionIDStore.Update(context.Background(), sessionID)
-	assert.EqualError(t, err, "no session ID found to update")
+	expiredCreatedAt := time.Now().UTC().Add(-2 * model.CandhisSessionLifetime)
+	expiredSessionID, err := model.NewCandhisSessionID("expired-session-id", &expiredCreatedAt)
+	require.NoError(t, err)
+	olderCreatedAt := time.Now().UTC().Add(-2 * time.Hour)
+	olderSessionID, err := model.NewCandhisSessionID("older-session-id", &olderCreatedAt)
+	require.NoError(t, err)
+	invalidatedSessionID := modeltest.MustCreateCandhisSessionID(t, "invalidated-session-id")
+
+	for _, sessionID := range []model.CandhisSessionID{expiredSessionID, olderSessionID, invalidatedSessionID} {
+		persistor.SessionID().Add(modeltest.MustCreateCandhisSession(t, sessionID, model.CandhisSessionSourceScraper))
+	}
+	require.NoError(t, sessionIDStore.Invalidate(context.Background(), invalidatedSessionID))
+
+	retrievedSessionID, err := sessionIDStore.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "older-session-id", retrievedSessionID.ID())
 }
 
-func TestSessionIDStore_Update_Success(t *testing.T) {
-	persistor, sessionIDStore := setupSessionIDTest(t)
+func TestSessionIDStore_Add_Success(t *testing.T) {
+	_, sessionIDStore := setupSessionIDTest(t)
 
 	initialSessionID := modeltest.MustCreateCandhisSessionID(t, "initial-session-id")
-	persistor.SessionID().Add(&initialSessionID)
+	err := sessionIDStore.Add(context.Background(),
+		modeltest.MustCreateCandhisSession(t, initialSessionID, model.CandhisSessionSourceBootstrap))
+	require.NoError(t, err)
 
-	updatedSessionID := modeltest.MustCreateCandhisSessionID(t, "updated-session-id")
-	err := sessionIDStore.Update(context.Background(), updatedSessionID)
+	renewedSessionID := modeltest.MustCreateCandhisSessionID(t, "renewed-session-id")
+	err = sessionIDStore.Add(context.Background(),
+		modeltest.MustCreateCandhisSession(t, renewedSessionID, model.CandhisSessionSourceRenewal))
 	require.NoError(t, err)
 
 	retrievedSessionID, err := sessionIDStore.Get(context.Background())
 	require.NoError(t, err)
 
-	assert.Equal(t, "updated-session-id", retrievedSessionID.ID())
-	assert.Equal(t, updatedSessionID.CreatedAt(), retrievedSessionID.CreatedAt())
+	assert.Equal(t, "renewed-session-id", retrievedSessionID.ID())
+	assert.Equal(t, renewedSessionID.CreatedAt(), retrievedSessionID.CreatedAt())
+}
+
+func TestSessionIDStore_Invalidate_Success(t *testing.T) {
+	persistor, sessionIDStore := setupSessionIDTest(t)
+
+	sessionID := modeltest.MustCreateCandhisSessionID(t, "rejected-session-id")
+	persistor.SessionID().Add(modeltest.MustCreateCandhisSession(t, sessionID, model.CandhisSessionSourceScraper))
+
+	err := sessionIDStore.Invalidate(context.Background(), sessionID)
+	require.NoError(t, err)
+
+	_, invalidatedAt := persistor.SessionID().Session(sessionID)
+	assert.NotNil(t, invalidatedAt)
+
+	_, err = sessionIDStore.Get(context.Background())
+	assert.ErrorIs(t, err, model.ErrNoValidCandhisSession)
 }
 
 func setupSessionIDTest(t *testing.T) (*persistencetest.Persistor, repository.SessionID) {
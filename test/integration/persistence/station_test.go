@@ -0,0 +1,63 @@
+package persistence_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/application/model"
+	"github.com/tul1/candhis_api/internal/application/model/modeltest"
+	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence/persistencetest"
+	"github.com/tul1/candhis_api/internal/pkg/db"
+	"github.com/tul1/candhis_api/internal/pkg/logger"
+)
+
+func TestStationStore_UpsertBatchAndList(t *testing.T) {
+	stationStore := setupStationTest(t)
+	ctx := context.Background()
+
+	depth := 60.0
+	lesPierresNoires := modeltest.MustCreateStation(
+		t, "Y2FtcD0wMjkxMQ==", "Les Pierres Noires", 48.291, -4.968, &depth, "Cerema", true)
+	belleIle := modeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "", true)
+	require.NoError(t, stationStore.UpsertBatch(ctx, []model.Station{lesPierresNoires, belleIle}))
+
+	// Belle Ile goes out of service
+	belleIle = modeltest.MustCreateStation(t, "Y2FtcD0wNTYwMg==", "Belle Ile", 47.285, -3.285, nil, "DREAL Bretagne", false)
+	require.NoError(t, stationStore.UpsertBatch(ctx, []model.Station{belleIle}))
+
+	stations, err := stationStore.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Station{belleIle, lesPierresNoires}, stations)
+}
+
+func setupStationTest(t *testing.T) repository.Station {
+	t.Helper()
+
+	host := os.Getenv("DATABASE_HOST")
+	require.NotEmpty(t, host)
+
+	port := os.Getenv("DATABASE_PORT")
+	require.NotEmpty(t, port)
+
+	user := os.Getenv("DATABASE_USER")
+	require.NotEmpty(t, user)
+
+	dbName := os.Getenv("DATABASE_NAME")
+	require.NotEmpty(t, dbName)
+
+	password := os.Getenv("DATABASE_PASSWORD")
+	require.NotEmpty(t, password)
+
+	dbConn, err := db.NewDBConnection(user, password, host, port, dbName, db.DefaultDBConnector, logger.NewWithDefaultLogger())
+	require.NoError(t, err, "failed to initialize database connection")
+
+	persistor := persistencetest.NewPersistor(t, dbConn.DB)
+	t.Cleanup(func() { persistor.Clear() })
+
+	return persistence.NewStation(dbConn.DB)
+}
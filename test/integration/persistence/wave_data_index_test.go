@@ -0,0 +1,74 @@
+package persistence_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tul1/candhis_api/internal/domain/model"
+	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
+	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
+)
+
+func TestWaveDataIndexBootstrapper_Success(t *testing.T) {
+	ctx := context.Background()
+	es, bootstrapper := setupWaveDataIndexTest(t, "wave_data_index_test")
+
+	require.NoError(t, bootstrapper.Bootstrap(ctx, []string{"wave_data_index_test"}))
+
+	// The index created by the first write gets the explicit mappings of the template.
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	_, err := persistence.NewWaveData(es).AddBatch(ctx, []model.WaveData{waveData}, "wave_data_index_test")
+	require.NoError(t, err)
+
+	// Bootstrapping again, as every binary does at startup, finds no drift.
+	assert.NoError(t, bootstrapper.Bootstrap(ctx, []string{"wave_data_index_test"}))
+}
+
+func TestWaveDataIndexBootstrapper_MappingDrift(t *testing.T) {
+	ctx := context.Background()
+	es, bootstrapper := setupWaveDataIndexTest(t, "wave_data_index_drift_test")
+
+	// An index created before the template gets dynamic mappings.
+	req := esapi.IndexRequest{
+		Index:   "wave_data_index_drift_test",
+		Body:    strings.NewReader(`{"timestamp": "2024-09-17T09:00:00Z", "peak_direction": 8}`),
+		Refresh: "true",
+	}
+	res, err := req.Do(ctx, es)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	err = bootstrapper.Bootstrap(ctx, []string{"wave_data_index_drift_test"})
+	assert.ErrorIs(t, err, persistence.ErrIndexMappingDrift)
+}
+
+func setupWaveDataIndexTest(t *testing.T, indexName string) (*elasticsearch.Client, *persistence.WaveDataIndexBootstrapper) {
+	t.Helper()
+
+	esURL := os.Getenv("ELASTICSEARCH_URL")
+	require.NotEmpty(t, esURL, "ELASTICSEARCH_URL must be set")
+
+	es, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{esURL},
+	})
+	require.NoError(t, err, "failed to create Elasticsearch client")
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+		if res, err := (esapi.IndicesDeleteRequest{Index: []string{indexName}}).Do(ctx, es); err == nil {
+			res.Body.Close()
+		}
+		deleteTemplate := esapi.IndicesDeleteIndexTemplateRequest{Name: persistence.WaveDataIndexTemplateName}
+		if res, err := deleteTemplate.Do(ctx, es); err == nil {
+			res.Body.Close()
+		}
+	})
+
+	return es, persistence.NewWaveDataIndexBootstrapper(es, 1, 0)
+}
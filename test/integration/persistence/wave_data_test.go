@@ -4,33 +4,215 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appmodel "github.com/tul1/candhis_api/internal/application/model"
 	"github.com/tul1/candhis_api/internal/application/repository"
+	"github.com/tul1/candhis_api/internal/domain/model"
 	"github.com/tul1/candhis_api/internal/domain/model/modeltest"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence"
 	"github.com/tul1/candhis_api/internal/infrastructure/persistence/persistencetest"
 )
 
-func TestWaveData_Add_Success(t *testing.T) {
+func TestWaveData_AddBatch_Success(t *testing.T) {
 	ctx := context.Background()
 	persistor, waveDataStore := setupWaveDataTest(t)
 
 	waveData1 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
 	waveData2 := modeltest.MustCreateWaveData(t, "18/09/2024", "10:00", "0.8", "1.3", "5.0", "10", "35", "14")
 
-	err := waveDataStore.Add(ctx, waveData1, "wave_data_test")
+	result, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData1, waveData2}, "wave_data_test")
 	require.NoError(t, err)
-	err = waveDataStore.Add(ctx, waveData2, "wave_data_test")
+	assert.Equal(t, appmodel.BatchResult{Indexed: 2}, result)
+
+	// Indexing the same observations again leaves them untouched instead of duplicating them.
+	result, err = waveDataStore.AddBatch(ctx, []model.WaveData{waveData1, waveData2}, "wave_data_test")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{Unchanged: 2}, result)
+
+	retrievedWaveDataList := persistor.WaveData().List(ctx, "wave_data_test")
+	assert.ElementsMatch(t, []model.WaveData{waveData1, waveData2}, retrievedWaveDataList)
+}
+
+func TestWaveData_AddBatch_QCFlags(t *testing.T) {
+	ctx := context.Background()
+	persistor, waveDataStore := setupWaveDataTest(t)
+
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	flags := model.QCFlags{
+		GrossRange:  model.QCFlagPass,
+		Consistency: model.QCFlagPass,
+		Spike:       model.QCFlagNotEvaluated,
+		FlatLine:    model.QCFlagNotEvaluated,
+	}
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData}, "wave_data_test")
+	require.NoError(t, err)
+
+	// Checking an observation stored before quality control adds its flags.
+	result, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData.WithQCFlags(flags)}, "wave_data_test")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{Indexed: 1}, result)
+
+	retrievedWaveDataList := persistor.WaveData().List(ctx, "wave_data_test")
+	assert.Equal(t, []model.WaveData{waveData.WithQCFlags(flags)}, retrievedWaveDataList)
+}
+
+func TestWaveData_AddBatch_ClearsDroppedFields(t *testing.T) {
+	ctx := context.Background()
+	persistor, waveDataStore := setupWaveDataTest(t)
+
+	waveData := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	withMeasurement := modeltest.MustWithMeasurement(t, waveData, model.MeasurementPeakPeriod, "11.2")
+	flags := model.QCFlags{GrossRange: model.QCFlagPass, Consistency: model.QCFlagPass}
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{withMeasurement.WithQCFlags(flags)}, "wave_data_test")
+	require.NoError(t, err)
+
+	// A re-scrape no longer publishing the measurement, before quality control, replaces the stored observation.
+	result, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData}, "wave_data_test")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{Indexed: 1}, result)
+
+	result, err = waveDataStore.AddBatch(ctx, []model.WaveData{waveData}, "wave_data_test")
+	require.NoError(t, err)
+	assert.Equal(t, appmodel.BatchResult{Unchanged: 1}, result)
+
+	retrievedWaveDataList := persistor.WaveData().List(ctx, "wave_data_test")
+	assert.Equal(t, []model.WaveData{waveData}, retrievedWaveDataList)
+}
+
+func TestWaveData_List_Success(t *testing.T) {
+	ctx := context.Background()
+	_, waveDataStore := setupWaveDataTest(t)
+
+	waveData1 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	waveData2 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:30", "0.8", "1.3", "5.0", "10", "35", "14")
+	waveData3 := modeltest.MustCreateWaveData(t, "17/09/2024", "10:00", "0.7", "1.2", "4.9", "12", "30", "14")
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData1, waveData2, waveData3}, "wave_data_test")
+	require.NoError(t, err)
+
+	query, err := appmodel.NewWaveDataQuery(nil, nil, "", 2, appmodel.SortOrderAsc)
+	require.NoError(t, err)
+
+	firstPage, err := waveDataStore.List(ctx, "wave_data_test", query)
+	require.NoError(t, err)
+	assert.Equal(t, []model.WaveData{waveData1, waveData2}, firstPage.WaveData)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	query, err = appmodel.NewWaveDataQuery(nil, nil, firstPage.NextCursor, 2, appmodel.SortOrderAsc)
+	require.NoError(t, err)
+
+	secondPage, err := waveDataStore.List(ctx, "wave_data_test", query)
+	require.NoError(t, err)
+	assert.Equal(t, []model.WaveData{waveData3}, secondPage.WaveData)
+	assert.Empty(t, secondPage.NextCursor)
+}
+
+func TestWaveData_List_UnknownIndex(t *testing.T) {
+	_, waveDataStore := setupWaveDataTest(t)
+
+	query, err := appmodel.NewWaveDataQuery(nil, nil, "", 0, "")
+	require.NoError(t, err)
+
+	page, err := waveDataStore.List(context.Background(), "unknown_index_test", query)
+	require.NoError(t, err)
+	assert.Empty(t, page.WaveData)
+}
+
+func TestWaveData_Summaries_Success(t *testing.T) {
+	ctx := context.Background()
+	_, waveDataStore := setupWaveDataTest(t)
+
+	waveData1 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	waveData2 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:30", "0.8", "1.3", "5.0", "10", "35", "14")
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData1, waveData2}, "wave_data_test")
+	require.NoError(t, err)
+
+	summaries, err := waveDataStore.Summaries(ctx, []string{"wave_data_test", "unknown_index_test"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]appmodel.WaveDataSummary{
+		"wave_data_test": {Count: 2, First: waveData1.Timestamp().UTC(), Last: waveData2.Timestamp().UTC(), Latest: waveData2},
+	}, summaries)
+}
+
+func TestWaveData_Statistics_Success(t *testing.T) {
+	ctx := context.Background()
+	_, waveDataStore := setupWaveDataTest(t)
+
+	waveData1 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "350", "32", "14")
+	waveData2 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:30", "0.8", "1.3", "5.0", "10", "35", "15")
+	waveData3 := modeltest.MustCreateWaveData(t, "19/09/2024", "10:00", "0.7", "1.2", "4.9", "90", "30", "16")
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData1, waveData2, waveData3}, "wave_data_test")
+	require.NoError(t, err)
+
+	from := time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 9, 20, 0, 0, 0, 0, time.UTC)
+	query, err := appmodel.NewWaveDataStatisticsQuery(&from, &to, appmodel.StatisticsIntervalDay,
+		[]appmodel.StatisticsField{appmodel.StatisticsFieldMaxHeight},
+		[]appmodel.StatisticsMetric{appmodel.StatisticsMetricMin, appmodel.StatisticsMetricMax})
+	require.NoError(t, err)
+
+	buckets, err := waveDataStore.Statistics(ctx, "wave_data_test", query)
+	require.NoError(t, err)
+	require.Len(t, buckets, 3)
+
+	assert.Equal(t, 2, buckets[0].Count)
+	assert.Equal(t, map[appmodel.StatisticsMetric]float64{
+		appmodel.StatisticsMetricMin: 1.1, appmodel.StatisticsMetricMax: 1.3,
+	}, buckets[0].Values[appmodel.StatisticsFieldMaxHeight])
+	require.NotNil(t, buckets[0].PeakDirectionMean)
+	assert.InDelta(t, 0, *buckets[0].PeakDirectionMean, 1e-6)
+
+	// The day without observations is kept in the time series.
+	assert.Equal(t, 0, buckets[1].Count)
+	assert.Nil(t, buckets[1].PeakDirectionMean)
+
+	assert.Equal(t, 1, buckets[2].Count)
+	require.NotNil(t, buckets[2].PeakDirectionMean)
+	assert.InDelta(t, 90, *buckets[2].PeakDirectionMean, 1e-6)
+}
+
+type collectingExportWriter struct {
+	count    int
+	waveData []model.WaveData
+}
+
+func (w *collectingExportWriter) Begin(count int) error {
+	w.count = count
+	return nil
+}
+
+func (w *collectingExportWriter) Write(waveData model.WaveData) error {
+	w.waveData = append(w.waveData, waveData)
+	return nil
+}
+
+func (w *collectingExportWriter) End() error {
+	return nil
+}
+
+func TestWaveData_Export_Success(t *testing.T) {
+	ctx := context.Background()
+	_, waveDataStore := setupWaveDataTest(t)
+
+	waveData1 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:00", "0.6", "1.1", "4.7", "8", "32", "14")
+	waveData2 := modeltest.MustCreateWaveData(t, "17/09/2024", "09:30", "0.8", "1.3", "5.0", "10", "35", "14")
+	waveData3 := modeltest.MustCreateWaveData(t, "18/09/2024", "10:00", "0.7", "1.2", "4.9", "12", "30", "14")
+	_, err := waveDataStore.AddBatch(ctx, []model.WaveData{waveData3, waveData1, waveData2}, "wave_data_test")
+	require.NoError(t, err)
+
+	to := waveData2.Timestamp()
+	query, err := appmodel.NewWaveDataExportQuery(nil, &to)
 	require.NoError(t, err)
 
-	retrievedWaveDataList := persistor.WaveData().List(context.Background(), "wave_data_test")
+	writer := &collectingExportWriter{}
+	require.NoError(t, waveDataStore.Export(ctx, "wave_data_test", query, writer))
 
-	require.Len(t, retrievedWaveDataList, 2)
-	assert.Equal(t, retrievedWaveDataList[0], waveData1)
-	assert.Equal(t, retrievedWaveDataList[1], waveData2)
+	assert.Equal(t, 2, writer.count)
+	assert.Equal(t, []model.WaveData{waveData1, waveData2}, writer.waveData)
 }
 
 func setupWaveDataTest(t *testing.T) (*persistencetest.ESPersistor, repository.WaveData) {